@@ -3,7 +3,6 @@ package auction
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -597,10 +596,6 @@ func RecoverUnclaimedRPL(rp *rocketpool.RocketPool, lotIndex uint64, opts *bind.
 }
 
 // Get contracts
-var rocketAuctionManagerLock sync.Mutex
-
 func getRocketAuctionManager(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketAuctionManagerLock.Lock()
-	defer rocketAuctionManagerLock.Unlock()
 	return rp.GetContract("rocketAuctionManager", opts)
 }
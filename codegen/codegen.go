@@ -0,0 +1,475 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The network contracts wrappers are generated for when no names are given
+var DefaultContractNames = []string{
+	"rocketAuctionManager",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAONodeTrustedSettingsMembers",
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAONodeTrustedSettingsProposals",
+	"rocketDAONodeTrustedSettingsRewards",
+	"rocketDAOProposal",
+	"rocketDAOProtocol",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolProposals",
+	"rocketDAOProtocolSettingsAuction",
+	"rocketDAOProtocolSettingsDeposit",
+	"rocketDAOProtocolSettingsInflation",
+	"rocketDAOProtocolSettingsMinipool",
+	"rocketDAOProtocolSettingsNetwork",
+	"rocketDAOProtocolSettingsNode",
+	"rocketDAOProtocolSettingsProposals",
+	"rocketDAOProtocolSettingsRewards",
+	"rocketDAOProtocolSettingsSecurity",
+	"rocketDAOProtocolVerifier",
+	"rocketDAOSecurity",
+	"rocketDAOSecurityProposals",
+	"rocketDepositPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolDelegate",
+	"rocketMinipoolFactory",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPenalties",
+	"rocketNetworkPrices",
+	"rocketNetworkSnapshots",
+	"rocketNetworkVoting",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+}
+
+// Identifiers that generated parameters can't use
+var reservedParamNames = map[string]bool{
+	"c":       true,
+	"err":     true,
+	"mc":      true,
+	"opts":    true,
+	"out":     true,
+	"output":  true,
+	"results": true,
+	"tx":      true,
+}
+
+// A contract ABI to generate a wrapper for
+type ContractAbi struct {
+	Name string
+	ABI  *abi.ABI
+}
+
+// The template model for a contract wrapper
+type contractModel struct {
+	Package  string
+	Name     string
+	Type     string
+	Imports  []string
+	Calls    []methodModel
+	Transact []methodModel
+}
+
+// The template model for a contract method
+type methodModel struct {
+	Name       string
+	AbiName    string
+	Payable    bool
+	Params     []paramModel
+	Outputs    []paramModel
+	OutputType string
+	Multicall  bool
+}
+
+// The template model for a method parameter or output
+type paramModel struct {
+	Name string
+	Type string
+}
+
+// Load contract ABIs from RocketStorage at the block in the call options
+func LoadAbisFromStorage(rp *rocketpool.RocketPool, contractNames []string, opts *bind.CallOpts) ([]ContractAbi, error) {
+	abis, err := rp.GetABIs(opts, contractNames...)
+	if err != nil {
+		return nil, err
+	}
+	contractAbis := make([]ContractAbi, len(contractNames))
+	for i, contractName := range contractNames {
+		contractAbis[i] = ContractAbi{
+			Name: contractName,
+			ABI:  abis[i],
+		}
+	}
+	return contractAbis, nil
+}
+
+// Load contract ABIs from a directory of <contract name>.json files
+// Files can hold plain ABI arrays, or compiler artifacts with an "abi" field
+func LoadAbisFromDir(dir string, contractNames []string) ([]ContractAbi, error) {
+
+	// Get the file names
+	if len(contractNames) == 0 {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("error listing ABI files in %s: %w", dir, err)
+		}
+		for _, path := range paths {
+			contractNames = append(contractNames, strings.TrimSuffix(filepath.Base(path), ".json"))
+		}
+		sort.Strings(contractNames)
+	}
+
+	// Read the ABIs
+	contractAbis := make([]ContractAbi, 0, len(contractNames))
+	for _, contractName := range contractNames {
+		path := filepath.Join(dir, contractName+".json")
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading contract %s ABI: %w", contractName, err)
+		}
+		abiJson := bytes.TrimSpace(contents)
+		if len(abiJson) > 0 && abiJson[0] == '{' {
+			var artifact struct {
+				ABI json.RawMessage `json:"abi"`
+			}
+			if err := json.Unmarshal(abiJson, &artifact); err != nil {
+				return nil, fmt.Errorf("error decoding contract %s artifact: %w", contractName, err)
+			}
+			abiJson = artifact.ABI
+		}
+		contractAbi, err := abi.JSON(bytes.NewReader(abiJson))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing contract %s ABI: %w", contractName, err)
+		}
+		contractAbis = append(contractAbis, ContractAbi{
+			Name: lowerFirst(contractName),
+			ABI:  &contractAbi,
+		})
+	}
+	return contractAbis, nil
+
+}
+
+// Generate the wrapper files for a set of contracts, returning the paths of the files that were written
+func WriteWrappers(outputDir string, packageName string, contractAbis []ContractAbi) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating %s: %w", outputDir, err)
+	}
+	paths := make([]string, 0, len(contractAbis))
+	for _, contractAbi := range contractAbis {
+		source, err := GenerateWrapper(packageName, contractAbi)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(outputDir, contractAbi.Name+".go")
+		if err := os.WriteFile(path, source, 0644); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Generate the source of a typed wrapper for a contract
+func GenerateWrapper(packageName string, contractAbi ContractAbi) ([]byte, error) {
+
+	model := contractModel{
+		Package:  packageName,
+		Name:     contractAbi.Name,
+		Type:     abi.ToCamelCase(contractAbi.Name),
+		Calls:    []methodModel{},
+		Transact: []methodModel{},
+	}
+
+	// Sort the methods so the output is stable
+	methodNames := make([]string, 0, len(contractAbi.ABI.Methods))
+	for name := range contractAbi.ABI.Methods {
+		methodNames = append(methodNames, name)
+	}
+	sort.Strings(methodNames)
+
+	// Build the method models
+	goNames := map[string]string{
+		"Contract": "the contract binding",
+	}
+	for _, name := range methodNames {
+		method := contractAbi.ABI.Methods[name]
+		methodModel := methodModel{
+			Name:    abi.ToCamelCase(method.Name),
+			AbiName: method.Name,
+			Payable: method.IsPayable(),
+			Params:  getParams(method.Inputs),
+		}
+
+		// Make sure the wrapper methods don't collide
+		wrapperNames := []string{methodModel.Name}
+		if method.IsConstant() {
+			wrapperNames = append(wrapperNames, "Add"+methodModel.Name)
+		} else {
+			wrapperNames = append(wrapperNames, "Estimate"+methodModel.Name+"Gas")
+		}
+		for _, wrapperName := range wrapperNames {
+			if existing, exists := goNames[wrapperName]; exists {
+				return nil, fmt.Errorf("error generating contract %s wrapper: %s for method %s collides with %s", contractAbi.Name, wrapperName, method.Name, existing)
+			}
+			goNames[wrapperName] = "method " + method.Name
+		}
+
+		if !method.IsConstant() {
+			model.Transact = append(model.Transact, methodModel)
+			continue
+		}
+
+		// Get the outputs of calls
+		allNamed := true
+		for i, output := range method.Outputs {
+			field := abi.ToCamelCase(output.Name)
+			if output.Name == "" || !token.IsIdentifier(field) {
+				allNamed = false
+				field = fmt.Sprintf("Out%d", i)
+			}
+			methodModel.Outputs = append(methodModel.Outputs, paramModel{
+				Name: field,
+				Type: getGoType(output.Type),
+			})
+		}
+		switch len(methodModel.Outputs) {
+		case 0:
+		case 1:
+			methodModel.OutputType = methodModel.Outputs[0].Type
+			methodModel.Multicall = true
+		default:
+			// Multicall unpacks into a struct by output name, so it needs every output to be named
+			methodModel.OutputType = model.Type + methodModel.Name + "Output"
+			methodModel.Multicall = allNamed
+		}
+		model.Calls = append(model.Calls, methodModel)
+	}
+
+	// Render the wrapper
+	var source bytes.Buffer
+	if err := wrapperTemplate.Execute(&source, model); err != nil {
+		return nil, fmt.Errorf("error generating contract %s wrapper: %w", contractAbi.Name, err)
+	}
+
+	// Add the imports that the wrapper uses
+	imports := []string{
+		"\"fmt\"",
+		"",
+		"\"github.com/ethereum/go-ethereum/accounts/abi/bind\"",
+	}
+	body := source.String()
+	if strings.Contains(body, "big.") {
+		imports = append([]string{"\"math/big\""}, imports...)
+	}
+	if strings.Contains(body, "abi.ConvertType") {
+		imports = append(imports, "\"github.com/ethereum/go-ethereum/accounts/abi\"")
+	}
+	if strings.Contains(body, "common.") {
+		imports = append(imports, "\"github.com/ethereum/go-ethereum/common\"")
+	}
+	imports = append(imports, "", "\"github.com/rocket-pool/rocketpool-go/rocketpool\"")
+	if strings.Contains(body, "multicall.") {
+		imports = append(imports, "\"github.com/rocket-pool/rocketpool-go/utils/multicall\"")
+	}
+	body = strings.Replace(body, "import ()", "import (\n"+strings.Join(imports, "\n")+"\n)", 1)
+
+	// Format it
+	formatted, err := format.Source([]byte(body))
+	if err != nil {
+		return nil, fmt.Errorf("error formatting contract %s wrapper: %w", contractAbi.Name, err)
+	}
+	return formatted, nil
+
+}
+
+// Get the parameter models for method inputs
+func getParams(inputs abi.Arguments) []paramModel {
+	params := make([]paramModel, len(inputs))
+	used := map[string]bool{}
+	for i, input := range inputs {
+		name := lowerFirst(strings.TrimLeft(input.Name, "_"))
+		if name == "" || !token.IsIdentifier(name) || token.IsKeyword(name) || reservedParamNames[name] || used[name] {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		used[name] = true
+		params[i] = paramModel{
+			Name: name,
+			Type: getGoType(input.Type),
+		}
+	}
+	return params
+}
+
+// Get the Go type that go-ethereum packs and unpacks an ABI type as
+func getGoType(abiType abi.Type) string {
+	return formatType(abiType.GetType())
+}
+
+// Format a Go type as source, including anonymous tuple structs
+func formatType(goType reflect.Type) string {
+	switch goType.Kind() {
+	case reflect.Ptr:
+		return "*" + formatType(goType.Elem())
+	case reflect.Slice:
+		return "[]" + formatType(goType.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", goType.Len(), formatType(goType.Elem()))
+	case reflect.Struct:
+		if goType.Name() != "" {
+			return goType.String()
+		}
+		fields := make([]string, goType.NumField())
+		for i := 0; i < goType.NumField(); i++ {
+			field := goType.Field(i)
+			fields[i] = fmt.Sprintf("%s %s `%s`", field.Name, formatType(field.Type), field.Tag)
+		}
+		return "struct {\n" + strings.Join(fields, "\n") + "\n}"
+	default:
+		return goType.String()
+	}
+}
+
+// Lowercase the first letter of a name
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return name
+	}
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// Join parameters into a function signature
+func joinParams(params []paramModel) string {
+	joined := ""
+	for _, param := range params {
+		joined += param.Name + " " + param.Type + ", "
+	}
+	return joined
+}
+
+// Join parameters into call arguments
+func joinArgs(params []paramModel) string {
+	joined := ""
+	for _, param := range params {
+		joined += ", " + param.Name
+	}
+	return joined
+}
+
+var wrapperTemplate = template.Must(template.New("wrapper").Funcs(template.FuncMap{
+	"params": joinParams,
+	"args":   joinArgs,
+}).Parse(`// Code generated by rocketpool-go/codegen from the {{.Name}} ABI. DO NOT EDIT.
+
+package {{.Package}}
+
+import ()
+
+// The name of the {{.Name}} contract in RocketStorage
+const {{.Type}}Name string = "{{.Name}}"
+
+// Typed wrapper for the {{.Name}} contract
+type {{.Type}} struct {
+	Contract *rocketpool.Contract
+}
+{{range .Calls}}{{if gt (len .Outputs) 1}}
+// The outputs of {{$.Name}}.{{.AbiName}}
+type {{.OutputType}} struct {
+{{range .Outputs}}	{{.Name}} {{.Type}}
+{{end}}}
+{{end}}{{end}}
+// Get the {{.Name}} contract at the block in the call options
+func New{{.Type}}(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*{{.Type}}, error) {
+	contract, err := rp.GetContract({{.Type}}Name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{Contract: contract}, nil
+}
+
+// Wrap an existing {{.Name}} contract binding
+func New{{.Type}}FromContract(contract *rocketpool.Contract) *{{.Type}} {
+	return &{{.Type}}{Contract: contract}
+}
+{{range .Calls}}
+// Call {{.AbiName}}
+{{- if eq (len .Outputs) 0}}
+func (c *{{$.Type}}) {{.Name}}({{params .Params}}opts *bind.CallOpts) error {
+	results := []interface{}{}
+	if err := c.Contract.Contract.Call(opts, &results, "{{.AbiName}}"{{args .Params}}); err != nil {
+		return fmt.Errorf("error calling {{$.Name}}.{{.AbiName}}: %w", err)
+	}
+	return nil
+}
+{{- else if eq (len .Outputs) 1}}
+func (c *{{$.Type}}) {{.Name}}({{params .Params}}opts *bind.CallOpts) ({{.OutputType}}, error) {
+	out := new({{.OutputType}})
+	err := c.Contract.Call(opts, out, "{{.AbiName}}"{{args .Params}})
+	if err != nil {
+		err = fmt.Errorf("error calling {{$.Name}}.{{.AbiName}}: %w", err)
+	}
+	return *out, err
+}
+{{- else}}
+func (c *{{$.Type}}) {{.Name}}({{params .Params}}opts *bind.CallOpts) ({{.OutputType}}, error) {
+	out := {{.OutputType}}{}
+	results := []interface{}{}
+	if err := c.Contract.Contract.Call(opts, &results, "{{.AbiName}}"{{args .Params}}); err != nil {
+		return out, fmt.Errorf("error calling {{$.Name}}.{{.AbiName}}: %w", err)
+	}
+{{- range $i, $output := .Outputs}}
+	out.{{$output.Name}} = *abi.ConvertType(results[{{$i}}], new({{$output.Type}})).(*{{$output.Type}})
+{{- end}}
+	return out, nil
+}
+{{- end}}
+{{if .Multicall}}
+// Add a {{.AbiName}} call to a multicall
+func (c *{{$.Type}}) Add{{.Name}}(mc *multicall.MultiCaller, output *{{.OutputType}}{{range .Params}}, {{.Name}} {{.Type}}{{end}}) error {
+	return mc.AddCall(c.Contract, output, "{{.AbiName}}"{{args .Params}})
+}
+{{end}}{{end}}{{range .Transact}}
+// Estimate the gas of {{.AbiName}}
+func (c *{{$.Type}}) Estimate{{.Name}}Gas({{params .Params}}opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return c.Contract.GetTransactionGasInfo(opts, "{{.AbiName}}"{{args .Params}})
+}
+
+// Send a {{.AbiName}} transaction{{if .Payable}}, with the value in the transactor{{end}}
+func (c *{{$.Type}}) {{.Name}}({{params .Params}}opts *bind.TransactOpts) (common.Hash, error) {
+	tx, err := c.Contract.Transact(opts, "{{.AbiName}}"{{args .Params}})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error sending {{$.Name}}.{{.AbiName}} transaction: %w", err)
+	}
+	return tx.Hash(), nil
+}
+{{end}}`))
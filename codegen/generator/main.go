@@ -0,0 +1,101 @@
+// Generates typed Go wrappers for Rocket Pool contracts.
+//
+// ABIs are read from RocketStorage on a live network:
+//
+//	go run ./codegen/generator -eth1 http://localhost:8545 -storage 0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46 -out ./bindings
+//
+// or from a local directory of <contract name>.json ABI files or compiler artifacts:
+//
+//	go run ./codegen/generator -abis ./abis -out ./bindings
+//
+// Each contract gets a <contract name>.go file with call, multicall, gas estimate and transaction methods.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/rocketpool-go/codegen"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+func main() {
+
+	// Parse the arguments
+	eth1Url := flag.String("eth1", "", "The execution client to read ABIs from RocketStorage with")
+	storageAddress := flag.String("storage", "", "The RocketStorage address")
+	blockNumber := flag.Uint64("block", 0, "The block to read ABIs at (default: latest)")
+	abiDir := flag.String("abis", "", "A directory of <contract name>.json ABI files to read instead of RocketStorage")
+	contractList := flag.String("contracts", "", "A comma-separated list of the contracts to generate wrappers for (default: all of them)")
+	outputDir := flag.String("out", "bindings", "The directory to write the wrappers to")
+	packageName := flag.String("package", "", "The package name of the wrappers (default: the output directory's name)")
+	flag.Parse()
+	if (*eth1Url == "") == (*abiDir == "") || (*eth1Url != "" && *storageAddress == "") {
+		fmt.Println("Either -eth1 and -storage, or -abis, must be provided.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if *packageName == "" {
+		*packageName = filepath.Base(*outputDir)
+	}
+	contractNames := []string{}
+	for _, contractName := range strings.Split(*contractList, ",") {
+		if contractName = strings.TrimSpace(contractName); contractName != "" {
+			contractNames = append(contractNames, contractName)
+		}
+	}
+
+	// Load the ABIs
+	var contractAbis []codegen.ContractAbi
+	var err error
+	if *abiDir != "" {
+		contractAbis, err = codegen.LoadAbisFromDir(*abiDir, contractNames)
+	} else {
+		contractAbis, err = loadAbisFromStorage(*eth1Url, common.HexToAddress(*storageAddress), *blockNumber, contractNames)
+	}
+	if err != nil {
+		fmt.Printf("Error loading ABIs: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Write the wrappers
+	paths, err := codegen.WriteWrappers(*outputDir, *packageName, contractAbis)
+	if err != nil {
+		fmt.Printf("Error generating wrappers: %s\n", err.Error())
+		os.Exit(1)
+	}
+	for _, path := range paths {
+		fmt.Printf("Wrote %s\n", path)
+	}
+
+}
+
+// Read ABIs from RocketStorage
+func loadAbisFromStorage(eth1Url string, storageAddress common.Address, blockNumber uint64, contractNames []string) ([]codegen.ContractAbi, error) {
+	rpcClient, err := rpc.Dial(eth1Url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", eth1Url, err)
+	}
+	rp, err := rocketpool.NewRocketPool(ethclient.NewClient(rpcClient), storageAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contractNames) == 0 {
+		contractNames = codegen.DefaultContractNames
+	}
+	var opts *bind.CallOpts
+	if blockNumber != 0 {
+		opts = &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	}
+	return codegen.LoadAbisFromStorage(rp, contractNames, opts)
+}
@@ -2,7 +2,6 @@ package dao
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -21,10 +20,6 @@ func GetContractExists(rp *rocketpool.RocketPool, contractName string, opts *bin
 }
 
 // Get contracts
-var rocketClaimDAOLock sync.Mutex
-
 func getRocketClaimDAO(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketClaimDAOLock.Lock()
-	defer rocketClaimDAOLock.Unlock()
 	return rp.GetContract("rocketClaimDAO", opts)
 }
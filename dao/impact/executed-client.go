@@ -0,0 +1,124 @@
+package impact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+// Get an execution client whose multicalls read the state after a proposal payload is executed
+// Clients that support tracing run the reads on top of the payload's state changes; other clients run the payload and the reads
+// together in a single eth_call, with the multicall contract's code placed at the executing contract so the payload is run as it
+func getExecutedClient(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, executor common.Address, target common.Address, proposalPayload []byte) (rocketpool.ExecutionClient, error) {
+	if tracer, ok := rp.Client.(rocketpool.CallTracer); ok {
+		changes, err := tracer.TraceStateChanges(context.Background(), ethereum.CallMsg{
+			From:     executor,
+			To:       &target,
+			Gas:      rocketpool.MaxGasLimit,
+			GasPrice: big.NewInt(0),
+			Data:     proposalPayload,
+		}, contracts.ElBlockNumber, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the state changes of the proposal: %w", err)
+		}
+		return rocketpool.NewOverrideClient(rp.Client, changes), nil
+	}
+
+	// Fall back to eth_call with state overrides
+	multicallCode, err := rp.Client.CodeAt(context.Background(), contracts.Multicaller.ContractAddress, contracts.ElBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the multicall contract code: %w", err)
+	}
+	if len(multicallCode) == 0 {
+		return nil, fmt.Errorf("the multicall contract at %s has no code", contracts.Multicaller.ContractAddress.Hex())
+	}
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall.MulticallABI))
+	if err != nil {
+		return nil, err
+	}
+	return &executedClient{
+		ExecutionClient: rocketpool.NewOverrideClient(rp.Client, rocketpool.StateOverrides{
+			executor: {Code: multicallCode},
+		}),
+		multicaller:  contracts.Multicaller.ContractAddress,
+		multicallAbi: multicallAbi,
+		executor:     executor,
+		target:       target,
+		payload:      proposalPayload,
+	}, nil
+}
+
+// An execution client that runs a proposal payload at the start of every multicall, in the same eth_call
+// The multicalls are sent to the executing contract, which has the multicall contract's code
+type executedClient struct {
+	rocketpool.ExecutionClient
+	multicaller  common.Address
+	multicallAbi abi.ABI
+	executor     common.Address
+	target       common.Address
+	payload      []byte
+}
+
+// A single multicall result
+type multicallResult struct {
+	Success    bool   `json:"success"`
+	ReturnData []byte `json:"returnData"`
+}
+
+func (c *executedClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method := c.multicallAbi.Methods["tryAggregate"]
+	if call.To == nil || *call.To != c.multicaller || len(call.Data) < 4 || !bytes.Equal(call.Data[:4], method.ID) {
+		return nil, fmt.Errorf("the state after a simulated proposal can only be read with multicall")
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding multicall: %w", err)
+	}
+	requireSuccess := args[0].(bool)
+	calls := *abi.ConvertType(args[1], new([]multicall.MultiCall)).(*[]multicall.MultiCall)
+
+	// Run the payload first, then the reads on top of it
+	calls = append([]multicall.MultiCall{{Target: c.target, CallData: c.payload}}, calls...)
+	callData, err := c.multicallAbi.Pack("tryAggregate", false, calls)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding multicall: %w", err)
+	}
+	output, err := c.ExecutionClient.CallContract(ctx, ethereum.CallMsg{
+		From: call.From,
+		To:   &c.executor,
+		Gas:  call.Gas,
+		Data: callData,
+	}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	var results []multicallResult
+	if err := c.multicallAbi.UnpackIntoInterface(&results, "tryAggregate", output); err != nil {
+		return nil, fmt.Errorf("error decoding multicall results: %w", err)
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("received %d multicall results for %d calls", len(results), len(calls))
+	}
+	if !results[0].Success {
+		return nil, fmt.Errorf("the proposal payload reverted when run with the reads")
+	}
+	results = results[1:]
+	if requireSuccess {
+		for _, result := range results {
+			if !result.Success {
+				return nil, fmt.Errorf("execution reverted: Multicall2 aggregate: call failed")
+			}
+		}
+	}
+	return method.Outputs.Pack(results)
+}
@@ -0,0 +1,301 @@
+package impact
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol/payload"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+// The contracts that execute each DAO's proposals, and the contracts their payloads are run against
+const (
+	protocolDaoExecutor  string = "rocketDAOProtocolProposal"
+	protocolDaoProposals string = "rocketDAOProtocolProposals"
+	oracleDaoExecutor    string = "rocketDAOProposal"
+	oracleDaoProposals   string = "rocketDAONodeTrustedProposals"
+)
+
+// Bonded and borrowed ETH of 8 and 16 ETH minipools, used for the RPL stake bounds
+var (
+	bond8      = eth.EthToWei(8)
+	borrowed8  = eth.EthToWei(24)
+	bond16     = eth.EthToWei(16)
+	borrowed16 = eth.EthToWei(16)
+)
+
+// Network values that are derived from the settings and the network's state
+type DerivedValues struct {
+	// Node fee range
+	NodeFee        *big.Int `json:"nodeFee"` // The fee new minipools currently get
+	MinimumNodeFee *big.Int `json:"minimumNodeFee"`
+	MaximumNodeFee *big.Int `json:"maximumNodeFee"`
+
+	// Deposit pool limits
+	DepositPoolBalance     *big.Int `json:"depositPoolBalance"`
+	MaximumDepositPoolSize *big.Int `json:"maximumDepositPoolSize"`
+	MaximumDepositAmount   *big.Int `json:"maximumDepositAmount"` // The most that can currently be deposited into the pool
+	MinimumDeposit         *big.Int `json:"minimumDeposit"`
+
+	// RPL collateral bounds, in RPL
+	RplPrice                     *big.Int `json:"rplPrice"`
+	MinimumRplStake8EthMinipool  *big.Int `json:"minimumRplStake8EthMinipool"`
+	MaximumRplStake8EthMinipool  *big.Int `json:"maximumRplStake8EthMinipool"`
+	MinimumRplStake16EthMinipool *big.Int `json:"minimumRplStake16EthMinipool"`
+	MaximumRplStake16EthMinipool *big.Int `json:"maximumRplStake16EthMinipool"`
+}
+
+// What a proposal would change if it were executed at a block
+type ProposalImpact struct {
+	Dao         settings.Dao                 `json:"dao"`
+	BlockNumber uint64                       `json:"blockNumber"`
+	Action      *payload.ProposalAction      `json:"action,omitempty"` // The decoded payload; only set for Protocol DAO proposals
+	Simulation  *rocketpool.SimulationResult `json:"simulation"`
+
+	// The state before and after execution; the after state is only set if execution succeeds
+	Before       *state.SettingsDetails `json:"before"`
+	After        *state.SettingsDetails `json:"after,omitempty"`
+	BeforeValues *DerivedValues         `json:"beforeValues"`
+	AfterValues  *DerivedValues         `json:"afterValues,omitempty"`
+
+	// The settings and derived values the proposal changes
+	Changes []state.Change `json:"changes"`
+}
+
+// Get the impact of executing a Protocol DAO proposal at the block the network contracts were created for
+func GetProtocolProposalImpact(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, proposalId uint64) (*ProposalImpact, error) {
+	proposalPayload, err := protocol.GetProposalPayload(rp, proposalId, &bind.CallOpts{BlockNumber: contracts.ElBlockNumber})
+	if err != nil {
+		return nil, err
+	}
+	return SimulateProtocolProposal(rp, contracts, proposalPayload)
+}
+
+// Get the impact of executing an Oracle DAO proposal at the block the network contracts were created for
+func GetOracleDaoProposalImpact(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, proposalId uint64) (*ProposalImpact, error) {
+	proposalPayload, err := dao.GetProposalPayload(rp, proposalId, &bind.CallOpts{BlockNumber: contracts.ElBlockNumber})
+	if err != nil {
+		return nil, err
+	}
+	return SimulateOracleDaoProposal(rp, contracts, proposalPayload)
+}
+
+// Simulate the execution of a Protocol DAO proposal payload and get its impact
+func SimulateProtocolProposal(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, proposalPayload []byte) (*ProposalImpact, error) {
+	impact, err := simulateProposal(rp, contracts, settings.DaoProtocol, protocolDaoExecutor, protocolDaoProposals, proposalPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Payloads that don't decode still get simulated, they just have no action
+	action, err := payload.DecodeProposalPayload(rp, proposalPayload)
+	if err == nil {
+		if err := action.LoadOldValues(rp, &bind.CallOpts{BlockNumber: contracts.ElBlockNumber}); err != nil {
+			return nil, err
+		}
+		impact.Action = action
+	}
+	return impact, nil
+}
+
+// Simulate the execution of an Oracle DAO proposal payload and get its impact
+func SimulateOracleDaoProposal(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, proposalPayload []byte) (*ProposalImpact, error) {
+	return simulateProposal(rp, contracts, settings.DaoOracle, oracleDaoExecutor, oracleDaoProposals, proposalPayload)
+}
+
+// Simulate a proposal payload being executed by a DAO's proposal contract, then read the settings and derived values
+// on top of the state changes it makes
+func simulateProposal(rp *rocketpool.RocketPool, contracts *state.NetworkContracts, daoType settings.Dao, executorName string, targetName string, proposalPayload []byte) (*ProposalImpact, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	executor, err := rp.GetContract(executorName, opts)
+	if err != nil {
+		return nil, err
+	}
+	target, err := rp.GetContract(targetName, opts)
+	if err != nil {
+		return nil, err
+	}
+	impact := &ProposalImpact{
+		Dao:         daoType,
+		BlockNumber: contracts.ElBlockNumber.Uint64(),
+		Changes:     []state.Change{},
+	}
+
+	// Get the state before execution
+	impact.Before, err = state.NewSettingsDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting settings before execution: %w", err)
+	}
+	impact.BeforeValues, err = getDerivedValues(contracts, impact.Before)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network values before execution: %w", err)
+	}
+
+	// Run the payload as the executing contract
+	impact.Simulation, err = target.SimulateData(&bind.TransactOpts{From: *executor.Address}, contracts.ElBlockNumber, nil, proposalPayload)
+	if err != nil {
+		return nil, err
+	}
+	if !impact.Simulation.Success {
+		return impact, nil
+	}
+
+	// Read the state after execution through a multicaller that runs on top of the changes
+	afterClient, err := getExecutedClient(rp, contracts, *executor.Address, *target.Address, proposalPayload)
+	if err != nil {
+		return nil, err
+	}
+	afterContracts := *contracts
+	afterContracts.Multicaller, err = multicall.NewMultiCaller(afterClient, contracts.Multicaller.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	impact.After, err = state.NewSettingsDetails(rp, &afterContracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting settings after execution: %w", err)
+	}
+	impact.AfterValues, err = getDerivedValues(&afterContracts, impact.After)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network values after execution: %w", err)
+	}
+
+	impact.Changes = append(impact.Changes, state.DiffSettingsDetails(impact.Before, impact.After)...)
+	impact.Changes = append(impact.Changes, diffDerivedValues(impact.BeforeValues, impact.AfterValues)...)
+	return impact, nil
+}
+
+// Get the derived network values, using a settings snapshot taken at the same point
+func getDerivedValues(contracts *state.NetworkContracts, details *state.SettingsDetails) (*DerivedValues, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	values := &DerivedValues{
+		MinimumNodeFee:         details.Network.MinimumNodeFee.Raw,
+		MaximumNodeFee:         details.Network.MaximumNodeFee.Raw,
+		MaximumDepositPoolSize: details.Deposit.MaximumDepositPoolSize.Raw,
+		MinimumDeposit:         details.Deposit.MinimumDeposit.Raw,
+	}
+
+	// Calls are allowed to fail so a reverting getter doesn't hide the rest of the report
+	if err := contracts.Multicaller.AddCall(contracts.RocketNetworkFees, &values.NodeFee, "getNodeFee"); err != nil {
+		return nil, err
+	}
+	if err := contracts.Multicaller.AddCall(contracts.RocketDepositPool, &values.DepositPoolBalance, "getBalance"); err != nil {
+		return nil, err
+	}
+	if err := contracts.Multicaller.AddCall(contracts.RocketDepositPool, &values.MaximumDepositAmount, "getMaximumDepositAmount"); err != nil {
+		return nil, err
+	}
+	if err := contracts.Multicaller.AddCall(contracts.RocketNetworkPrices, &values.RplPrice, "getRPLPrice"); err != nil {
+		return nil, err
+	}
+	_, err := contracts.Multicaller.FlexibleCall(false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error executing multicall: %w", err)
+	}
+
+	// The minimum stake is based on borrowed ETH and the maximum on bonded ETH
+	minimumStake := details.Node.MinimumPerMinipoolStake.Raw
+	maximumStake := details.Node.MaximumPerMinipoolStake.Raw
+	values.MinimumRplStake8EthMinipool = getRplAmount(borrowed8, minimumStake, values.RplPrice)
+	values.MaximumRplStake8EthMinipool = getRplAmount(bond8, maximumStake, values.RplPrice)
+	values.MinimumRplStake16EthMinipool = getRplAmount(borrowed16, minimumStake, values.RplPrice)
+	values.MaximumRplStake16EthMinipool = getRplAmount(bond16, maximumStake, values.RplPrice)
+	return values, nil
+}
+
+// Get the amount of RPL worth a fraction of an amount of ETH
+func getRplAmount(ethAmount *big.Int, fraction *big.Int, rplPrice *big.Int) *big.Int {
+	if fraction == nil || rplPrice == nil || rplPrice.Sign() == 0 {
+		return nil
+	}
+	amount := new(big.Int).Mul(ethAmount, fraction)
+	return amount.Div(amount, rplPrice)
+}
+
+// Get the changes between two sets of derived values
+func diffDerivedValues(before *DerivedValues, after *DerivedValues) []state.Change {
+	fields := []struct {
+		name   string
+		before *big.Int
+		after  *big.Int
+	}{
+		{"NodeFee", before.NodeFee, after.NodeFee},
+		{"MinimumNodeFee", before.MinimumNodeFee, after.MinimumNodeFee},
+		{"MaximumNodeFee", before.MaximumNodeFee, after.MaximumNodeFee},
+		{"DepositPoolBalance", before.DepositPoolBalance, after.DepositPoolBalance},
+		{"MaximumDepositPoolSize", before.MaximumDepositPoolSize, after.MaximumDepositPoolSize},
+		{"MaximumDepositAmount", before.MaximumDepositAmount, after.MaximumDepositAmount},
+		{"MinimumDeposit", before.MinimumDeposit, after.MinimumDeposit},
+		{"RplPrice", before.RplPrice, after.RplPrice},
+		{"MinimumRplStake8EthMinipool", before.MinimumRplStake8EthMinipool, after.MinimumRplStake8EthMinipool},
+		{"MaximumRplStake8EthMinipool", before.MaximumRplStake8EthMinipool, after.MaximumRplStake8EthMinipool},
+		{"MinimumRplStake16EthMinipool", before.MinimumRplStake16EthMinipool, after.MinimumRplStake16EthMinipool},
+		{"MaximumRplStake16EthMinipool", before.MaximumRplStake16EthMinipool, after.MaximumRplStake16EthMinipool},
+	}
+	changes := []state.Change{}
+	for _, field := range fields {
+		if field.before == nil && field.after == nil {
+			continue
+		}
+		if field.before != nil && field.after != nil && field.before.Cmp(field.after) == 0 {
+			continue
+		}
+		changes = append(changes, state.Change{
+			Entity:   state.EntityNetwork,
+			Type:     state.ChangeModified,
+			Field:    field.name,
+			OldValue: field.before,
+			NewValue: field.after,
+		})
+	}
+	return changes
+}
+
+// Get a human-readable report of the proposal's impact, one line per change
+func (i *ProposalImpact) String() string {
+	if !i.Simulation.Success {
+		return fmt.Sprintf("The proposal would revert at block %d: %s", i.BlockNumber, i.Simulation.RevertReason)
+	}
+	if len(i.Changes) == 0 {
+		return fmt.Sprintf("The proposal wouldn't change any settings or network values at block %d", i.BlockNumber)
+	}
+	lines := make([]string, len(i.Changes))
+	for j, change := range i.Changes {
+		lines[j] = fmt.Sprintf("%s.%s: %s -> %s", change.Entity, change.Field, formatValue(change.OldValue), formatValue(change.NewValue))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format a changed value, showing setting values by their converted value
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case state.DurationSetting:
+		return v.Value.String()
+	case state.FractionSetting:
+		return fmt.Sprintf("%.4f", v.Value)
+	case state.EtherSetting:
+		return fmt.Sprintf("%.6f", v.Value)
+	case state.TimeSetting:
+		return v.Value.UTC().String()
+	case state.CountSetting:
+		return fmt.Sprint(v.Value)
+	case *big.Int:
+		if v == nil {
+			return "(unknown)"
+		}
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -15,14 +14,8 @@ import (
 )
 
 // Get the string representation of a proposal payload
-var getProposalPayloadStringLock sync.Mutex
-
 func GetProposalPayloadString(rp *rocketpool.RocketPool, daoName string, payload []byte, opts *bind.CallOpts) (string, error) {
 
-	// Lock while getting proposal payload string
-	getProposalPayloadStringLock.Lock()
-	defer getProposalPayloadStringLock.Unlock()
-
 	// Get proposal DAO contract ABI
 	daoContractAbi, err := rp.GetABI(daoName, opts)
 	if err != nil {
@@ -3,7 +3,6 @@ package dao
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -638,10 +637,6 @@ func GetProposalMemberSupported(rp *rocketpool.RocketPool, proposalId uint64, me
 }
 
 // Get contracts
-var rocketDAOProposalLock sync.Mutex
-
 func getRocketDAOProposal(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOProposalLock.Lock()
-	defer rocketDAOProposalLock.Unlock()
 	return rp.GetContract("rocketDAOProposal", opts)
 }
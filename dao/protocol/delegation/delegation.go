@@ -0,0 +1,334 @@
+package delegation
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const (
+	VotingInitializedBatchSize = 250
+)
+
+// A node's voting power and delegate
+type NodeDelegation struct {
+	NodeAddress       common.Address `json:"nodeAddress"`
+	VotingPower       *big.Int       `json:"votingPower"`
+	Delegate          common.Address `json:"delegate"`
+	VotingInitialized bool           `json:"votingInitialized"`
+}
+
+// A node that other nodes (or itself) have delegated their voting power to
+type DelegateInfo struct {
+	Address              common.Address   `json:"address"`
+	DelegatedVotingPower *big.Int         `json:"delegatedVotingPower"` // Includes the delegate's own voting power if it's self-delegated
+	OwnVotingPower       *big.Int         `json:"ownVotingPower"`       // Zero unless the delegate is self-delegated
+	DelegatorCount       uint64           `json:"delegatorCount"`       // Doesn't include the delegate itself
+	Delegators           []common.Address `json:"delegators"`
+	IsSelfDelegated      bool             `json:"isSelfDelegated"`
+	SelfDelegationRatio  float64          `json:"selfDelegationRatio"` // The share of the delegated voting power that's the delegate's own
+	VotingPowerShare     float64          `json:"votingPowerShare"`    // The share of the network's total voting power
+}
+
+// The voting delegation graph at a block
+type DelegationGraph struct {
+	BlockNumber            uint32           `json:"blockNumber"`
+	TotalVotingPower       *big.Int         `json:"totalVotingPower"`
+	NodeCount              uint64           `json:"nodeCount"`
+	SelfDelegatedNodeCount uint64           `json:"selfDelegatedNodeCount"`
+	Nodes                  []NodeDelegation `json:"nodes"`
+	Delegates              []DelegateInfo   `json:"delegates"` // Sorted by delegated voting power, highest first
+	UninitializedNodes     []common.Address `json:"uninitializedNodes"`
+}
+
+// A change to a node's delegation between two blocks
+type NodeChange struct {
+	NodeAddress          common.Address `json:"nodeAddress"`
+	IsNew                bool           `json:"isNew"`
+	OldDelegate          common.Address `json:"oldDelegate"`
+	NewDelegate          common.Address `json:"newDelegate"`
+	OldVotingPower       *big.Int       `json:"oldVotingPower"`
+	NewVotingPower       *big.Int       `json:"newVotingPower"`
+	OldVotingInitialized bool           `json:"oldVotingInitialized"`
+	NewVotingInitialized bool           `json:"newVotingInitialized"`
+}
+
+// A change to a delegate between two blocks
+type DelegateChange struct {
+	Address                 common.Address   `json:"address"`
+	OldDelegatedVotingPower *big.Int         `json:"oldDelegatedVotingPower"`
+	NewDelegatedVotingPower *big.Int         `json:"newDelegatedVotingPower"`
+	OldDelegatorCount       uint64           `json:"oldDelegatorCount"`
+	NewDelegatorCount       uint64           `json:"newDelegatorCount"`
+	GainedDelegators        []common.Address `json:"gainedDelegators"`
+	LostDelegators          []common.Address `json:"lostDelegators"`
+}
+
+// The changes to the delegation graph between two blocks
+type DelegationDiff struct {
+	FromBlock           uint32           `json:"fromBlock"`
+	ToBlock             uint32           `json:"toBlock"`
+	OldTotalVotingPower *big.Int         `json:"oldTotalVotingPower"`
+	NewTotalVotingPower *big.Int         `json:"newTotalVotingPower"`
+	NodeChanges         []NodeChange     `json:"nodeChanges"`
+	DelegateChanges     []DelegateChange `json:"delegateChanges"`
+}
+
+// Get the voting delegation graph at a block
+// If the call options don't have a block number, they're pinned to the given block so voting initialization is read from it too
+func GetDelegationGraph(rp *rocketpool.RocketPool, blockNumber uint32, multicallAddress common.Address, opts *bind.CallOpts) (*DelegationGraph, error) {
+	if opts == nil || opts.BlockNumber == nil {
+		pinnedOpts := bind.CallOpts{}
+		if opts != nil {
+			pinnedOpts = *opts
+		}
+		pinnedOpts.BlockNumber = new(big.Int).SetUint64(uint64(blockNumber))
+		opts = &pinnedOpts
+	}
+
+	// Get the voting power and delegates
+	votingInfos, err := network.GetNodeInfoSnapshotFast(rp, blockNumber, multicallAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting voting snapshot for block %d: %w", blockNumber, err)
+	}
+	nodes := make([]NodeDelegation, len(votingInfos))
+	for i, info := range votingInfos {
+		nodes[i] = NodeDelegation{
+			NodeAddress: info.NodeAddress,
+			VotingPower: info.VotingPower,
+			Delegate:    info.Delegate,
+		}
+	}
+
+	// Get the voting initialization flags in batches
+	rocketNetworkVoting, err := rp.GetContract("rocketNetworkVoting", opts)
+	if err != nil {
+		return nil, err
+	}
+	var wg errgroup.Group
+	for bsi := 0; bsi < len(nodes); bsi += VotingInitializedBatchSize {
+		bsi := bsi
+		bei := bsi + VotingInitializedBatchSize
+		if bei > len(nodes) {
+			bei = len(nodes)
+		}
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for i := bsi; i < bei; i++ {
+				if err := mc.AddCall(rocketNetworkVoting, &nodes[i].VotingInitialized, "getVotingInitialised", nodes[i].NodeAddress); err != nil {
+					return fmt.Errorf("error adding voting initialization call for node %s: %w", nodes[i].NodeAddress.Hex(), err)
+				}
+			}
+			if _, err := mc.FlexibleCall(true, opts); err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return BuildDelegationGraph(blockNumber, nodes), nil
+}
+
+// Build the delegation graph from each node's voting power and delegate
+// Nodes without a delegate aren't counted towards any delegate
+func BuildDelegationGraph(blockNumber uint32, nodes []NodeDelegation) *DelegationGraph {
+	graph := &DelegationGraph{
+		BlockNumber:        blockNumber,
+		TotalVotingPower:   big.NewInt(0),
+		NodeCount:          uint64(len(nodes)),
+		Nodes:              nodes,
+		Delegates:          []DelegateInfo{},
+		UninitializedNodes: []common.Address{},
+	}
+
+	// Group the nodes by delegate
+	delegates := map[common.Address]*DelegateInfo{}
+	for _, node := range nodes {
+		votingPower := bigOrZero(node.VotingPower)
+		graph.TotalVotingPower.Add(graph.TotalVotingPower, votingPower)
+		if !node.VotingInitialized {
+			graph.UninitializedNodes = append(graph.UninitializedNodes, node.NodeAddress)
+		}
+		if node.Delegate == (common.Address{}) {
+			continue
+		}
+
+		delegate, exists := delegates[node.Delegate]
+		if !exists {
+			delegate = &DelegateInfo{
+				Address:              node.Delegate,
+				DelegatedVotingPower: big.NewInt(0),
+				OwnVotingPower:       big.NewInt(0),
+				Delegators:           []common.Address{},
+			}
+			delegates[node.Delegate] = delegate
+		}
+		delegate.DelegatedVotingPower.Add(delegate.DelegatedVotingPower, votingPower)
+		if node.Delegate == node.NodeAddress {
+			delegate.IsSelfDelegated = true
+			delegate.OwnVotingPower.Set(votingPower)
+			graph.SelfDelegatedNodeCount++
+		} else {
+			delegate.Delegators = append(delegate.Delegators, node.NodeAddress)
+			delegate.DelegatorCount++
+		}
+	}
+
+	// Get the ratios and sort the delegates
+	for _, delegate := range delegates {
+		delegate.SelfDelegationRatio = getRatio(delegate.OwnVotingPower, delegate.DelegatedVotingPower)
+		delegate.VotingPowerShare = getRatio(delegate.DelegatedVotingPower, graph.TotalVotingPower)
+		graph.Delegates = append(graph.Delegates, *delegate)
+	}
+	sort.Slice(graph.Delegates, func(i, j int) bool {
+		if cmp := graph.Delegates[i].DelegatedVotingPower.Cmp(graph.Delegates[j].DelegatedVotingPower); cmp != 0 {
+			return cmp > 0
+		}
+		return bytes.Compare(graph.Delegates[i].Address[:], graph.Delegates[j].Address[:]) < 0
+	})
+	return graph
+}
+
+// Get a delegate by its address
+func (g *DelegationGraph) GetDelegate(address common.Address) (DelegateInfo, bool) {
+	for _, delegate := range g.Delegates {
+		if delegate.Address == address {
+			return delegate, true
+		}
+	}
+	return DelegateInfo{}, false
+}
+
+// Get the fewest delegates whose combined voting power reaches the target, e.g. a proposal's quorum
+// Returns false if every delegate combined doesn't reach it
+func (g *DelegationGraph) GetDelegatesForVotingPower(target *big.Int) ([]DelegateInfo, bool) {
+	total := big.NewInt(0)
+	delegates := []DelegateInfo{}
+	for _, delegate := range g.Delegates {
+		if total.Cmp(target) >= 0 {
+			break
+		}
+		total.Add(total, delegate.DelegatedVotingPower)
+		delegates = append(delegates, delegate)
+	}
+	return delegates, total.Cmp(target) >= 0
+}
+
+// Get the changes to the delegation graph between two blocks
+func DiffDelegationGraphs(oldGraph *DelegationGraph, newGraph *DelegationGraph) *DelegationDiff {
+	diff := &DelegationDiff{
+		FromBlock:           oldGraph.BlockNumber,
+		ToBlock:             newGraph.BlockNumber,
+		OldTotalVotingPower: oldGraph.TotalVotingPower,
+		NewTotalVotingPower: newGraph.TotalVotingPower,
+		NodeChanges:         []NodeChange{},
+		DelegateChanges:     []DelegateChange{},
+	}
+
+	// Get the node changes
+	oldNodes := map[common.Address]NodeDelegation{}
+	for _, node := range oldGraph.Nodes {
+		oldNodes[node.NodeAddress] = node
+	}
+	for _, newNode := range newGraph.Nodes {
+		oldNode, exists := oldNodes[newNode.NodeAddress]
+		oldPower := bigOrZero(oldNode.VotingPower)
+		newPower := bigOrZero(newNode.VotingPower)
+		if exists && oldNode.Delegate == newNode.Delegate && oldPower.Cmp(newPower) == 0 && oldNode.VotingInitialized == newNode.VotingInitialized {
+			continue
+		}
+		diff.NodeChanges = append(diff.NodeChanges, NodeChange{
+			NodeAddress:          newNode.NodeAddress,
+			IsNew:                !exists,
+			OldDelegate:          oldNode.Delegate,
+			NewDelegate:          newNode.Delegate,
+			OldVotingPower:       oldPower,
+			NewVotingPower:       newPower,
+			OldVotingInitialized: oldNode.VotingInitialized,
+			NewVotingInitialized: newNode.VotingInitialized,
+		})
+	}
+
+	// Get the delegate changes, including delegates that were added or removed
+	oldDelegates := map[common.Address]DelegateInfo{}
+	for _, delegate := range oldGraph.Delegates {
+		oldDelegates[delegate.Address] = delegate
+	}
+	newDelegates := map[common.Address]DelegateInfo{}
+	addresses := []common.Address{}
+	for _, delegate := range newGraph.Delegates {
+		newDelegates[delegate.Address] = delegate
+		addresses = append(addresses, delegate.Address)
+	}
+	for _, delegate := range oldGraph.Delegates {
+		if _, exists := newDelegates[delegate.Address]; !exists {
+			addresses = append(addresses, delegate.Address)
+		}
+	}
+	for _, address := range addresses {
+		oldDelegate := oldDelegates[address]
+		newDelegate := newDelegates[address]
+		change := DelegateChange{
+			Address:                 address,
+			OldDelegatedVotingPower: bigOrZero(oldDelegate.DelegatedVotingPower),
+			NewDelegatedVotingPower: bigOrZero(newDelegate.DelegatedVotingPower),
+			OldDelegatorCount:       oldDelegate.DelegatorCount,
+			NewDelegatorCount:       newDelegate.DelegatorCount,
+			GainedDelegators:        getMissing(newDelegate.Delegators, oldDelegate.Delegators),
+			LostDelegators:          getMissing(oldDelegate.Delegators, newDelegate.Delegators),
+		}
+		if change.OldDelegatedVotingPower.Cmp(change.NewDelegatedVotingPower) == 0 && len(change.GainedDelegators) == 0 && len(change.LostDelegators) == 0 && oldDelegate.IsSelfDelegated == newDelegate.IsSelfDelegated {
+			continue
+		}
+		diff.DelegateChanges = append(diff.DelegateChanges, change)
+	}
+	return diff
+}
+
+// Get the addresses in the first list that aren't in the second
+func getMissing(addresses []common.Address, others []common.Address) []common.Address {
+	existing := map[common.Address]bool{}
+	for _, address := range others {
+		existing[address] = true
+	}
+	missing := []common.Address{}
+	for _, address := range addresses {
+		if !existing[address] {
+			missing = append(missing, address)
+		}
+	}
+	return missing
+}
+
+// Get the ratio of two amounts, or zero if the denominator is zero
+func getRatio(numerator *big.Int, denominator *big.Int) float64 {
+	if denominator.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(numerator, denominator).Float64()
+	return ratio
+}
+
+// Treat a missing value as zero
+func bigOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
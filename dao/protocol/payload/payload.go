@@ -0,0 +1,365 @@
+package payload
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of action a proposal takes when it's executed
+type ActionType string
+
+const (
+	ActionSetUint                ActionType = "setUint"
+	ActionSetBool                ActionType = "setBool"
+	ActionSetAddress             ActionType = "setAddress"
+	ActionSetMulti               ActionType = "setMulti"
+	ActionSetRewardsPercentages  ActionType = "setRewardsPercentages"
+	ActionTreasuryOneTimeSpend   ActionType = "treasuryOneTimeSpend"
+	ActionTreasuryNewContract    ActionType = "treasuryNewContract"
+	ActionTreasuryUpdateContract ActionType = "treasuryUpdateContract"
+	ActionSecurityInvite         ActionType = "securityInvite"
+	ActionSecurityKick           ActionType = "securityKick"
+	ActionSecurityKickMulti      ActionType = "securityKickMulti"
+	ActionSecurityReplace        ActionType = "securityReplace"
+)
+
+// A change to a single setting
+type SettingChange struct {
+	ContractName string                    `json:"contractName"`
+	Path         string                    `json:"path"`
+	Type         types.ProposalSettingType `json:"type"`
+	Unit         settings.SettingUnit      `json:"unit"`
+	OldValue     interface{}               `json:"oldValue,omitempty"` // The value at the proposal block; nil if it hasn't been loaded or the setting isn't in the registry
+	NewValue     interface{}               `json:"newValue"`
+
+	// The setting's registry entry, if it has one
+	Setting *settings.Setting `json:"-"`
+}
+
+// A change to the RPL rewards percentages
+type RewardsPercentagesChange struct {
+	OldPercentages *psettings.RplRewardsPercentages `json:"oldPercentages,omitempty"`
+	NewPercentages psettings.RplRewardsPercentages  `json:"newPercentages"`
+}
+
+// A one-time spend from the treasury
+type TreasuryOneTimeSpend struct {
+	InvoiceID string         `json:"invoiceId"`
+	Recipient common.Address `json:"recipient"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+// A new or updated recurring spend from the treasury
+type TreasuryRecurringSpend struct {
+	ContractName    string         `json:"contractName"`
+	Recipient       common.Address `json:"recipient"`
+	AmountPerPeriod *big.Int       `json:"amountPerPeriod"`
+	PeriodLength    time.Duration  `json:"periodLength"`
+	StartTime       time.Time      `json:"startTime,omitempty"` // Only set for new contracts
+	NumberOfPeriods uint64         `json:"numberOfPeriods"`
+}
+
+// A change to the security council's membership
+type SecurityCouncilChange struct {
+	ID              string           `json:"id,omitempty"`              // The new member's ID, for invites and replacements
+	Address         common.Address   `json:"address,omitempty"`         // The new member, for invites and replacements
+	KickedAddresses []common.Address `json:"kickedAddresses,omitempty"` // The members being removed, for kicks and replacements
+}
+
+// The action a Protocol DAO proposal takes when it's executed
+// Only the field that matches the action's type is set
+type ProposalAction struct {
+	Type   ActionType `json:"type"`
+	Method string     `json:"method"`
+
+	SettingChanges     []SettingChange           `json:"settingChanges,omitempty"`
+	RewardsPercentages *RewardsPercentagesChange `json:"rewardsPercentages,omitempty"`
+	OneTimeSpend       *TreasuryOneTimeSpend     `json:"oneTimeSpend,omitempty"`
+	RecurringSpend     *TreasuryRecurringSpend   `json:"recurringSpend,omitempty"`
+	SecurityCouncil    *SecurityCouncilChange    `json:"securityCouncil,omitempty"`
+}
+
+// Get the action a proposal takes, with the settings' values at the proposal block
+func GetProposalAction(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (*ProposalAction, error) {
+	payload, err := protocol.GetProposalPayload(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	proposalBlock, err := protocol.GetProposalBlock(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	action, err := DecodeProposalPayload(rp, payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding payload of proposal %d: %w", proposalId, err)
+	}
+	err = action.LoadOldValues(rp, &bind.CallOpts{BlockNumber: big.NewInt(int64(proposalBlock))})
+	if err != nil {
+		return nil, fmt.Errorf("error getting old values for proposal %d: %w", proposalId, err)
+	}
+	return action, nil
+}
+
+// Decode a proposal payload using the deployed rocketDAOProtocolProposals ABI
+func DecodeProposalPayload(rp *rocketpool.RocketPool, payload []byte) (*ProposalAction, error) {
+	contractAbi, err := rp.GetABI("rocketDAOProtocolProposals", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePayload(contractAbi, payload)
+}
+
+// Decode a proposal payload using the provided rocketDAOProtocolProposals ABI
+func DecodePayload(contractAbi *abi.ABI, payload []byte) (*ProposalAction, error) {
+	if len(payload) < 4 {
+		return nil, fmt.Errorf("payload is too short to contain a method ID")
+	}
+	method, err := contractAbi.MethodById(payload[:4])
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposal payload method: %w", err)
+	}
+	args, err := method.Inputs.Unpack(payload[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposal payload arguments for %s: %w", method.RawName, err)
+	}
+	action := &ProposalAction{
+		Method: method.RawName,
+	}
+
+	switch method.RawName {
+	case "proposalSettingUint":
+		action.Type = ActionSetUint
+		action.SettingChanges = []SettingChange{newSettingChange(args[0].(string), args[1].(string), types.ProposalSettingType_Uint256, args[2].(*big.Int))}
+
+	case "proposalSettingBool":
+		action.Type = ActionSetBool
+		action.SettingChanges = []SettingChange{newSettingChange(args[0].(string), args[1].(string), types.ProposalSettingType_Bool, args[2].(bool))}
+
+	case "proposalSettingAddress":
+		action.Type = ActionSetAddress
+		action.SettingChanges = []SettingChange{newSettingChange(args[0].(string), args[1].(string), types.ProposalSettingType_Address, args[2].(common.Address))}
+
+	case "proposalSettingMulti":
+		action.Type = ActionSetMulti
+		contractNames := args[0].([]string)
+		paths := args[1].([]string)
+		settingTypes := args[2].([]uint8)
+		data := args[3].([][]byte)
+		if len(paths) != len(contractNames) || len(settingTypes) != len(contractNames) || len(data) != len(contractNames) {
+			return nil, fmt.Errorf("multi-setting payload has mismatched argument lengths")
+		}
+		action.SettingChanges = make([]SettingChange, len(contractNames))
+		for i := range contractNames {
+			settingType := types.ProposalSettingType(settingTypes[i])
+			value, err := decodeSettingValue(settingType, data[i])
+			if err != nil {
+				return nil, fmt.Errorf("error decoding value of %s: %w", paths[i], err)
+			}
+			action.SettingChanges[i] = newSettingChange(contractNames[i], paths[i], settingType, value)
+		}
+
+	case "proposalSettingRewardsClaimers":
+		action.Type = ActionSetRewardsPercentages
+		action.RewardsPercentages = &RewardsPercentagesChange{
+			NewPercentages: psettings.RplRewardsPercentages{
+				OdaoPercentage: args[0].(*big.Int),
+				PdaoPercentage: args[1].(*big.Int),
+				NodePercentage: args[2].(*big.Int),
+			},
+		}
+
+	case "proposalTreasuryOneTimeSpend":
+		action.Type = ActionTreasuryOneTimeSpend
+		action.OneTimeSpend = &TreasuryOneTimeSpend{
+			InvoiceID: args[0].(string),
+			Recipient: args[1].(common.Address),
+			Amount:    args[2].(*big.Int),
+		}
+
+	case "proposalTreasuryNewContract":
+		action.Type = ActionTreasuryNewContract
+		action.RecurringSpend = &TreasuryRecurringSpend{
+			ContractName:    args[0].(string),
+			Recipient:       args[1].(common.Address),
+			AmountPerPeriod: args[2].(*big.Int),
+			PeriodLength:    time.Duration(args[3].(*big.Int).Uint64()) * time.Second,
+			StartTime:       time.Unix(args[4].(*big.Int).Int64(), 0),
+			NumberOfPeriods: args[5].(*big.Int).Uint64(),
+		}
+
+	case "proposalTreasuryUpdateContract":
+		action.Type = ActionTreasuryUpdateContract
+		action.RecurringSpend = &TreasuryRecurringSpend{
+			ContractName:    args[0].(string),
+			Recipient:       args[1].(common.Address),
+			AmountPerPeriod: args[2].(*big.Int),
+			PeriodLength:    time.Duration(args[3].(*big.Int).Uint64()) * time.Second,
+			NumberOfPeriods: args[4].(*big.Int).Uint64(),
+		}
+
+	case "proposalSecurityInvite":
+		action.Type = ActionSecurityInvite
+		action.SecurityCouncil = &SecurityCouncilChange{
+			ID:      args[0].(string),
+			Address: args[1].(common.Address),
+		}
+
+	case "proposalSecurityKick":
+		action.Type = ActionSecurityKick
+		action.SecurityCouncil = &SecurityCouncilChange{
+			KickedAddresses: []common.Address{args[0].(common.Address)},
+		}
+
+	case "proposalSecurityKickMulti":
+		action.Type = ActionSecurityKickMulti
+		action.SecurityCouncil = &SecurityCouncilChange{
+			KickedAddresses: args[0].([]common.Address),
+		}
+
+	case "proposalSecurityReplace":
+		action.Type = ActionSecurityReplace
+		action.SecurityCouncil = &SecurityCouncilChange{
+			KickedAddresses: []common.Address{args[0].(common.Address)},
+			ID:              args[1].(string),
+			Address:         args[2].(common.Address),
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown proposal method %s", method.RawName)
+	}
+	return action, nil
+}
+
+// Create a setting change, looking the setting up in the registry
+func newSettingChange(contractName string, path string, settingType types.ProposalSettingType, value interface{}) SettingChange {
+	change := SettingChange{
+		ContractName: contractName,
+		Path:         path,
+		Type:         settingType,
+		NewValue:     value,
+	}
+	if setting, err := settings.GetSetting(contractName, path); err == nil {
+		change.Setting = setting
+		change.Unit = setting.Unit
+	}
+	return change
+}
+
+// Decode a single ABI-encoded value from a multi-setting payload
+func decodeSettingValue(settingType types.ProposalSettingType, data []byte) (interface{}, error) {
+	if len(data) != 32 {
+		return nil, fmt.Errorf("expected 32 bytes but got %d", len(data))
+	}
+	switch settingType {
+	case types.ProposalSettingType_Uint256:
+		return new(big.Int).SetBytes(data), nil
+	case types.ProposalSettingType_Bool:
+		return data[31] != 0, nil
+	case types.ProposalSettingType_Address:
+		return common.BytesToAddress(data[12:]), nil
+	}
+	return nil, fmt.Errorf("unknown proposal setting type %d", settingType)
+}
+
+// Load the values the action's settings had at a block, so the action shows the change it makes
+// Settings that aren't in the registry are left without an old value
+func (a *ProposalAction) LoadOldValues(rp *rocketpool.RocketPool, opts *bind.CallOpts) error {
+	for i, change := range a.SettingChanges {
+		if change.Setting == nil {
+			continue
+		}
+		oldValue, err := change.Setting.GetValue(rp, opts)
+		if err != nil {
+			return err
+		}
+		a.SettingChanges[i].OldValue = oldValue
+	}
+	if a.RewardsPercentages != nil {
+		percentages, err := psettings.GetRewardsPercentages(rp, opts)
+		if err != nil {
+			return err
+		}
+		a.RewardsPercentages.OldPercentages = &percentages
+	}
+	return nil
+}
+
+// Get a human-readable description of a setting change
+func (c SettingChange) String() string {
+	format := func(value interface{}) string {
+		if c.Setting != nil {
+			return c.Setting.Format(value)
+		}
+		return fmt.Sprint(value)
+	}
+	if c.OldValue == nil {
+		return fmt.Sprintf("Set %s/%s to %s", c.ContractName, c.Path, format(c.NewValue))
+	}
+	return fmt.Sprintf("Set %s/%s from %s to %s", c.ContractName, c.Path, format(c.OldValue), format(c.NewValue))
+}
+
+// Get a human-readable description of the action, one line per change
+func (a *ProposalAction) String() string {
+	switch a.Type {
+	case ActionSetUint, ActionSetBool, ActionSetAddress, ActionSetMulti:
+		lines := make([]string, len(a.SettingChanges))
+		for i, change := range a.SettingChanges {
+			lines[i] = change.String()
+		}
+		return strings.Join(lines, "\n")
+
+	case ActionSetRewardsPercentages:
+		percentages := a.RewardsPercentages.NewPercentages
+		description := fmt.Sprintf("Set the RPL rewards percentages to %s for the Oracle DAO, %s for the Protocol DAO and %s for node operators",
+			formatPercent(percentages.OdaoPercentage), formatPercent(percentages.PdaoPercentage), formatPercent(percentages.NodePercentage))
+		if old := a.RewardsPercentages.OldPercentages; old != nil {
+			description += fmt.Sprintf(" (from %s, %s and %s)", formatPercent(old.OdaoPercentage), formatPercent(old.PdaoPercentage), formatPercent(old.NodePercentage))
+		}
+		return description
+
+	case ActionTreasuryOneTimeSpend:
+		return fmt.Sprintf("Spend %.6f RPL from the treasury to %s for invoice %s", eth.WeiToEth(a.OneTimeSpend.Amount), a.OneTimeSpend.Recipient.Hex(), a.OneTimeSpend.InvoiceID)
+
+	case ActionTreasuryNewContract:
+		spend := a.RecurringSpend
+		return fmt.Sprintf("Create recurring treasury spend %s paying %.6f RPL to %s every %s for %d periods, starting %s",
+			spend.ContractName, eth.WeiToEth(spend.AmountPerPeriod), spend.Recipient.Hex(), spend.PeriodLength, spend.NumberOfPeriods, spend.StartTime.UTC().Format(time.RFC3339))
+
+	case ActionTreasuryUpdateContract:
+		spend := a.RecurringSpend
+		return fmt.Sprintf("Update recurring treasury spend %s to pay %.6f RPL to %s every %s for %d periods",
+			spend.ContractName, eth.WeiToEth(spend.AmountPerPeriod), spend.Recipient.Hex(), spend.PeriodLength, spend.NumberOfPeriods)
+
+	case ActionSecurityInvite:
+		return fmt.Sprintf("Invite %s (%s) to the security council", a.SecurityCouncil.ID, a.SecurityCouncil.Address.Hex())
+
+	case ActionSecurityKick, ActionSecurityKickMulti:
+		addresses := make([]string, len(a.SecurityCouncil.KickedAddresses))
+		for i, address := range a.SecurityCouncil.KickedAddresses {
+			addresses[i] = address.Hex()
+		}
+		return fmt.Sprintf("Kick %s from the security council", strings.Join(addresses, ", "))
+
+	case ActionSecurityReplace:
+		return fmt.Sprintf("Replace security council member %s with %s (%s)", a.SecurityCouncil.KickedAddresses[0].Hex(), a.SecurityCouncil.ID, a.SecurityCouncil.Address.Hex())
+	}
+	return a.Method
+}
+
+// Format an 18-decimal fraction as a percentage
+func formatPercent(value *big.Int) string {
+	return fmt.Sprintf("%.2f%%", eth.WeiToEth(value)*100)
+}
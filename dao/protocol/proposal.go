@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -583,7 +582,7 @@ func estimateProposalGas(rp *rocketpool.RocketPool, message string, payload []by
 	if err != nil {
 		return rocketpool.GasInfo{}, err
 	}
-	err = simulateProposalExecution(rp, payload)
+	err = SimulateProposalExecution(rp, payload)
 	if err != nil {
 		return rocketpool.GasInfo{}, fmt.Errorf("error simulating proposal execution: %w", err)
 	}
@@ -697,7 +696,7 @@ func ExecuteProposal(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.Tr
 }
 
 // Simulate a proposal's execution to verify it won't revert
-func simulateProposalExecution(rp *rocketpool.RocketPool, payload []byte) error {
+func SimulateProposalExecution(rp *rocketpool.RocketPool, payload []byte) error {
 	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
 	if err != nil {
 		return err
@@ -718,10 +717,6 @@ func simulateProposalExecution(rp *rocketpool.RocketPool, payload []byte) error
 }
 
 // Get contracts
-var rocketDAOProtocolProposalLock sync.Mutex
-
 func getRocketDAOProtocolProposal(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOProtocolProposalLock.Lock()
-	defer rocketDAOProtocolProposalLock.Unlock()
 	return rp.GetContract("rocketDAOProtocolProposal", opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -13,37 +12,38 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 )
 
-// Estimate the gas of ProposeSetMulti
-func EstimateProposeSetMultiGas(rp *rocketpool.RocketPool, message string, contractNames []string, settingPaths []string, settingTypes []types.ProposalSettingType, values []any, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+// Get the payload of a proposal to update multiple Protocol DAO settings at once
+func GetProposeSetMultiPayload(rp *rocketpool.RocketPool, contractNames []string, settingPaths []string, settingTypes []types.ProposalSettingType, values []any) ([]byte, error) {
 	rocketDAOProtocolProposals, err := getRocketDAOProtocolProposals(rp, nil)
 	if err != nil {
-		return rocketpool.GasInfo{}, err
+		return nil, err
 	}
 	encodedValues, err := abiEncodeMultiValues(settingTypes, values)
 	if err != nil {
-		return rocketpool.GasInfo{}, fmt.Errorf("error ABI encoding values: %w", err)
+		return nil, fmt.Errorf("error ABI encoding values: %w", err)
 	}
 	payload, err := rocketDAOProtocolProposals.ABI.Pack("proposalSettingMulti", contractNames, settingPaths, settingTypes, encodedValues)
 	if err != nil {
-		return rocketpool.GasInfo{}, fmt.Errorf("error setting multi-set proposal payload: %w", err)
+		return nil, fmt.Errorf("error setting multi-set proposal payload: %w", err)
+	}
+	return payload, nil
+}
+
+// Estimate the gas of ProposeSetMulti
+func EstimateProposeSetMultiGas(rp *rocketpool.RocketPool, message string, contractNames []string, settingPaths []string, settingTypes []types.ProposalSettingType, values []any, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	payload, err := GetProposeSetMultiPayload(rp, contractNames, settingPaths, settingTypes, values)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
 	}
 	return estimateProposalGas(rp, message, payload, blockNumber, treeNodes, opts)
 }
 
 // Submit a proposal to update multiple Protocol DAO settings at once
 func ProposeSetMulti(rp *rocketpool.RocketPool, message string, contractNames []string, settingPaths []string, settingTypes []types.ProposalSettingType, values []any, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (uint64, common.Hash, error) {
-	rocketDAOProtocolProposals, err := getRocketDAOProtocolProposals(rp, nil)
+	payload, err := GetProposeSetMultiPayload(rp, contractNames, settingPaths, settingTypes, values)
 	if err != nil {
 		return 0, common.Hash{}, err
 	}
-	encodedValues, err := abiEncodeMultiValues(settingTypes, values)
-	if err != nil {
-		return 0, common.Hash{}, fmt.Errorf("error ABI encoding values: %w", err)
-	}
-	payload, err := rocketDAOProtocolProposals.ABI.Pack("proposalSettingMulti", contractNames, settingPaths, settingTypes, encodedValues)
-	if err != nil {
-		return 0, common.Hash{}, fmt.Errorf("error setting multi-set proposal payload: %w", err)
-	}
 	return submitProposal(rp, message, payload, blockNumber, treeNodes, opts)
 }
 
@@ -384,10 +384,6 @@ func abiEncodeMultiValues(settingTypes []types.ProposalSettingType, values []any
 }
 
 // Get contracts
-var rocketDAOProtocolProposalsLock sync.Mutex
-
 func getRocketDAOProtocolProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOProtocolProposalsLock.Lock()
-	defer rocketDAOProtocolProposalsLock.Unlock()
 	return rp.GetContract("rocketDAOProtocolProposals", opts)
 }
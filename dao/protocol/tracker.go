@@ -0,0 +1,300 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// ========================
+// === Proposal Tracker ===
+// ========================
+
+// The kind of change in a proposal's lifecycle
+type ProposalEventType string
+
+const (
+	ProposalEventCreated           ProposalEventType = "created"
+	ProposalEventEnteredPhase1     ProposalEventType = "enteredPhase1"
+	ProposalEventEnteredPhase2     ProposalEventType = "enteredPhase2"
+	ProposalEventQuorumReached     ProposalEventType = "quorumReached"
+	ProposalEventVetoQuorumReached ProposalEventType = "vetoQuorumReached"
+	ProposalEventVetoed            ProposalEventType = "vetoed"
+	ProposalEventDefeated          ProposalEventType = "defeated"
+	ProposalEventExecutable        ProposalEventType = "executable"
+	ProposalEventExpiringSoon      ProposalEventType = "expiringSoon"
+	ProposalEventExpired           ProposalEventType = "expired"
+	ProposalEventExecuted          ProposalEventType = "executed"
+	ProposalEventDestroyed         ProposalEventType = "destroyed"
+)
+
+// The events emitted when a proposal enters a state
+var proposalStateEvents = map[types.ProtocolDaoProposalState]ProposalEventType{
+	types.ProtocolDaoProposalState_ActivePhase1: ProposalEventEnteredPhase1,
+	types.ProtocolDaoProposalState_ActivePhase2: ProposalEventEnteredPhase2,
+	types.ProtocolDaoProposalState_Vetoed:       ProposalEventVetoed,
+	types.ProtocolDaoProposalState_QuorumNotMet: ProposalEventDefeated,
+	types.ProtocolDaoProposalState_Defeated:     ProposalEventDefeated,
+	types.ProtocolDaoProposalState_Succeeded:    ProposalEventExecutable,
+	types.ProtocolDaoProposalState_Expired:      ProposalEventExpired,
+	types.ProtocolDaoProposalState_Executed:     ProposalEventExecuted,
+	types.ProtocolDaoProposalState_Destroyed:    ProposalEventDestroyed,
+}
+
+// A proposal's details along with the values derived from them at a point in time
+type ProtocolDaoProposalStatus struct {
+	Details                 ProtocolDaoProposalDetails     `json:"details"`
+	Time                    time.Time                      `json:"time"`
+	State                   types.ProtocolDaoProposalState `json:"state"` // Computed for the status time, so it can be ahead of the state in the details
+	TotalVotingPower        *big.Int                       `json:"totalVotingPower"`
+	QuorumMet               bool                           `json:"quorumMet"`
+	VotingPowerToQuorum     *big.Int                       `json:"votingPowerToQuorum"`
+	VetoQuorumMet           bool                           `json:"vetoQuorumMet"`
+	VotingPowerToVetoQuorum *big.Int                       `json:"votingPowerToVetoQuorum"`
+	PhaseEndTime            time.Time                      `json:"phaseEndTime"` // The end of the current phase, or the expiry time if it's waiting to be executed
+	TimeRemaining           time.Duration                  `json:"timeRemaining"`
+	ProjectedState          types.ProtocolDaoProposalState `json:"projectedState"` // The state voting will end in if the tallies don't change
+	CanFinalize             bool                           `json:"canFinalize"`
+	CanExecute              bool                           `json:"canExecute"`
+	IsExpiringSoon          bool                           `json:"isExpiringSoon"`
+}
+
+// A change in a proposal's lifecycle
+type ProposalEvent struct {
+	Type       ProposalEventType              `json:"type"`
+	ProposalID uint64                         `json:"proposalId"`
+	Time       time.Time                      `json:"time"`
+	State      types.ProtocolDaoProposalState `json:"state"`
+}
+
+// Tracks proposals between updates and reports the changes in their lifecycle
+type ProposalTracker struct {
+	expiryWarning time.Duration
+	statuses      map[uint64]ProtocolDaoProposalStatus
+}
+
+// Create a new proposal tracker
+// Executable proposals are reported as expiring soon once they're within the expiry warning of their expiry time
+func NewProposalTracker(expiryWarning time.Duration) *ProposalTracker {
+	return &ProposalTracker{
+		expiryWarning: expiryWarning,
+	}
+}
+
+// Get the status of every proposal
+func (t *ProposalTracker) GetStatuses(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]ProtocolDaoProposalStatus, error) {
+	return GetProposalStatuses(rp, t.expiryWarning, opts)
+}
+
+// Update the tracker with the latest proposal statuses and get the events since the last update, ordered by proposal
+// The first update only records the statuses, so it doesn't report any events
+func (t *ProposalTracker) Update(statuses []ProtocolDaoProposalStatus) []ProposalEvent {
+	events := []ProposalEvent{}
+	seeded := t.statuses != nil
+	if !seeded {
+		t.statuses = map[uint64]ProtocolDaoProposalStatus{}
+	}
+	for _, status := range statuses {
+		if seeded {
+			previous, exists := t.statuses[status.Details.ID]
+			if exists {
+				events = append(events, GetProposalEvents(&previous, status)...)
+			} else {
+				events = append(events, GetProposalEvents(nil, status)...)
+			}
+		}
+		t.statuses[status.Details.ID] = status
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].ProposalID < events[j].ProposalID
+	})
+	return events
+}
+
+// Get the status of every proposal
+func GetProposalStatuses(rp *rocketpool.RocketPool, expiryWarning time.Duration, opts *bind.CallOpts) ([]ProtocolDaoProposalStatus, error) {
+	// Pin the statuses to a single block
+	if opts == nil || opts.BlockNumber == nil {
+		latestBlock, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block number: %w", err)
+		}
+		opts = &bind.CallOpts{
+			BlockNumber: new(big.Int).SetUint64(latestBlock),
+		}
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting block header: %w", err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+
+	// Get the proposals
+	proposals, err := GetProposals(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]ProtocolDaoProposalStatus, len(proposals))
+	for i, proposal := range proposals {
+		statuses[i] = GetProposalStatus(proposal, blockTime, expiryWarning)
+	}
+	return statuses, nil
+}
+
+// Get a proposal's status at the given time
+func GetProposalStatus(details ProtocolDaoProposalDetails, now time.Time, expiryWarning time.Duration) ProtocolDaoProposalStatus {
+	status := ProtocolDaoProposalStatus{
+		Details:          details,
+		Time:             now,
+		TotalVotingPower: getTotalVotingPower(details),
+	}
+
+	// Quorums
+	required := bigOrZero(details.VotingPowerRequired)
+	status.QuorumMet = status.TotalVotingPower.Cmp(required) >= 0
+	status.VotingPowerToQuorum = getShortfall(required, status.TotalVotingPower)
+	vetoQuorum := bigOrZero(details.VetoQuorum)
+	vetoPower := bigOrZero(details.VotingPowerToVeto)
+	status.VetoQuorumMet = vetoQuorum.Sign() > 0 && vetoPower.Cmp(vetoQuorum) >= 0
+	status.VotingPowerToVetoQuorum = getShortfall(vetoQuorum, vetoPower)
+
+	// Timing
+	status.State = ComputeProposalState(details, now)
+	switch status.State {
+	case types.ProtocolDaoProposalState_Pending:
+		status.PhaseEndTime = details.VotingStartTime
+	case types.ProtocolDaoProposalState_ActivePhase1:
+		status.PhaseEndTime = details.Phase1EndTime
+	case types.ProtocolDaoProposalState_ActivePhase2:
+		status.PhaseEndTime = details.Phase2EndTime
+	case types.ProtocolDaoProposalState_Succeeded:
+		status.PhaseEndTime = details.ExpiryTime
+	}
+	if status.PhaseEndTime.After(now) {
+		status.TimeRemaining = status.PhaseEndTime.Sub(now)
+	}
+
+	// Outcome
+	switch status.State {
+	case types.ProtocolDaoProposalState_Pending,
+		types.ProtocolDaoProposalState_ActivePhase1,
+		types.ProtocolDaoProposalState_ActivePhase2:
+		status.ProjectedState = getClosedState(details, details.Phase2EndTime)
+	default:
+		status.ProjectedState = status.State
+	}
+	status.CanFinalize = status.State == types.ProtocolDaoProposalState_Vetoed && !details.IsFinalized
+	status.CanExecute = status.State == types.ProtocolDaoProposalState_Succeeded
+	status.IsExpiringSoon = status.CanExecute && status.TimeRemaining <= expiryWarning
+	return status
+}
+
+// Get the state of a proposal at the given time, following the same rules as the contract
+// This lets the state be worked out for any time without another call, e.g. to show when it will change
+func ComputeProposalState(details ProtocolDaoProposalDetails, now time.Time) types.ProtocolDaoProposalState {
+	if details.IsDestroyed {
+		return types.ProtocolDaoProposalState_Destroyed
+	}
+	if details.IsFinalized {
+		return types.ProtocolDaoProposalState_Vetoed
+	}
+	if details.IsExecuted {
+		return types.ProtocolDaoProposalState_Executed
+	}
+	if now.Before(details.VotingStartTime) {
+		return types.ProtocolDaoProposalState_Pending
+	}
+	if now.Before(details.Phase1EndTime) {
+		return types.ProtocolDaoProposalState_ActivePhase1
+	}
+	if now.Before(details.Phase2EndTime) {
+		return types.ProtocolDaoProposalState_ActivePhase2
+	}
+	return getClosedState(details, now)
+}
+
+// Get the events between two statuses of a proposal
+// A nil previous status means the proposal is new
+func GetProposalEvents(previous *ProtocolDaoProposalStatus, current ProtocolDaoProposalStatus) []ProposalEvent {
+	events := []ProposalEvent{}
+	newEvent := func(eventType ProposalEventType) ProposalEvent {
+		return ProposalEvent{
+			Type:       eventType,
+			ProposalID: current.Details.ID,
+			Time:       current.Time,
+			State:      current.State,
+		}
+	}
+	if previous == nil {
+		events = append(events, newEvent(ProposalEventCreated))
+		previous = &ProtocolDaoProposalStatus{
+			State: types.ProtocolDaoProposalState_Pending,
+		}
+	}
+
+	active := current.State == types.ProtocolDaoProposalState_ActivePhase1 || current.State == types.ProtocolDaoProposalState_ActivePhase2
+	if active && current.QuorumMet && !previous.QuorumMet {
+		events = append(events, newEvent(ProposalEventQuorumReached))
+	}
+	if active && current.VetoQuorumMet && !previous.VetoQuorumMet {
+		events = append(events, newEvent(ProposalEventVetoQuorumReached))
+	}
+	if current.State != previous.State {
+		if eventType, exists := proposalStateEvents[current.State]; exists {
+			events = append(events, newEvent(eventType))
+		}
+	}
+	if current.IsExpiringSoon && !previous.IsExpiringSoon {
+		events = append(events, newEvent(ProposalEventExpiringSoon))
+	}
+	return events
+}
+
+// Get the state of a proposal once voting has ended
+func getClosedState(details ProtocolDaoProposalDetails, now time.Time) types.ProtocolDaoProposalState {
+	vetoQuorum := bigOrZero(details.VetoQuorum)
+	if vetoQuorum.Sign() > 0 && bigOrZero(details.VotingPowerToVeto).Cmp(vetoQuorum) >= 0 {
+		return types.ProtocolDaoProposalState_Vetoed
+	}
+	if getTotalVotingPower(details).Cmp(bigOrZero(details.VotingPowerRequired)) < 0 {
+		return types.ProtocolDaoProposalState_QuorumNotMet
+	}
+	if bigOrZero(details.VotingPowerFor).Cmp(bigOrZero(details.VotingPowerAgainst)) <= 0 {
+		return types.ProtocolDaoProposalState_Defeated
+	}
+	if now.Before(details.ExpiryTime) {
+		return types.ProtocolDaoProposalState_Succeeded
+	}
+	return types.ProtocolDaoProposalState_Expired
+}
+
+// Get the total voting power that counts towards a proposal's quorum
+func getTotalVotingPower(details ProtocolDaoProposalDetails) *big.Int {
+	total := new(big.Int).Set(bigOrZero(details.VotingPowerFor))
+	total.Add(total, bigOrZero(details.VotingPowerAgainst))
+	total.Add(total, bigOrZero(details.VotingPowerAbstained))
+	return total
+}
+
+// Get the amount still needed to reach a target, or zero if it's been reached
+func getShortfall(target *big.Int, current *big.Int) *big.Int {
+	shortfall := new(big.Int).Sub(target, current)
+	if shortfall.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return shortfall
+}
+
+// Treat a missing value as zero
+func bigOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
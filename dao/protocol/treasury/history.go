@@ -0,0 +1,262 @@
+package treasury
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of treasury event
+type EventType string
+
+const (
+	EventSpend           EventType = "spend"
+	EventContractCreated EventType = "contractCreated"
+	EventContractUpdated EventType = "contractUpdated"
+	EventContractPayment EventType = "contractPayment"
+	EventClaim           EventType = "claim"
+)
+
+// The treasury events, by their name in the contract ABI
+var eventTypes = map[string]EventType{
+	"RPLTokensSentByDAOProtocol": EventSpend,
+	"RPLTreasuryContractCreated": EventContractCreated,
+	"RPLTreasuryContractUpdated": EventContractUpdated,
+	"RPLTreasuryContractPayment": EventContractPayment,
+	"RPLTreasuryContractClaimed": EventClaim,
+}
+
+// Something that happened to the treasury
+// Fields that don't apply to the event's type are left empty
+type Event struct {
+	Type        EventType      `json:"type"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      common.Hash    `json:"txHash"`
+	Time        time.Time      `json:"time"`
+	Recipient   common.Address `json:"recipient"`
+	Amount      *big.Int       `json:"amount"` // The amount per period for created and updated contracts
+
+	// One-time spends
+	InvoiceID string `json:"invoiceId,omitempty"`
+
+	// Payment contracts; the name is only known if it was provided when decoding, since the event only has its hash
+	ContractName     string        `json:"contractName,omitempty"`
+	ContractNameHash common.Hash   `json:"contractNameHash,omitempty"`
+	PeriodLength     time.Duration `json:"periodLength,omitempty"`
+	StartTime        time.Time     `json:"startTime,omitempty"`
+	NumberOfPeriods  uint64        `json:"numberOfPeriods,omitempty"`
+}
+
+// The treasury's balance, payment contracts and history
+type Report struct {
+	BlockNumber       uint64                      `json:"blockNumber"`
+	Balance           *big.Int                    `json:"balance"`
+	Contracts         []PaymentContract           `json:"contracts"`
+	RecipientBalances map[common.Address]*big.Int `json:"recipientBalances"` // Paid out to recipients but not claimed yet
+	Committed         *big.Int                    `json:"committed"`         // Still owed by the payment contracts, including pending amounts
+	History           []Event                     `json:"history"`
+
+	// Totals over the history
+	TotalSpent   *big.Int `json:"totalSpent"`
+	TotalPaidOut *big.Int `json:"totalPaidOut"`
+	TotalClaimed *big.Int `json:"totalClaimed"`
+}
+
+// Get the treasury's history between two blocks, oldest first
+// Logs from every rocketClaimDAO deployment are included; a nil start block starts from Rocket Pool's deployment
+func GetHistory(rp *rocketpool.RocketPool, contractNames []string, intervalSize *big.Int, startBlock *big.Int, endBlock *big.Int, opts *bind.CallOpts) ([]Event, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	eventIds := []common.Hash{}
+	for name := range eventTypes {
+		if event, exists := rocketClaimDAO.ABI.Events[name]; exists {
+			eventIds = append(eventIds, event.ID)
+		}
+	}
+	logs, err := eth.FilterContractLogs(rp, "rocketClaimDAO", eth.FilterQuery{
+		FromBlock: startBlock,
+		ToBlock:   endBlock,
+		Topics:    [][]common.Hash{eventIds},
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeEvents(rocketClaimDAO.ABI, logs, contractNames)
+}
+
+// Decode treasury event logs, skipping logs that aren't treasury events
+// The names of payment contracts are resolved from their hashes using the provided names
+func DecodeEvents(contractAbi *abi.ABI, logs []types.Log, contractNames []string) ([]Event, error) {
+	names := map[common.Hash]string{}
+	for _, name := range contractNames {
+		names[crypto.Keccak256Hash([]byte(name))] = name
+	}
+
+	events := []Event{}
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		abiEvent, err := contractAbi.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		eventType, exists := eventTypes[abiEvent.Name]
+		if !exists {
+			continue
+		}
+		values := map[string]interface{}{}
+		if err := abiEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error unpacking %s event data: %w", abiEvent.Name, err)
+		}
+		indexed := map[string]common.Hash{}
+		topicIndex := 1
+		for _, input := range abiEvent.Inputs {
+			if !input.Indexed {
+				continue
+			}
+			if topicIndex >= len(log.Topics) {
+				return nil, fmt.Errorf("%s event had %d topics but more are required", abiEvent.Name, len(log.Topics))
+			}
+			indexed[input.Name] = log.Topics[topicIndex]
+			topicIndex++
+		}
+
+		event := Event{
+			Type:        eventType,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			Time:        getTime(values, "time"),
+		}
+		switch eventType {
+		case EventSpend:
+			event.Recipient = common.BytesToAddress(indexed["to"].Bytes())
+			event.Amount = getUint(values, "amount")
+			if invoiceID, ok := values["invoiceID"].(string); ok {
+				event.InvoiceID = invoiceID
+			}
+		case EventContractCreated, EventContractUpdated:
+			event.Recipient = common.BytesToAddress(indexed["recipient"].Bytes())
+			event.Amount = getUint(values, "amountPerPeriod")
+			event.PeriodLength = time.Duration(getUint(values, "periodLength").Uint64()) * time.Second
+			event.NumberOfPeriods = getUint(values, "numPeriods").Uint64()
+			if eventType == EventContractCreated {
+				event.StartTime = getTime(values, "startTime")
+			}
+		case EventContractPayment:
+			event.Recipient = common.BytesToAddress(indexed["recipient"].Bytes())
+			event.Amount = getUint(values, "amount")
+		case EventClaim:
+			event.Recipient = common.BytesToAddress(indexed["recipient"].Bytes())
+			event.Amount = getUint(values, "amount")
+		}
+		if nameHash, exists := indexed["contractName"]; exists {
+			event.ContractNameHash = nameHash
+			event.ContractName = names[nameHash]
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Get a report of the treasury's balance, payment contracts and history
+func GetReport(rp *rocketpool.RocketPool, intervalSize *big.Int, startBlock *big.Int, opts *bind.CallOpts) (*Report, error) {
+	// Pin the report to a single block
+	if opts == nil || opts.BlockNumber == nil {
+		latestBlock, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block number: %w", err)
+		}
+		opts = &bind.CallOpts{
+			BlockNumber: new(big.Int).SetUint64(latestBlock),
+		}
+	}
+	report := &Report{
+		BlockNumber:       opts.BlockNumber.Uint64(),
+		RecipientBalances: map[common.Address]*big.Int{},
+		Committed:         big.NewInt(0),
+	}
+
+	// Get the current state
+	var err error
+	report.Balance, err = GetTreasuryBalance(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	createdNames, err := getCreatedContractNames(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	existingNames, err := getExistingContractNames(rp, createdNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.Contracts, err = getPaymentContracts(rp, existingNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, contract := range report.Contracts {
+		report.Committed.Add(report.Committed, contract.GetRemainingAmount())
+		if _, exists := report.RecipientBalances[contract.Recipient]; exists {
+			continue
+		}
+		report.RecipientBalances[contract.Recipient], err = GetBalance(rp, contract.Recipient, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the history, resolving the names of contracts that have since been removed too
+	report.History, err = GetHistory(rp, createdNames, intervalSize, startBlock, opts.BlockNumber, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.TotalSpent, report.TotalPaidOut, report.TotalClaimed = GetHistoryTotals(report.History)
+	return report, nil
+}
+
+// Get the total amounts spent one time, paid out to payment contract recipients, and claimed in a history
+func GetHistoryTotals(history []Event) (*big.Int, *big.Int, *big.Int) {
+	spent := big.NewInt(0)
+	paidOut := big.NewInt(0)
+	claimed := big.NewInt(0)
+	for _, event := range history {
+		if event.Amount == nil {
+			continue
+		}
+		switch event.Type {
+		case EventSpend:
+			spent.Add(spent, event.Amount)
+		case EventContractPayment:
+			paidOut.Add(paidOut, event.Amount)
+		case EventClaim:
+			claimed.Add(claimed, event.Amount)
+		}
+	}
+	return spent, paidOut, claimed
+}
+
+// Get a uint256 event value, or zero if the event doesn't have it
+func getUint(values map[string]interface{}, name string) *big.Int {
+	if value, ok := values[name].(*big.Int); ok {
+		return value
+	}
+	return big.NewInt(0)
+}
+
+// Get a timestamp event value
+func getTime(values map[string]interface{}, name string) time.Time {
+	return time.Unix(getUint(values, name).Int64(), 0)
+}
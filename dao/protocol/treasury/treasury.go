@@ -0,0 +1,383 @@
+package treasury
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol/payload"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Settings
+const (
+	PaymentContractBatchSize = 10
+)
+
+// A recurring payment contract paid out of the treasury
+type PaymentContract struct {
+	Name            string         `json:"name"`
+	Recipient       common.Address `json:"recipient"`
+	AmountPerPeriod *big.Int       `json:"amountPerPeriod"`
+	PeriodLength    time.Duration  `json:"periodLength"`
+	StartTime       time.Time      `json:"startTime"` // Derived from the last payment, so it assumes the period length has never been updated
+	LastPaymentTime time.Time      `json:"lastPaymentTime"`
+	NumberOfPeriods uint64         `json:"numberOfPeriods"`
+	PeriodsPaid     uint64         `json:"periodsPaid"`
+	PendingAmount   *big.Int       `json:"pendingAmount"` // Owed for elapsed periods but not paid out yet, as of the block it was read at
+}
+
+// The raw payment contract returned by getContract
+type paymentContractRaw struct {
+	Recipient       common.Address
+	AmountPerPeriod *big.Int
+	PeriodLength    *big.Int
+	LastPaymentTime *big.Int
+	NumPeriods      *big.Int
+	PeriodsPaid     *big.Int
+}
+
+// Get the amount owed for the periods that have elapsed since the last payment, capped at the periods remaining
+func (c PaymentContract) GetPendingAmount(now time.Time) *big.Int {
+	periods := c.GetPendingPeriods(now)
+	return new(big.Int).Mul(c.AmountPerPeriod, new(big.Int).SetUint64(periods))
+}
+
+// Get the number of periods that have elapsed since the last payment, capped at the periods remaining
+func (c PaymentContract) GetPendingPeriods(now time.Time) uint64 {
+	if c.PeriodLength <= 0 || now.Before(c.LastPaymentTime) || c.PeriodsPaid >= c.NumberOfPeriods {
+		return 0
+	}
+	periods := uint64(now.Sub(c.LastPaymentTime) / c.PeriodLength)
+	if remaining := c.NumberOfPeriods - c.PeriodsPaid; periods > remaining {
+		periods = remaining
+	}
+	return periods
+}
+
+// Get the amount the contract will still pay, including the pending amount
+func (c PaymentContract) GetRemainingAmount() *big.Int {
+	if c.PeriodsPaid >= c.NumberOfPeriods {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Mul(c.AmountPerPeriod, new(big.Int).SetUint64(c.NumberOfPeriods-c.PeriodsPaid))
+}
+
+// Get the time the contract's last period ends
+func (c PaymentContract) GetEndTime() time.Time {
+	return c.StartTime.Add(c.PeriodLength * time.Duration(c.NumberOfPeriods))
+}
+
+// Check if a payment contract exists
+func GetContractExists(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (bool, error) {
+	return dao.GetContractExists(rp, contractName, opts)
+}
+
+// Get a payment contract
+func GetPaymentContract(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (PaymentContract, error) {
+	blockTime, err := getBlockTime(rp, opts)
+	if err != nil {
+		return PaymentContract{}, err
+	}
+	return getPaymentContract(rp, contractName, blockTime, opts)
+}
+
+// Get every payment contract that still exists, in the order they were created
+// The contract doesn't enumerate its payment contracts, so the names are taken from executed proposals (see GetPaymentContractNames)
+func GetPaymentContracts(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]PaymentContract, error) {
+	names, err := GetPaymentContractNames(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	return getPaymentContracts(rp, names, opts)
+}
+
+// Get the names of every payment contract that still exists, in the order they were created
+// Payment contracts can only be created by Protocol DAO proposals, so the names are decoded from the payloads of executed proposals
+func GetPaymentContractNames(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]string, error) {
+	createdNames, err := getCreatedContractNames(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	return getExistingContractNames(rp, createdNames, opts)
+}
+
+// Load a set of payment contracts in batches
+func getPaymentContracts(rp *rocketpool.RocketPool, names []string, opts *bind.CallOpts) ([]PaymentContract, error) {
+	blockTime, err := getBlockTime(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	contracts := make([]PaymentContract, len(names))
+	for bsi := 0; bsi < len(names); bsi += PaymentContractBatchSize {
+		bei := bsi + PaymentContractBatchSize
+		if bei > len(names) {
+			bei = len(names)
+		}
+		var wg errgroup.Group
+		for i := bsi; i < bei; i++ {
+			i := i
+			wg.Go(func() error {
+				contract, err := getPaymentContract(rp, names[i], blockTime, opts)
+				if err == nil {
+					contracts[i] = contract
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+	}
+	return contracts, nil
+}
+
+// Filter out payment contracts that have since been removed
+func getExistingContractNames(rp *rocketpool.RocketPool, candidates []string, opts *bind.CallOpts) ([]string, error) {
+	exists := make([]bool, len(candidates))
+	var wg errgroup.Group
+	for i, name := range candidates {
+		i, name := i, name
+		wg.Go(func() error {
+			contractExists, err := GetContractExists(rp, name, opts)
+			if err == nil {
+				exists[i] = contractExists
+			}
+			return err
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for i, name := range candidates {
+		if exists[i] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Get the names of every payment contract created by an executed proposal, whether or not it still exists
+func getCreatedContractNames(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]string, error) {
+	proposalCount, err := protocol.GetTotalProposalCount(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	proposalsAbi, err := rp.GetABI("rocketDAOProtocolProposals", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the payloads of executed proposals in batches
+	names := make([]string, proposalCount)
+	for bsi := uint64(0); bsi < proposalCount; bsi += protocol.ProposalDetailsBatchSize {
+		bei := bsi + protocol.ProposalDetailsBatchSize
+		if bei > proposalCount {
+			bei = proposalCount
+		}
+		var wg errgroup.Group
+		for pi := bsi; pi < bei; pi++ {
+			pi := pi
+			wg.Go(func() error {
+				proposalId := pi + 1 // Proposals are 1-indexed
+				executed, err := protocol.GetProposalIsExecuted(rp, proposalId, opts)
+				if err != nil || !executed {
+					return err
+				}
+				proposalPayload, err := protocol.GetProposalPayload(rp, proposalId, opts)
+				if err != nil {
+					return err
+				}
+				action, err := payload.DecodePayload(proposalsAbi, proposalPayload)
+				if err != nil {
+					// Payloads from older contract versions may not decode, and can't create payment contracts anyway
+					return nil
+				}
+				if action.Type == payload.ActionTreasuryNewContract {
+					names[pi] = action.RecurringSpend.ContractName
+				}
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove duplicates, keeping the first creation
+	seen := map[string]bool{}
+	uniqueNames := []string{}
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		uniqueNames = append(uniqueNames, name)
+	}
+	return uniqueNames, nil
+}
+
+// Get the RPL a recipient has been paid out and can withdraw
+func GetBalance(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := rocketClaimDAO.Call(opts, value, "getBalance", recipient); err != nil {
+		return nil, fmt.Errorf("error getting treasury balance of %s: %w", recipient.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get the RPL a recipient could claim by paying out its contracts and withdrawing: its balance plus the pending amount of
+// each of its payment contracts
+func GetClaimableAmount(rp *rocketpool.RocketPool, recipient common.Address, contracts []PaymentContract, opts *bind.CallOpts) (*big.Int, error) {
+	balance, err := GetBalance(rp, recipient, opts)
+	if err != nil {
+		return nil, err
+	}
+	claimable := new(big.Int).Set(balance)
+	for _, contract := range contracts {
+		if contract.Recipient == recipient && contract.PendingAmount != nil {
+			claimable.Add(claimable, contract.PendingAmount)
+		}
+	}
+	return claimable, nil
+}
+
+// Get the RPL held in the vault for the treasury
+func GetTreasuryBalance(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketVault, err := getRocketVault(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rplAddress, err := rp.GetAddress("rocketTokenRPL", opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := rocketVault.Call(opts, value, "balanceOfToken", "rocketClaimDAO", *rplAddress); err != nil {
+		return nil, fmt.Errorf("error getting treasury balance: %w", err)
+	}
+	return *value, nil
+}
+
+// Estimate the gas of PayOutContracts
+func EstimatePayOutContractsGas(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "payOutContracts", contractNames)
+}
+
+// Pay the elapsed periods of payment contracts into their recipients' balances
+func PayOutContracts(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "payOutContracts", contractNames)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error paying out treasury contracts: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of PayOutContractsAndWithdraw
+func EstimatePayOutContractsAndWithdrawGas(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "payOutContractsAndWithdraw", contractNames)
+}
+
+// Pay out payment contracts and withdraw the sender's balance in one transaction
+func PayOutContractsAndWithdraw(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "payOutContractsAndWithdraw", contractNames)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error paying out treasury contracts and withdrawing: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of WithdrawBalance
+func EstimateWithdrawBalanceGas(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "withdrawBalance", recipient)
+}
+
+// Claim a recipient's balance; the sender must be the recipient, or its withdrawal address if the recipient is a node
+func WithdrawBalance(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "withdrawBalance", recipient)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error withdrawing treasury balance of %s: %w", recipient.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a payment contract, with its pending amount as of a time
+func getPaymentContract(rp *rocketpool.RocketPool, contractName string, now time.Time, opts *bind.CallOpts) (PaymentContract, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
+	if err != nil {
+		return PaymentContract{}, err
+	}
+	raw := new(paymentContractRaw)
+	if err := rocketClaimDAO.Call(opts, raw, "getContract", contractName); err != nil {
+		return PaymentContract{}, fmt.Errorf("error getting treasury contract %s: %w", contractName, err)
+	}
+	contract := PaymentContract{
+		Name:            contractName,
+		Recipient:       raw.Recipient,
+		AmountPerPeriod: raw.AmountPerPeriod,
+		PeriodLength:    time.Duration(raw.PeriodLength.Uint64()) * time.Second,
+		LastPaymentTime: time.Unix(raw.LastPaymentTime.Int64(), 0),
+		NumberOfPeriods: raw.NumPeriods.Uint64(),
+		PeriodsPaid:     raw.PeriodsPaid.Uint64(),
+	}
+	contract.StartTime = contract.LastPaymentTime.Add(-contract.PeriodLength * time.Duration(contract.PeriodsPaid))
+	contract.PendingAmount = contract.GetPendingAmount(now)
+	return contract, nil
+}
+
+// Get the timestamp of the block in the call options, or the latest block
+func getBlockTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Time, error) {
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error getting block header: %w", err)
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// Get contracts
+func getRocketClaimDAO(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	return rp.GetContract("rocketClaimDAO", opts)
+}
+func getRocketVault(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	return rp.GetContract("rocketVault", opts)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -526,10 +525,6 @@ func DefeatProposal(rp *rocketpool.RocketPool, proposalId uint64, index uint64,
 }
 
 // Get contracts
-var rocketDAOProtocolVerifierLock sync.Mutex
-
 func getRocketDAOProtocolVerifier(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOProtocolVerifierLock.Lock()
-	defer rocketDAOProtocolVerifierLock.Unlock()
 	return rp.GetContract("rocketDAOProtocolVerifier", opts)
 }
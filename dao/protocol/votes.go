@@ -0,0 +1,336 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+)
+
+// ====================
+// === Vote History ===
+// ====================
+
+// A vote or vote override on a proposal
+type ProposalVoteEvent struct {
+	ProposalID  uint64              `json:"proposalId"`
+	Voter       common.Address      `json:"voter"`
+	Delegate    common.Address      `json:"delegate"` // The delegate whose vote was overridden; empty for regular votes
+	Direction   types.VoteDirection `json:"direction"`
+	VotingPower *big.Int            `json:"votingPower"`
+	IsOverride  bool                `json:"isOverride"`
+	BlockNumber uint64              `json:"blockNumber"`
+	TxHash      common.Hash         `json:"txHash"`
+	LogIndex    uint                `json:"logIndex"`
+	Time        time.Time           `json:"time"`
+}
+
+// How an address voted on a proposal
+type ProposalVoter struct {
+	Address               common.Address      `json:"address"`
+	Direction             types.VoteDirection `json:"direction"`
+	VotingPower           *big.Int            `json:"votingPower"`           // The voting power the vote was cast with
+	OverriddenVotingPower *big.Int            `json:"overriddenVotingPower"` // Removed from the vote by delegators that overrode it
+	EffectiveVotingPower  *big.Int            `json:"effectiveVotingPower"`  // What the vote counts for in the tallies
+	IsOverride            bool                `json:"isOverride"`
+	OverriddenDelegate    common.Address      `json:"overriddenDelegate"`
+	BlockNumber           uint64              `json:"blockNumber"`
+	TxHash                common.Hash         `json:"txHash"`
+	Time                  time.Time           `json:"time"`
+}
+
+// The voting power tallies of a proposal
+// Votes against with a veto count towards both the against and veto tallies
+type ProposalVoteTotals struct {
+	For       *big.Int `json:"for"`
+	Against   *big.Int `json:"against"`
+	Abstained *big.Int `json:"abstained"`
+	Veto      *big.Int `json:"veto"`
+}
+
+// Every vote on a proposal and the tallies they add up to
+// If any overrides are unmatched, the delegates' votes weren't in the events (e.g. they were cast before the start block) and the totals won't match the contract's
+type ProposalVoteHistory struct {
+	ProposalID         uint64             `json:"proposalId"`
+	Voters             []ProposalVoter    `json:"voters"` // In the order they voted
+	Totals             ProposalVoteTotals `json:"totals"`
+	UnmatchedOverrides []common.Address   `json:"unmatchedOverrides"` // Voters that overrode a delegate vote that isn't in the history
+}
+
+// Get the vote history of a proposal
+func GetProposalVoteHistory(rp *rocketpool.RocketPool, proposalId uint64, intervalSize *big.Int, startBlock *big.Int, endBlock *big.Int, opts *bind.CallOpts) (*ProposalVoteHistory, error) {
+	histories, err := GetProposalVoteHistories(rp, []uint64{proposalId}, intervalSize, startBlock, endBlock, opts)
+	if err != nil {
+		return nil, err
+	}
+	if history, exists := histories[proposalId]; exists {
+		return history, nil
+	}
+	return BuildProposalVoteHistory(proposalId, []ProposalVoteEvent{}), nil
+}
+
+// Get the vote histories of a set of proposals, or every proposal voted on in the block range if no IDs are provided
+// The direction of each override is read from its vote receipt, since the override event doesn't include it
+func GetProposalVoteHistories(rp *rocketpool.RocketPool, proposalIds []uint64, intervalSize *big.Int, startBlock *big.Int, endBlock *big.Int, opts *bind.CallOpts) (map[uint64]*ProposalVoteHistory, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct a filter query for relevant logs
+	topics := [][]common.Hash{{
+		rocketDAOProtocolProposal.ABI.Events["ProposalVoted"].ID,
+		rocketDAOProtocolProposal.ABI.Events["ProposalVoteOverridden"].ID,
+	}}
+	if len(proposalIds) > 0 {
+		idBuffers := make([]common.Hash, len(proposalIds))
+		for i, id := range proposalIds {
+			proposalIdBig := big.NewInt(0).SetUint64(id)
+			proposalIdBig.FillBytes(idBuffers[i][:])
+		}
+		topics = append(topics, idBuffers)
+	}
+
+	// Get the event logs
+	logs, err := eth.FilterContractLogs(rp, "rocketDAOProtocolProposal", eth.FilterQuery{
+		FromBlock: startBlock,
+		ToBlock:   endBlock,
+		Topics:    topics,
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	events, err := DecodeProposalVoteEvents(rocketDAOProtocolProposal.ABI, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the direction of each override
+	var wg errgroup.Group
+	for i := range events {
+		if !events[i].IsOverride {
+			continue
+		}
+		event := &events[i]
+		wg.Go(func() error {
+			var err error
+			event.Direction, err = GetAddressVoteDirection(rp, event.ProposalID, event.Voter, opts)
+			return err
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Build the histories
+	proposalEvents := map[uint64][]ProposalVoteEvent{}
+	for _, event := range events {
+		proposalEvents[event.ProposalID] = append(proposalEvents[event.ProposalID], event)
+	}
+	histories := map[uint64]*ProposalVoteHistory{}
+	for proposalId, events := range proposalEvents {
+		histories[proposalId] = BuildProposalVoteHistory(proposalId, events)
+	}
+	return histories, nil
+}
+
+// Decode vote and vote override logs, ordered by when they happened
+// Logs that aren't vote events are skipped; the direction of overrides is left empty
+func DecodeProposalVoteEvents(contractAbi *abi.ABI, logs []ethtypes.Log) ([]ProposalVoteEvent, error) {
+	votedEvent := contractAbi.Events["ProposalVoted"]
+	overriddenEvent := contractAbi.Events["ProposalVoteOverridden"]
+
+	events := make([]ProposalVoteEvent, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		switch log.Topics[0] {
+		case votedEvent.ID:
+			// Get the topic values
+			if len(log.Topics) < 3 {
+				return nil, fmt.Errorf("ProposalVoted event had %d topics but at least 3 are required", len(log.Topics))
+			}
+			values := map[string]interface{}{}
+			if err := votedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error unpacking ProposalVoted event data: %w", err)
+			}
+			direction, ok := values["direction"].(uint8)
+			if !ok {
+				direction, _ = values["vote"].(uint8)
+			}
+			events = append(events, ProposalVoteEvent{
+				ProposalID:  new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64(),
+				Voter:       common.BytesToAddress(log.Topics[2].Bytes()),
+				Direction:   types.VoteDirection(direction),
+				VotingPower: getEventUint(values, "votingPower"),
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+				LogIndex:    log.Index,
+				Time:        time.Unix(getEventUint(values, "time").Int64(), 0),
+			})
+
+		case overriddenEvent.ID:
+			// Get the topic values
+			if len(log.Topics) < 4 {
+				return nil, fmt.Errorf("ProposalVoteOverridden event had %d topics but at least 4 are required", len(log.Topics))
+			}
+			values := map[string]interface{}{}
+			if err := overriddenEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error unpacking ProposalVoteOverridden event data: %w", err)
+			}
+			events = append(events, ProposalVoteEvent{
+				ProposalID:  new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64(),
+				Delegate:    common.BytesToAddress(log.Topics[2].Bytes()),
+				Voter:       common.BytesToAddress(log.Topics[3].Bytes()),
+				VotingPower: getEventUint(values, "votingPower"),
+				IsOverride:  true,
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+				LogIndex:    log.Index,
+				Time:        time.Unix(getEventUint(values, "time").Int64(), 0),
+			})
+		}
+	}
+
+	// Logs from different deployments are returned separately, so put them back in order
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].BlockNumber != events[j].BlockNumber {
+			return events[i].BlockNumber < events[j].BlockNumber
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+	return events, nil
+}
+
+// Build a proposal's vote history from its vote events
+// Overrides move the overriding node's voting power from its delegate's direction to its own, the same way the contract does
+func BuildProposalVoteHistory(proposalId uint64, events []ProposalVoteEvent) *ProposalVoteHistory {
+	history := &ProposalVoteHistory{
+		ProposalID:         proposalId,
+		Voters:             []ProposalVoter{},
+		Totals:             newProposalVoteTotals(),
+		UnmatchedOverrides: []common.Address{},
+	}
+	voterIndices := map[common.Address]int{}
+	for _, event := range events {
+		if event.ProposalID != proposalId {
+			continue
+		}
+		votingPower := event.VotingPower
+		if votingPower == nil {
+			votingPower = big.NewInt(0)
+		}
+
+		// Remove the overridden voting power from the delegate's vote
+		if event.IsOverride {
+			if index, exists := voterIndices[event.Delegate]; exists {
+				delegate := &history.Voters[index]
+				delegate.OverriddenVotingPower.Add(delegate.OverriddenVotingPower, votingPower)
+				delegate.EffectiveVotingPower.Sub(delegate.EffectiveVotingPower, votingPower)
+				history.Totals.add(delegate.Direction, new(big.Int).Neg(votingPower))
+			} else {
+				history.UnmatchedOverrides = append(history.UnmatchedOverrides, event.Voter)
+			}
+		}
+
+		// Record the vote
+		history.Totals.add(event.Direction, votingPower)
+		voterIndices[event.Voter] = len(history.Voters)
+		history.Voters = append(history.Voters, ProposalVoter{
+			Address:               event.Voter,
+			Direction:             event.Direction,
+			VotingPower:           votingPower,
+			OverriddenVotingPower: big.NewInt(0),
+			EffectiveVotingPower:  new(big.Int).Set(votingPower),
+			IsOverride:            event.IsOverride,
+			OverriddenDelegate:    event.Delegate,
+			BlockNumber:           event.BlockNumber,
+			TxHash:                event.TxHash,
+			Time:                  event.Time,
+		})
+	}
+	return history
+}
+
+// Get a proposal's voting power tallies from the contract
+func GetProposalVoteTotals(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (ProposalVoteTotals, error) {
+	var wg errgroup.Group
+	var totals ProposalVoteTotals
+	wg.Go(func() error {
+		var err error
+		totals.For, err = GetProposalVotingPowerFor(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		totals.Against, err = GetProposalVotingPowerAgainst(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		totals.Abstained, err = GetProposalVotingPowerAbstained(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		totals.Veto, err = GetProposalVotingPowerVetoed(rp, proposalId, opts)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return ProposalVoteTotals{}, err
+	}
+	return totals, nil
+}
+
+// Check if two sets of tallies match, e.g. a vote history's and the contract's
+func (t ProposalVoteTotals) Equals(other ProposalVoteTotals) bool {
+	return bigEquals(t.For, other.For) && bigEquals(t.Against, other.Against) && bigEquals(t.Abstained, other.Abstained) && bigEquals(t.Veto, other.Veto)
+}
+
+// Create empty vote tallies
+func newProposalVoteTotals() ProposalVoteTotals {
+	return ProposalVoteTotals{
+		For:       big.NewInt(0),
+		Against:   big.NewInt(0),
+		Abstained: big.NewInt(0),
+		Veto:      big.NewInt(0),
+	}
+}
+
+// Add voting power to the tallies for a direction
+func (t ProposalVoteTotals) add(direction types.VoteDirection, votingPower *big.Int) {
+	switch direction {
+	case types.VoteDirection_For:
+		t.For.Add(t.For, votingPower)
+	case types.VoteDirection_Against:
+		t.Against.Add(t.Against, votingPower)
+	case types.VoteDirection_AgainstWithVeto:
+		t.Against.Add(t.Against, votingPower)
+		t.Veto.Add(t.Veto, votingPower)
+	case types.VoteDirection_Abstain:
+		t.Abstained.Add(t.Abstained, votingPower)
+	}
+}
+
+// Get a uint256 event value, or zero if the event doesn't have it
+func getEventUint(values map[string]interface{}, name string) *big.Int {
+	if value, ok := values[name].(*big.Int); ok {
+		return value
+	}
+	return big.NewInt(0)
+}
+
+// Check if two amounts are equal, treating a missing value as zero
+func bigEquals(a *big.Int, b *big.Int) bool {
+	return bigOrZero(a).Cmp(bigOrZero(b)) == 0
+}
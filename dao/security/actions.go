@@ -2,7 +2,6 @@ package security
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -121,10 +120,6 @@ func Leave(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, err
 }
 
 // Get contracts
-var rocketDAOSecurityActionsLock sync.Mutex
-
 func getRocketDAOSecurityActions(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOSecurityActionsLock.Lock()
-	defer rocketDAOSecurityActionsLock.Unlock()
 	return rp.GetContract("rocketDAOSecurityActions", opts)
 }
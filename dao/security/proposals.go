@@ -3,7 +3,6 @@ package security
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -157,10 +156,6 @@ func ExecuteProposal(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.Tr
 }
 
 // Get contracts
-var rocketDAOSecurityProposalsLock sync.Mutex
-
 func getRocketDAOSecurityProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOSecurityProposalsLock.Lock()
-	defer rocketDAOSecurityProposalsLock.Unlock()
 	return rp.GetContract("rocketDAOSecurityProposals", opts)
 }
@@ -3,7 +3,6 @@ package security
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -240,10 +239,6 @@ func GetMemberProposalExecutedTime(rp *rocketpool.RocketPool, proposalType strin
 }
 
 // Get contracts
-var rocketDAOSecurityLock sync.Mutex
-
 func getRocketDAOSecurity(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAOSecurityLock.Lock()
-	defer rocketDAOSecurityLock.Unlock()
 	return rp.GetContract("rocketDAOSecurity", opts)
 }
@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -138,10 +137,6 @@ func GetLatestMemberCountChangedBlock(rp *rocketpool.RocketPool, fromBlock uint6
 }
 
 // Get contracts
-var rocketDAONodeTrustedActionsLock sync.Mutex
-
 func getRocketDAONodeTrustedActions(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAONodeTrustedActionsLock.Lock()
-	defer rocketDAONodeTrustedActionsLock.Unlock()
 	return rp.GetContract("rocketDAONodeTrustedActions", opts)
 }
@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -354,10 +353,6 @@ func GetMemberIsChallenged(rp *rocketpool.RocketPool, memberAddress common.Addre
 }
 
 // Get contracts
-var rocketDAONodeTrustedLock sync.Mutex
-
 func getRocketDAONodeTrusted(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAONodeTrustedLock.Lock()
-	defer rocketDAONodeTrustedLock.Unlock()
 	return rp.GetContract("rocketDAONodeTrusted", opts)
 }
@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -301,10 +300,6 @@ func ExecuteProposal(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.Tr
 }
 
 // Get contracts
-var rocketDAONodeTrustedProposalsLock sync.Mutex
-
 func getRocketDAONodeTrustedProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAONodeTrustedProposalsLock.Lock()
-	defer rocketDAONodeTrustedProposalsLock.Unlock()
 	return rp.GetContract("rocketDAONodeTrustedProposals", opts)
 }
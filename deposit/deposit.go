@@ -3,7 +3,6 @@ package deposit
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -95,10 +94,6 @@ func AssignDeposits(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.
 }
 
 // Get contracts
-var rocketDepositPoolLock sync.Mutex
-
 func getRocketDepositPool(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDepositPoolLock.Lock()
-	defer rocketDepositPoolLock.Unlock()
 	return rp.GetContract("rocketDepositPool", opts)
 }
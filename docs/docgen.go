@@ -34,25 +34,34 @@ func main() {
 
 	// These are all of the packages to generate the source for
 	packages := map[string]string{
-		"auction":              "%s/../auction",
-		"contracts":            "%s/../contracts",
-		"dao":                  "%s/../dao",
-		"dao-protocol":         "%s/../dao/protocol",
-		"dao-trustednode":      "%s/../dao/trustednode",
-		"deposit":              "%s/../deposit",
-		"minipool":             "%s/../minipool",
-		"network":              "%s/../network",
-		"node":                 "%s/../node",
-		"rewards":              "%s/../rewards",
-		"rocketpool":           "%s/../rocketpool",
-		"settings-protocol":    "%s/../settings/protocol",
-		"settings-trustednode": "%s/../settings/trustednode",
-		"storage":              "%s/../storage",
-		"tokens":               "%s/../tokens",
-		"types":                "%s/../types",
-		"utils":                "%s/../utils",
-		"utils-eth":            "%s/../utils/eth",
-		"utils-strings":        "%s/../utils/strings",
+		"auction":                 "%s/../auction",
+		"codegen":                 "%s/../codegen",
+		"contracts":               "%s/../contracts",
+		"dao":                     "%s/../dao",
+		"dao-impact":              "%s/../dao/impact",
+		"dao-protocol":            "%s/../dao/protocol",
+		"dao-protocol-delegation": "%s/../dao/protocol/delegation",
+		"dao-protocol-payload":    "%s/../dao/protocol/payload",
+		"dao-protocol-treasury":   "%s/../dao/protocol/treasury",
+		"dao-trustednode":         "%s/../dao/trustednode",
+		"deposit":                 "%s/../deposit",
+		"minipool":                "%s/../minipool",
+		"network":                 "%s/../network",
+		"networks":                "%s/../networks",
+		"node":                    "%s/../node",
+		"rewards":                 "%s/../rewards",
+		"rocketpool":              "%s/../rocketpool",
+		"settings":                "%s/../settings",
+		"settings-protocol":       "%s/../settings/protocol",
+		"settings-trustednode":    "%s/../settings/trustednode",
+		"signer":                  "%s/../signer",
+		"storage":                 "%s/../storage",
+		"tokens":                  "%s/../tokens",
+		"types":                   "%s/../types",
+		"utils":                   "%s/../utils",
+		"utils-drift":             "%s/../utils/drift",
+		"utils-eth":               "%s/../utils/eth",
+		"utils-strings":           "%s/../utils/strings",
 	}
 
 	// Build the documentation file for each package
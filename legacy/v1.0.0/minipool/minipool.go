@@ -539,11 +539,7 @@ func GetMinipoolWithdrawalCredentials(rp *rocketpool.RocketPool, minipoolAddress
 }
 
 // Get contracts
-var rocketMinipoolManagerLock sync.Mutex
-
 func getRocketMinipoolManager(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolManagerLock.Lock()
-	defer rocketMinipoolManagerLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_0_0.GetContract("rocketMinipoolManager", opts)
 	} else {
@@ -2,7 +2,6 @@ package rewards
 
 import (
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -119,11 +118,7 @@ func GetNodeTotalClaimed(rp *rocketpool.RocketPool, opts *bind.CallOpts, legacyR
 }
 
 // Get contracts
-var rocketClaimNodeLock sync.Mutex
-
 func getRocketClaimNode(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketClaimNodeLock.Lock()
-	defer rocketClaimNodeLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_0_0.GetContract("rocketClaimNode", opts)
 	} else {
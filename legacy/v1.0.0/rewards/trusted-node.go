@@ -2,7 +2,6 @@ package rewards
 
 import (
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -119,11 +118,7 @@ func GetTrustedNodeTotalClaimed(rp *rocketpool.RocketPool, opts *bind.CallOpts,
 }
 
 // Get contracts
-var rocketClaimTrustedNodeLock sync.Mutex
-
 func getRocketClaimTrustedNode(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketClaimTrustedNodeLock.Lock()
-	defer rocketClaimTrustedNodeLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_0_0.GetContract("rocketClaimTrustedNode", opts)
 	} else {
@@ -3,7 +3,6 @@ package utils
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -62,11 +61,7 @@ func GenerateAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, depo
 }
 
 // Get contracts
-var rocketMinipoolManagerLock sync.Mutex
-
 func getRocketMinipoolManager(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolManagerLock.Lock()
-	defer rocketMinipoolManagerLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_0_0.GetContract("rocketMinipoolManager", opts)
 	} else {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -293,11 +292,7 @@ func GetRewardSnapshotEventWithUpgrades(rp *rocketpool.RocketPool, index uint64,
 }
 
 // Get contracts
-var rocketRewardsPoolLock sync.Mutex
-
 func getRocketRewardsPool(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketRewardsPoolLock.Lock()
-	defer rocketRewardsPoolLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0_RC1.GetContract("rocketRewardsPool", opts)
 	} else {
@@ -2,7 +2,6 @@ package minipool
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,11 +23,7 @@ func GetMinipoolBytecode(rp *rocketpool.RocketPool, opts *bind.CallOpts, legacyR
 }
 
 // Get contracts
-var rocketMinipoolFactoryLock sync.Mutex
-
 func getRocketMinipoolFactory(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolFactoryLock.Lock()
-	defer rocketMinipoolFactoryLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0.GetContract("rocketMinipoolFactory", opts)
 	} else {
@@ -3,7 +3,6 @@ package minipool
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -289,11 +288,7 @@ func GetQueueMinipoolAtPosition(rp *rocketpool.RocketPool, position uint64, opts
 }
 
 // Get contracts
-var rocketMinipoolQueueLock sync.Mutex
-
 func getRocketMinipoolQueue(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolQueueLock.Lock()
-	defer rocketMinipoolQueueLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0.GetContract("rocketMinipoolQueue", opts)
 	} else {
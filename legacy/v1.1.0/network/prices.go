@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -86,11 +85,7 @@ func GetLatestReportablePricesBlock(rp *rocketpool.RocketPool, opts *bind.CallOp
 }
 
 // Get contracts
-var rocketNetworkPricesLock sync.Mutex
-
 func getRocketNetworkPrices(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkPricesLock.Lock()
-	defer rocketNetworkPricesLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0.GetContract("rocketNetworkPrices", opts)
 	} else {
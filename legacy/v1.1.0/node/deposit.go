@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -51,11 +50,7 @@ func GetDepositType(rp *rocketpool.RocketPool, amount *big.Int, opts *bind.CallO
 }
 
 // Get contracts
-var rocketNodeDepositLock sync.Mutex
-
 func getRocketNodeDeposit(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeDepositLock.Lock()
-	defer rocketNodeDepositLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0.GetContract("rocketNodeDeposit", opts)
 	} else {
@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -198,11 +197,7 @@ func CalculateTotalEffectiveRPLStake(rp *rocketpool.RocketPool, offset, limit, r
 }
 
 // Get contracts
-var rocketNodeStakingLock sync.Mutex
-
 func getRocketNodeStaking(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeStakingLock.Lock()
-	defer rocketNodeStakingLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_1_0.GetContract("rocketNodeStaking", opts)
 	} else {
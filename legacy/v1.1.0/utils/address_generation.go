@@ -3,7 +3,6 @@ package utils
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -62,10 +61,6 @@ func GenerateAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, depo
 }
 
 // Get contracts
-var rocketMinipoolFactoryLock sync.Mutex
-
 func getRocketMinipoolFactory(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolFactoryLock.Lock()
-	defer rocketMinipoolFactoryLock.Unlock()
 	return rp.GetContract("rocketMinipoolFactory", opts)
 }
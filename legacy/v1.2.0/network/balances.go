@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -126,11 +125,7 @@ func GetLatestReportableBalancesBlock(rp *rocketpool.RocketPool, opts *bind.Call
 }
 
 // Get contracts
-var rocketNetworkBalancesLock sync.Mutex
-
 func getRocketNetworkBalances(rp *rocketpool.RocketPool, address *common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkBalancesLock.Lock()
-	defer rocketNetworkBalancesLock.Unlock()
 	if address == nil {
 		return rp.VersionManager.V1_2_0.GetContract("rocketNetworkBalances", opts)
 	}
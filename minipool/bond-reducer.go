@@ -3,7 +3,6 @@ package minipool
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -134,10 +133,6 @@ func BeginReduceBondAmount(rp *rocketpool.RocketPool, minipoolAddress common.Add
 }
 
 // Get contracts
-var rocketMinipoolBondReducerLock sync.Mutex
-
 func getRocketMinipoolBondReducer(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolBondReducerLock.Lock()
-	defer rocketMinipoolBondReducerLock.Unlock()
 	return rp.GetContract("rocketMinipoolBondReducer", opts)
 }
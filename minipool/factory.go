@@ -3,7 +3,6 @@ package minipool
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -25,10 +24,6 @@ func GetExpectedAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, s
 }
 
 // Get contracts
-var rocketMinipoolFactoryLock sync.Mutex
-
 func getRocketMinipoolFactory(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolFactoryLock.Lock()
-	defer rocketMinipoolFactoryLock.Unlock()
 	return rp.GetContract("rocketMinipoolFactory", opts)
 }
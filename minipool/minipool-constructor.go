@@ -3,7 +3,6 @@ package minipool
 import (
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -60,10 +59,12 @@ func createMinipoolContractFromEncodedAbi(rp *rocketpool.RocketPool, address com
 
 	// Create and return
 	return &rocketpool.Contract{
-		Contract: bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  &address,
-		ABI:      abi,
-		Client:   rp.Client,
+		Contract:            bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             &address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                "rocketMinipool",
+		GasLimitMultipliers: rp.GasLimitMultipliers,
 	}, nil
 }
 
@@ -71,18 +72,16 @@ func createMinipoolContractFromEncodedAbi(rp *rocketpool.RocketPool, address com
 func createMinipoolContractFromAbi(rp *rocketpool.RocketPool, address common.Address, abi *abi.ABI) (*rocketpool.Contract, error) {
 	// Create and return
 	return &rocketpool.Contract{
-		Contract: bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  &address,
-		ABI:      abi,
-		Client:   rp.Client,
+		Contract:            bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             &address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                "rocketMinipool",
+		GasLimitMultipliers: rp.GasLimitMultipliers,
 	}, nil
 }
 
 // Get a minipool contract
-var rocketMinipoolLock sync.Mutex
-
 func getMinipoolContract(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolLock.Lock()
-	defer rocketMinipoolLock.Unlock()
 	return rp.MakeContract("rocketMinipool", minipoolAddress, opts)
 }
@@ -617,10 +617,6 @@ func GetMinipoolDepositType(rp *rocketpool.RocketPool, minipoolAddress common.Ad
 }
 
 // Get contracts
-var rocketMinipoolManagerLock sync.Mutex
-
 func getRocketMinipoolManager(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolManagerLock.Lock()
-	defer rocketMinipoolManagerLock.Unlock()
 	return rp.GetContract("rocketMinipoolManager", opts)
 }
@@ -3,7 +3,6 @@ package minipool
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -135,10 +134,6 @@ func GetQueueMinipoolAtPosition(rp *rocketpool.RocketPool, position uint64, opts
 }
 
 // Get contracts
-var rocketMinipoolQueueLock sync.Mutex
-
 func getRocketMinipoolQueue(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolQueueLock.Lock()
-	defer rocketMinipoolQueueLock.Unlock()
-	return rp.GetContract("rocketMinipoolQueue", opts)
+	return rp.VersionManager.GetContractAtBlock("rocketMinipoolQueue", opts)
 }
@@ -2,7 +2,6 @@ package minipool
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,10 +32,6 @@ func SubmitMinipoolWithdrawable(rp *rocketpool.RocketPool, minipoolAddress commo
 }
 
 // Get contracts
-var rocketMinipoolStatusLock sync.Mutex
-
 func getRocketMinipoolStatus(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketMinipoolStatusLock.Lock()
-	defer rocketMinipoolStatusLock.Unlock()
 	return rp.GetContract("rocketMinipoolStatus", opts)
 }
@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -220,10 +219,6 @@ func GetBalancesUpdatedEvent(rp *rocketpool.RocketPool, blockNumber uint64, opts
 }
 
 // Get contracts
-var rocketNetworkBalancesLock sync.Mutex
-
 func getRocketNetworkBalances(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkBalancesLock.Lock()
-	defer rocketNetworkBalancesLock.Unlock()
-	return rp.GetContract("rocketNetworkBalances", opts)
+	return rp.VersionManager.GetContractAtBlock("rocketNetworkBalances", opts)
 }
@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
@@ -51,10 +50,6 @@ func GetNodeFeeByDemand(rp *rocketpool.RocketPool, nodeDemand *big.Int, opts *bi
 }
 
 // Get contracts
-var rocketNetworkFeesLock sync.Mutex
-
 func getRocketNetworkFees(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkFeesLock.Lock()
-	defer rocketNetworkFeesLock.Unlock()
 	return rp.GetContract("rocketNetworkFees", opts)
 }
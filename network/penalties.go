@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -34,10 +33,6 @@ func SubmitPenalty(rp *rocketpool.RocketPool, minipoolAddress common.Address, bl
 }
 
 // Get contracts
-var rocketNetworkPenaltiesLock sync.Mutex
-
 func getRocketNetworkPenalties(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkPenaltiesLock.Lock()
-	defer rocketNetworkPenaltiesLock.Unlock()
 	return rp.GetContract("rocketNetworkPenalties", opts)
 }
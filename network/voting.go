@@ -3,7 +3,6 @@ package network
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -211,10 +210,6 @@ func SetVotingDelegate(rp *rocketpool.RocketPool, newDelegate common.Address, op
 }
 
 // Get contracts
-var rocketNetworkVotingLock sync.Mutex
-
 func getRocketNetworkVoting(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkVotingLock.Lock()
-	defer rocketNetworkVotingLock.Unlock()
 	return rp.GetContract("rocketNetworkVoting", opts)
 }
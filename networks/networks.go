@@ -0,0 +1,206 @@
+package networks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+// Built-in preset names
+const (
+	Mainnet string = "mainnet"
+	Holesky string = "holesky"
+)
+
+// The addresses and deployment details of a Rocket Pool network
+type Preset struct {
+	Name                   string         `json:"name"`
+	ChainID                uint64         `json:"chainId"`
+	StorageAddress         common.Address `json:"storageAddress"`
+	MulticallAddress       common.Address `json:"multicallAddress"`
+	BalanceBatcherAddress  common.Address `json:"balanceBatcherAddress"`
+	DepositContractAddress common.Address `json:"depositContractAddress"`
+	DeployBlock            uint64         `json:"deployBlock,omitempty"` // 0 if unknown
+	IsHoustonDeployed      bool           `json:"isHoustonDeployed"`
+	HoustonDeployBlock     uint64         `json:"houstonDeployBlock,omitempty"` // 0 if unknown
+}
+
+// Registered presets, keyed by name
+// Block hints that aren't known are left at 0; the deploy block is then read from RocketStorage and Houston's activation isn't checked
+var presets = map[string]Preset{
+	Mainnet: {
+		Name:                   Mainnet,
+		ChainID:                1,
+		StorageAddress:         common.HexToAddress("0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"),
+		MulticallAddress:       common.HexToAddress("0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696"),
+		BalanceBatcherAddress:  common.HexToAddress("0xb1f8e55c7f64d203c1400b9d8555d050f94adf39"),
+		DepositContractAddress: common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+		DeployBlock:            13325233,
+		IsHoustonDeployed:      true,
+	},
+	Holesky: {
+		Name:                   Holesky,
+		ChainID:                17000,
+		StorageAddress:         common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
+		MulticallAddress:       common.HexToAddress("0x0540b786f03c9491f3a2ab4b0E3ae4A38e9FBA4b"),
+		BalanceBatcherAddress:  common.HexToAddress("0xfAa2e7C84eD801dd9D27Ac1ed957274530796140"),
+		DepositContractAddress: common.HexToAddress("0x4242424242424242424242424242424242424242"),
+		IsHoustonDeployed:      true,
+	},
+}
+var presetsLock sync.RWMutex
+
+// Get the preset with the given name
+func GetPreset(name string) (Preset, error) {
+	presetsLock.RLock()
+	defer presetsLock.RUnlock()
+	preset, ok := presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown network preset '%s'", name)
+	}
+	return preset, nil
+}
+
+// Get the names of all registered presets
+func GetPresetNames() []string {
+	presetsLock.RLock()
+	defer presetsLock.RUnlock()
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Register a custom preset, such as a devnet
+// Registering a preset with an existing name replaces it
+func RegisterPreset(preset Preset) error {
+	if err := preset.Validate(); err != nil {
+		return err
+	}
+	presetsLock.Lock()
+	defer presetsLock.Unlock()
+	presets[preset.Name] = preset
+	return nil
+}
+
+// Load and register the custom presets in a JSON config file
+// The file holds a list of presets with the same fields as the Preset type
+func LoadPresets(path string) ([]Preset, error) {
+
+	// Read the file
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading network preset file %s: %w", path, err)
+	}
+	var loaded []Preset
+	if err := json.Unmarshal(bytes, &loaded); err != nil {
+		return nil, fmt.Errorf("error decoding network preset file %s: %w", path, err)
+	}
+
+	// Validate all of the presets before registering any of them
+	for _, preset := range loaded {
+		if err := preset.Validate(); err != nil {
+			return nil, fmt.Errorf("error loading network preset file %s: %w", path, err)
+		}
+	}
+	for _, preset := range loaded {
+		if err := RegisterPreset(preset); err != nil {
+			return nil, err
+		}
+	}
+	return loaded, nil
+
+}
+
+// Check that a preset has everything needed to connect to its network
+func (p Preset) Validate() error {
+	emptyAddress := common.Address{}
+	if p.Name == "" {
+		return fmt.Errorf("network preset is missing a name")
+	}
+	if p.ChainID == 0 {
+		return fmt.Errorf("network preset '%s' is missing a chain ID", p.Name)
+	}
+	if p.StorageAddress == emptyAddress {
+		return fmt.Errorf("network preset '%s' is missing the RocketStorage address", p.Name)
+	}
+	if p.MulticallAddress == emptyAddress {
+		return fmt.Errorf("network preset '%s' is missing the multicall address", p.Name)
+	}
+	if p.BalanceBatcherAddress == emptyAddress {
+		return fmt.Errorf("network preset '%s' is missing the balance batcher address", p.Name)
+	}
+	if p.HoustonDeployBlock != 0 && !p.IsHoustonDeployed {
+		return fmt.Errorf("network preset '%s' has a Houston deploy block but Houston isn't deployed", p.Name)
+	}
+	if p.HoustonDeployBlock != 0 && p.HoustonDeployBlock < p.DeployBlock {
+		return fmt.Errorf("network preset '%s' has a Houston deploy block before its deploy block", p.Name)
+	}
+	return nil
+}
+
+// Get the preset's chain ID as a big.Int, for signing
+func (p Preset) GetChainID() *big.Int {
+	return new(big.Int).SetUint64(p.ChainID)
+}
+
+// Check if Houston was deployed as of the block in the call options
+// If the deploy block is unknown, the preset's flag is used regardless of the block
+func (p Preset) IsHoustonDeployedAt(opts *bind.CallOpts) bool {
+	if !p.IsHoustonDeployed {
+		return false
+	}
+	if p.HoustonDeployBlock == 0 || opts == nil || opts.BlockNumber == nil {
+		return true
+	}
+	return opts.BlockNumber.Uint64() >= p.HoustonDeployBlock
+}
+
+// Create a Rocket Pool manager and the network contracts for the network with the given preset name
+func NewRocketPool(client rocketpool.ExecutionClient, presetName string, opts *bind.CallOpts) (*rocketpool.RocketPool, *state.NetworkContracts, Preset, error) {
+	preset, err := GetPreset(presetName)
+	if err != nil {
+		return nil, nil, Preset{}, err
+	}
+	rp, contracts, err := preset.NewRocketPool(client, opts)
+	if err != nil {
+		return nil, nil, Preset{}, err
+	}
+	return rp, contracts, preset, nil
+}
+
+// Create a Rocket Pool manager and the network contracts for the preset's network
+// The protocol features deployed on the network are detected at the block in the call options, and checked against the preset's Houston deploy block if it's known
+func (p Preset) NewRocketPool(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*rocketpool.RocketPool, *state.NetworkContracts, error) {
+	rp, err := rocketpool.NewRocketPool(client, p.StorageAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating Rocket Pool manager for network '%s': %w", p.Name, err)
+	}
+	multicallAddress := p.MulticallAddress
+	rp.MulticallAddress = &multicallAddress
+	if p.DeployBlock != 0 {
+		rp.AddressHistory.SetDeployBlock(p.DeployBlock)
+	}
+	contracts, err := state.NewNetworkContracts(rp, p.MulticallAddress, p.BalanceBatcherAddress, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating network contracts for network '%s': %w", p.Name, err)
+	}
+	if p.HoustonDeployBlock != 0 {
+		isHoustonDeployed := p.IsHoustonDeployedAt(&bind.CallOpts{BlockNumber: contracts.ElBlockNumber})
+		if isHoustonDeployed != contracts.Capabilities.Has(rocketpool.FeatureOnChainPDaoVoting) {
+			return nil, nil, fmt.Errorf("network '%s' is running protocol version %s at block %s, which does not match the preset's Houston deploy block %d", p.Name, contracts.Version.String(), contracts.ElBlockNumber.String(), p.HoustonDeployBlock)
+		}
+	}
+	return rp, contracts, nil
+}
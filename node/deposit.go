@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -173,10 +172,6 @@ func GetNodeUsableCredit(rp *rocketpool.RocketPool, nodeAddress common.Address,
 }
 
 // Get contracts
-var rocketNodeDepositLock sync.Mutex
-
 func getRocketNodeDeposit(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeDepositLock.Lock()
-	defer rocketNodeDepositLock.Unlock()
 	return rp.GetContract("rocketNodeDeposit", opts)
 }
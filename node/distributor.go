@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -81,19 +80,11 @@ func (d *Distributor) GetUserShare(opts *bind.CallOpts) (*big.Int, error) {
 }
 
 // Get contracts
-var rocketNodeDistributorFactoryLock sync.Mutex
-
 func getRocketNodeDistributorFactory(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeDistributorFactoryLock.Lock()
-	defer rocketNodeDistributorFactoryLock.Unlock()
 	return rp.GetContract("rocketNodeDistributorFactory", opts)
 }
 
 // Get a distributor contract
-var rocketDistributorLock sync.Mutex
-
 func getDistributorContract(rp *rocketpool.RocketPool, distributorAddress common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDistributorLock.Lock()
-	defer rocketDistributorLock.Unlock()
 	return rp.MakeContract("rocketNodeDistributorDelegate", distributorAddress, opts)
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -66,8 +65,7 @@ func GetNodeManagerVersion(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint
 }
 
 // Get all node details
-// The 'includeRplWithdrawalAddress' flag is used for backwards compatibility with Atlas, - set it to `false` if Houston hasn't been deployed yet
-func GetNodes(rp *rocketpool.RocketPool, includeRplWithdrawalAddress bool, opts *bind.CallOpts) ([]NodeDetails, error) {
+func GetNodes(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]NodeDetails, error) {
 
 	// Get node addresses
 	nodeAddresses, err := GetNodeAddresses(rp, opts)
@@ -75,6 +73,12 @@ func GetNodes(rp *rocketpool.RocketPool, includeRplWithdrawalAddress bool, opts
 		return []NodeDetails{}, err
 	}
 
+	// RPL withdrawal addresses only exist from Houston onwards
+	includeRplWithdrawalAddress, err := rp.HasFeature(rocketpool.FeatureRplWithdrawalAddress, opts)
+	if err != nil {
+		return []NodeDetails{}, err
+	}
+
 	// Load node details in batches
 	details := make([]NodeDetails, len(nodeAddresses))
 	for bsi := 0; bsi < len(nodeAddresses); bsi += NodeDetailsBatchSize {
@@ -92,7 +96,7 @@ func GetNodes(rp *rocketpool.RocketPool, includeRplWithdrawalAddress bool, opts
 			ni := ni
 			wg.Go(func() error {
 				nodeAddress := nodeAddresses[ni]
-				nodeDetails, err := GetNodeDetails(rp, nodeAddress, includeRplWithdrawalAddress, opts)
+				nodeDetails, err := getNodeDetails(rp, nodeAddress, includeRplWithdrawalAddress, opts)
 				if err == nil {
 					details[ni] = nodeDetails
 				}
@@ -204,8 +208,16 @@ func GetNodeAddressesFast(rp *rocketpool.RocketPool, multicallAddress common.Add
 }
 
 // Get a node's details
-// The 'includeRplWithdrawalAddress' flag is used for backwards compatibility with Atlas, - set it to `false` if Houston hasn't been deployed yet
-func GetNodeDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, includeRplWithdrawalAddress bool, opts *bind.CallOpts) (NodeDetails, error) {
+func GetNodeDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (NodeDetails, error) {
+	includeRplWithdrawalAddress, err := rp.HasFeature(rocketpool.FeatureRplWithdrawalAddress, opts)
+	if err != nil {
+		return NodeDetails{}, err
+	}
+	return getNodeDetails(rp, nodeAddress, includeRplWithdrawalAddress, opts)
+}
+
+// Get a node's details, with its RPL withdrawal addresses if the network has them
+func getNodeDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, includeRplWithdrawalAddress bool, opts *bind.CallOpts) (NodeDetails, error) {
 
 	// Data
 	var wg errgroup.Group
@@ -708,34 +720,18 @@ func ConfirmRPLWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.A
 }
 
 // Get contracts
-var rocketNodeManagerLock sync.Mutex
-
 func getRocketNodeManager(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeManagerLock.Lock()
-	defer rocketNodeManagerLock.Unlock()
 	return rp.GetContract("rocketNodeManager", opts)
 }
 
-var rocketNetworkPricesLock sync.Mutex
-
 func getRocketNetworkPrices(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkPricesLock.Lock()
-	defer rocketNetworkPricesLock.Unlock()
 	return rp.GetContract("rocketNetworkPrices", opts)
 }
 
-var rocketNetworkBalancesLock sync.Mutex
-
 func getRocketNetworkBalances(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNetworkBalancesLock.Lock()
-	defer rocketNetworkBalancesLock.Unlock()
 	return rp.GetContract("rocketNetworkBalances", opts)
 }
 
-var rocketDAONodeTrustedActionsLock sync.Mutex
-
 func getRocketDAONodeTrustedActions(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDAONodeTrustedActionsLock.Lock()
-	defer rocketDAONodeTrustedActionsLock.Unlock()
 	return rp.GetContract("rocketDAONodeTrustedActions", opts)
 }
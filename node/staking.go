@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -264,10 +263,6 @@ func GetNodeRPLLocked(rp *rocketpool.RocketPool, nodeAddress common.Address, opt
 }
 
 // Get contracts
-var rocketNodeStakingLock sync.Mutex
-
 func getRocketNodeStaking(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketNodeStakingLock.Lock()
-	defer rocketNodeStakingLock.Unlock()
 	return rp.GetContract("rocketNodeStaking", opts)
 }
@@ -3,7 +3,6 @@ package rewards
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -81,10 +80,6 @@ func ClaimAndStake(rp *rocketpool.RocketPool, address common.Address, indices []
 }
 
 // Get contracts
-var rocketDistributorMainnetLock sync.Mutex
-
 func getRocketDistributorMainnet(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketDistributorMainnetLock.Lock()
-	defer rocketDistributorMainnetLock.Unlock()
 	return rp.GetContract("rocketMerkleDistributorMainnet", opts)
 }
@@ -3,7 +3,6 @@ package rewards
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -242,6 +241,7 @@ func SubmitRewardSnapshot(rp *rocketpool.RocketPool, submission RewardSubmission
 }
 
 // Get the event info for a rewards snapshot using the Atlas getter
+// If rocketRewardsPoolAddresses is nil and the address history resolver is enabled, the rewards pool that was live when the snapshot was executed is checked as well
 func GetRewardsEvent(rp *rocketpool.RocketPool, index uint64, rocketRewardsPoolAddresses []common.Address, opts *bind.CallOpts) (bool, RewardsEvent, error) {
 	// Get contracts
 	rocketRewardsPool, err := getRocketRewardsPool(rp, opts)
@@ -261,6 +261,15 @@ func GetRewardsEvent(rp *rocketpool.RocketPool, index uint64, rocketRewardsPoolA
 	currentAddress := *rocketRewardsPool.Address
 	if rocketRewardsPoolAddresses == nil {
 		rocketRewardsPoolAddresses = []common.Address{currentAddress}
+		if rp.AddressHistory.IsEnabled() {
+			history, err := rp.AddressHistory.GetHistoryAtBlock("rocketRewardsPool", block.Uint64())
+			if err != nil {
+				return false, RewardsEvent{}, fmt.Errorf("error getting rewards pool address history: %w", err)
+			}
+			if deployment, exists := history.GetDeploymentAtBlock(block.Uint64()); exists && deployment.Address != currentAddress {
+				rocketRewardsPoolAddresses = append(rocketRewardsPoolAddresses, deployment.Address)
+			}
+		}
 	} else {
 		found := false
 		for _, address := range rocketRewardsPoolAddresses {
@@ -331,10 +340,6 @@ func GetRewardsEvent(rp *rocketpool.RocketPool, index uint64, rocketRewardsPoolA
 }
 
 // Get contracts
-var rocketRewardsPoolLock sync.Mutex
-
 func getRocketRewardsPool(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketRewardsPoolLock.Lock()
-	defer rocketRewardsPoolLock.Unlock()
-	return rp.GetContract("rocketRewardsPool", opts)
+	return rp.VersionManager.GetContractAtBlock("rocketRewardsPool", opts)
 }
@@ -0,0 +1,156 @@
+// Imports the ABIs of a Rocket Pool release into the legacy ABI registry.
+//
+// Run it from the repository root when a release replaces network contracts, passing the ABIs of the contracts
+// that are being replaced:
+//
+//	go run ./rocketpool/abis/importer -version 1.3.0 -artifacts ../rocketpool/build/contracts -contracts rocketNetworkPrices,rocketNodeManager
+//
+// Artifacts can be plain ABI arrays, or Truffle / Hardhat / Foundry artifacts with an "abi" field.
+// Each ABI is written to rocketpool/abis/<version>/<contract name>.json, where the contract name is the artifact's
+// contract name with a lowercase first letter (e.g. RocketNetworkPrices becomes rocketNetworkPrices).
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/hashicorp/go-version"
+)
+
+// The fields of a compiler artifact that the importer uses
+type artifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+}
+
+func main() {
+
+	// Parse the arguments
+	rpVersion := flag.String("version", "", "The protocol version the ABIs belong to (e.g. 1.3.0)")
+	artifactsDir := flag.String("artifacts", "", "The directory with the release's ABIs or compiler artifacts")
+	contractList := flag.String("contracts", "", "A comma-separated list of the contracts to import (default: all of them)")
+	outputDir := flag.String("out", "rocketpool/abis", "The legacy ABI directory")
+	flag.Parse()
+	if *rpVersion == "" || *artifactsDir == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if _, err := version.NewSemver(*rpVersion); err != nil {
+		fmt.Printf("Error parsing version %s: %s\n", *rpVersion, err.Error())
+		os.Exit(1)
+	}
+	wanted := map[string]bool{}
+	for _, contractName := range strings.Split(*contractList, ",") {
+		if contractName = strings.TrimSpace(contractName); contractName != "" {
+			wanted[lowerFirst(contractName)] = false
+		}
+	}
+
+	// Read the ABIs
+	abis, err := readArtifacts(*artifactsDir)
+	if err != nil {
+		fmt.Printf("Error reading artifacts: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Select the contracts to import
+	names := []string{}
+	for contractName := range abis {
+		if len(wanted) > 0 {
+			if _, ok := wanted[contractName]; !ok {
+				continue
+			}
+			wanted[contractName] = true
+		}
+		names = append(names, contractName)
+	}
+	for contractName, found := range wanted {
+		if !found {
+			fmt.Printf("Error: no ABI found for contract %s\n", contractName)
+			os.Exit(1)
+		}
+	}
+	sort.Strings(names)
+
+	// Write the ABIs
+	versionDir := filepath.Join(*outputDir, *rpVersion)
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		fmt.Printf("Error creating %s: %s\n", versionDir, err.Error())
+		os.Exit(1)
+	}
+	for _, contractName := range names {
+		path := filepath.Join(versionDir, contractName+".json")
+		if err := os.WriteFile(path, abis[contractName], 0644); err != nil {
+			fmt.Printf("Error writing %s: %s\n", path, err.Error())
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+	fmt.Printf("Imported %d ABIs for v%s. Remember to add the contracts' versioned names to the v%s legacy version wrapper.\n", len(names), *rpVersion, *rpVersion)
+
+}
+
+// Read every ABI in an artifact directory, keyed by contract name
+func readArtifacts(artifactsDir string) (map[string][]byte, error) {
+	abis := map[string][]byte{}
+	err := filepath.WalkDir(artifactsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".json" || strings.HasSuffix(path, ".dbg.json") {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// Get the ABI and contract name
+		contractName := strings.TrimSuffix(filepath.Base(path), ".json")
+		abiJson := json.RawMessage(bytes.TrimSpace(contents))
+		if len(abiJson) == 0 || abiJson[0] != '[' {
+			var compiled artifact
+			if err := json.Unmarshal(contents, &compiled); err != nil || len(compiled.ABI) == 0 {
+				// Not an artifact, e.g. a build info or metadata file
+				return nil
+			}
+			abiJson = compiled.ABI
+			if compiled.ContractName != "" {
+				contractName = compiled.ContractName
+			}
+		}
+		contractName = lowerFirst(contractName)
+
+		// Check that the ABI is valid
+		if _, err := abi.JSON(bytes.NewReader(abiJson)); err != nil {
+			return fmt.Errorf("error parsing ABI in %s: %w", path, err)
+		}
+		var formatted bytes.Buffer
+		if err := json.Indent(&formatted, abiJson, "", "  "); err != nil {
+			return fmt.Errorf("error formatting ABI in %s: %w", path, err)
+		}
+		formatted.WriteString("\n")
+		abis[contractName] = formatted.Bytes()
+		return nil
+	})
+	return abis, err
+}
+
+// Lowercase the first letter of a contract name
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return name
+	}
+	return string(unicode.ToLower(r)) + name[size:]
+}
@@ -0,0 +1,218 @@
+package rocketpool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Context key for access list transactors
+type accessListKey struct{}
+
+// The gas comparison of a transaction with and without an EIP-2930 access list
+type AccessListGasInfo struct {
+	AccessList           types.AccessList `json:"accessList"`
+	EstGasLimit          uint64           `json:"estGasLimit"`
+	EstGasLimitWithList  uint64           `json:"estGasLimitWithList"`
+	SafeGasLimit         uint64           `json:"safeGasLimit"`
+	SafeGasLimitWithList uint64           `json:"safeGasLimitWithList"`
+	UseAccessList        bool             `json:"useAccessList"`
+}
+
+// Get a copy of the transactor that requests an access list for each transaction
+// The client must be an AccessListCreator, and the access list is only attached to transactions that it makes cheaper
+func NewAccessListTransactor(client ExecutionClient, opts *bind.TransactOpts) (*bind.TransactOpts, error) {
+	if _, ok := client.(AccessListCreator); !ok {
+		return nil, fmt.Errorf("the execution client does not support creating access lists")
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	listOpts := *opts
+	listOpts.Context = context.WithValue(ctx, accessListKey{}, true)
+	return &listOpts, nil
+}
+
+// Check if a transactor requests access lists
+func usesAccessList(opts *bind.TransactOpts) bool {
+	if opts == nil || opts.Context == nil {
+		return false
+	}
+	useAccessList, _ := opts.Context.Value(accessListKey{}).(bool)
+	return useAccessList
+}
+
+// Compare the gas of a contract transaction with and without an access list
+func (c *Contract) GetTransactionAccessListGasInfo(opts *bind.TransactOpts, method string, params ...interface{}) (AccessListGasInfo, error) {
+	input, err := c.ABI.Pack(method, params...)
+	if err != nil {
+		return AccessListGasInfo{}, fmt.Errorf("Error getting transaction access list gas info: Could not encode input data: %w", err)
+	}
+	gasInfo, err := c.getAccessListGasInfo(opts, method, input)
+	if err != nil {
+		return AccessListGasInfo{}, fmt.Errorf("Error getting transaction access list gas info: %w", err)
+	}
+	return gasInfo, nil
+}
+
+// Create an access list for a contract transaction and estimate its gas with and without the list
+func (c *Contract) getAccessListGasInfo(opts *bind.TransactOpts, method string, input []byte) (AccessListGasInfo, error) {
+
+	// Check that the client can create access lists
+	response := AccessListGasInfo{}
+	creator, ok := c.Client.(AccessListCreator)
+	if !ok {
+		return response, fmt.Errorf("the execution client does not support creating access lists")
+	}
+	ctx := getTransactContext(opts)
+
+	// Estimate gas without the access list
+	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, method, input)
+	if err != nil {
+		return response, err
+	}
+	response.EstGasLimit = estGasLimit
+	response.SafeGasLimit = safeGasLimit
+	response.EstGasLimitWithList = estGasLimit
+	response.SafeGasLimitWithList = safeGasLimit
+
+	// Create the access list
+	accessList, _, vmErr, err := creator.CreateAccessList(ctx, ethereum.CallMsg{
+		From:     opts.From,
+		To:       c.Address,
+		GasPrice: big.NewInt(0), // use 0 gwei for simulation
+		Value:    opts.Value,
+		Data:     input,
+	})
+	if err != nil {
+		return response, fmt.Errorf("error creating access list: %w", c.normalizeErrorMessage(err))
+	}
+	if vmErr != "" || accessList == nil || len(*accessList) == 0 {
+		return response, nil
+	}
+	response.AccessList = *accessList
+
+	// Estimate gas with the access list
+	estGasLimitWithList, err := c.Client.EstimateGas(ctx, ethereum.CallMsg{
+		From:       opts.From,
+		To:         c.Address,
+		GasPrice:   big.NewInt(0), // use 0 gwei for simulation
+		Value:      opts.Value,
+		Data:       input,
+		AccessList: response.AccessList,
+	})
+	if err != nil {
+		return response, fmt.Errorf("error estimating gas needed with access list: %w", c.normalizeErrorMessage(err))
+	}
+	response.EstGasLimitWithList = estGasLimitWithList
+	response.SafeGasLimitWithList = c.GasLimitMultipliers.getSafeGasLimit(c.Name, method, estGasLimitWithList)
+
+	// Only use the list if it saves gas
+	response.UseAccessList = estGasLimitWithList < estGasLimit
+	return response, nil
+
+}
+
+// Send a contract transaction with an access list if it saves gas
+// Returns false if the list was not used, in which case the transaction should be sent normally
+func (c *Contract) transactWithAccessList(opts *bind.TransactOpts, method string, input []byte) (*types.Transaction, bool, error) {
+
+	// Compare gas with and without the access list; this fails if the client can't create access lists
+	ctx := getTransactContext(opts)
+	gasInfo, err := c.getAccessListGasInfo(opts, method, input)
+	if err != nil {
+		return nil, true, err
+	}
+	if !gasInfo.UseAccessList {
+		if opts.GasLimit == 0 {
+			opts.GasLimit = gasInfo.SafeGasLimit
+		}
+		return nil, false, nil
+	}
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		gasLimit = gasInfo.SafeGasLimitWithList
+	}
+
+	// Get from address nonce
+	var nonce uint64
+	if opts.Nonce == nil {
+		nonce, err = c.Client.PendingNonceAt(ctx, opts.From)
+		if err != nil {
+			return nil, true, fmt.Errorf("error getting nonce: %w", err)
+		}
+	} else {
+		nonce = opts.Nonce.Uint64()
+	}
+
+	// Get the fees, using the same defaults as bound contracts
+	gasTipCap := opts.GasTipCap
+	if gasTipCap == nil {
+		gasTipCap, err = c.Client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, true, fmt.Errorf("error getting suggested priority fee: %w", err)
+		}
+	}
+	gasFeeCap := opts.GasFeeCap
+	if gasFeeCap == nil {
+		header, err := c.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, true, fmt.Errorf("error getting latest block header: %w", err)
+		}
+		if header.BaseFee == nil {
+			return nil, true, fmt.Errorf("access lists require an EIP-1559 network")
+		}
+		gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+	}
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		return nil, true, fmt.Errorf("max fee per gas (%v) < max priority fee per gas (%v)", gasFeeCap, gasTipCap)
+	}
+
+	// Initialize transaction
+	value := opts.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:      nonce,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        gasLimit,
+		To:         c.Address,
+		Value:      value,
+		Data:       input,
+		AccessList: gasInfo.AccessList,
+	})
+
+	// Sign transaction
+	if opts.Signer == nil {
+		return nil, true, fmt.Errorf("no signer to authorize the transaction with")
+	}
+	signedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		return nil, true, err
+	}
+
+	// Send transaction, unless it was only being built
+	if opts.NoSend {
+		return signedTx, true, nil
+	}
+	if err := c.Client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, true, c.normalizeErrorMessage(err)
+	}
+	return signedTx, true, nil
+
+}
+
+// Get the context of a transactor, or the background context if it doesn't have one
+func getTransactContext(opts *bind.TransactOpts) context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
@@ -0,0 +1,300 @@
+package rocketpool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// A single deployment of a network contract
+type ContractDeployment struct {
+	Address    common.Address `json:"address"`
+	ABI        *abi.ABI       `json:"-"`
+	StartBlock uint64         `json:"startBlock"` // The first block the address was live
+	EndBlock   uint64         `json:"endBlock"`   // The last block the address was live, as of the history's resolved block
+	IsCurrent  bool           `json:"isCurrent"`  // True if the address is still live
+}
+
+// Every address a network contract has been deployed at, oldest first
+type ContractHistory struct {
+	Name          string               `json:"name"`
+	Deployments   []ContractDeployment `json:"deployments"`
+	ResolvedBlock uint64               `json:"resolvedBlock"`
+}
+
+// Resolves and caches the address timelines of network contracts
+// Timelines are built from RocketStorage's state directly, so they include every upgrade path that can change it:
+// oDAO upgrade proposals, upgrade contracts, and anything else with storage write access.
+// Resolving requires an archive node, so log queries and event helpers only use the resolver once it has been enabled.
+type AddressHistoryResolver struct {
+	rp          *RocketPool
+	enabled     bool
+	deployBlock uint64
+	histories   map[string]*ContractHistory
+	lock        sync.Mutex
+}
+
+// Create a new address history resolver
+func NewAddressHistoryResolver(rp *RocketPool) *AddressHistoryResolver {
+	return &AddressHistoryResolver{
+		rp:        rp,
+		histories: map[string]*ContractHistory{},
+	}
+}
+
+// Set whether log queries and event helpers should resolve contract addresses with the resolver
+// When disabled (the default), they use the ContractUpgraded logs and the current address instead
+func (r *AddressHistoryResolver) SetEnabled(enabled bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.enabled = enabled
+}
+
+// Check whether log queries and event helpers should resolve contract addresses with the resolver
+func (r *AddressHistoryResolver) IsEnabled() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.enabled
+}
+
+// Set the block RocketStorage was deployed at, so it doesn't have to be read from the chain
+func (r *AddressHistoryResolver) SetDeployBlock(deployBlock uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.deployBlock = deployBlock
+}
+
+// Get the address timeline of a contract, up to the latest block
+func (r *AddressHistoryResolver) GetHistory(contractName string) (*ContractHistory, error) {
+	latestBlock, err := r.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	return r.GetHistoryAtBlock(contractName, latestBlock)
+}
+
+// Get the address timeline of a contract, up to the given block
+// Cached timelines are extended rather than rebuilt when a later block is requested
+func (r *AddressHistoryResolver) GetHistoryAtBlock(contractName string, block uint64) (*ContractHistory, error) {
+	// Get a copy of the cached history, so it can be extended without holding the lock
+	r.lock.Lock()
+	cached, exists := r.histories[contractName]
+	if exists && cached.ResolvedBlock >= block {
+		r.lock.Unlock()
+		return cached.truncate(block), nil
+	}
+	var history *ContractHistory
+	if exists {
+		history = cached.truncate(cached.ResolvedBlock)
+	}
+	r.lock.Unlock()
+
+	// Start from the deploy block if there's no history yet
+	if history == nil {
+		deployBlock, err := r.getDeployBlock()
+		if err != nil {
+			return nil, err
+		}
+		history = &ContractHistory{
+			Name:          contractName,
+			Deployments:   []ContractDeployment{},
+			ResolvedBlock: deployBlock,
+		}
+		address, err := r.getAddress(contractName, deployBlock)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.addDeployment(history, address, deployBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	// Find every address change between the resolved block and the target block
+	startAddress, err := r.getAddress(contractName, history.ResolvedBlock)
+	if err != nil {
+		return nil, err
+	}
+	endAddress, err := r.getAddress(contractName, block)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.findChanges(history, history.ResolvedBlock, startAddress, block, endAddress); err != nil {
+		return nil, err
+	}
+
+	// Update the live range of the current deployment
+	history.ResolvedBlock = block
+	if len(history.Deployments) > 0 {
+		last := &history.Deployments[len(history.Deployments)-1]
+		if last.IsCurrent {
+			last.EndBlock = block
+		}
+	}
+
+	// Cache the history unless a later one was resolved in the meantime
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if cached, exists := r.histories[contractName]; !exists || cached.ResolvedBlock < block {
+		r.histories[contractName] = history
+	}
+	return history.truncate(block), nil
+}
+
+// Get every address a contract has been deployed at
+func (r *AddressHistoryResolver) GetAddresses(contractName string) ([]common.Address, error) {
+	history, err := r.GetHistory(contractName)
+	if err != nil {
+		return nil, err
+	}
+	return history.GetAddresses(), nil
+}
+
+// Clear the cached timelines
+func (r *AddressHistoryResolver) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.histories = map[string]*ContractHistory{}
+}
+
+// Bisect a block range to find every block where the contract's address changed
+// Changes that revert to the previous address within the range are not detected
+func (r *AddressHistoryResolver) findChanges(history *ContractHistory, startBlock uint64, startAddress common.Address, endBlock uint64, endAddress common.Address) error {
+	if startAddress == endAddress {
+		return nil
+	}
+	if endBlock-startBlock <= 1 {
+		return r.addDeployment(history, endAddress, endBlock)
+	}
+	midBlock := startBlock + (endBlock-startBlock)/2
+	midAddress, err := r.getAddress(history.Name, midBlock)
+	if err != nil {
+		return err
+	}
+	if err := r.findChanges(history, startBlock, startAddress, midBlock, midAddress); err != nil {
+		return err
+	}
+	return r.findChanges(history, midBlock, midAddress, endBlock, endAddress)
+}
+
+// Close the current deployment and start a new one at the given block
+func (r *AddressHistoryResolver) addDeployment(history *ContractHistory, address common.Address, block uint64) error {
+	if len(history.Deployments) > 0 {
+		last := &history.Deployments[len(history.Deployments)-1]
+		if last.IsCurrent {
+			last.EndBlock = block - 1
+			last.IsCurrent = false
+		}
+	}
+	if address == (common.Address{}) {
+		// The contract was removed (or not deployed yet)
+		return nil
+	}
+
+	// Get the ABI that was registered alongside the address
+	abi, err := r.rp.GetABI(history.Name, &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)})
+	if err != nil {
+		return fmt.Errorf("error getting contract %s ABI at block %d: %w", history.Name, block, err)
+	}
+	history.Deployments = append(history.Deployments, ContractDeployment{
+		Address:    address,
+		ABI:        abi,
+		StartBlock: block,
+		EndBlock:   block,
+		IsCurrent:  true,
+	})
+	return nil
+}
+
+// Get the block Rocket Pool was deployed at
+func (r *AddressHistoryResolver) getDeployBlock() (uint64, error) {
+	r.lock.Lock()
+	deployBlock := r.deployBlock
+	r.lock.Unlock()
+	if deployBlock != 0 {
+		return deployBlock, nil
+	}
+
+	deployBlockBig, err := r.rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+	if err != nil {
+		return 0, fmt.Errorf("error getting Rocket Pool deploy block: %w", err)
+	}
+	r.lock.Lock()
+	r.deployBlock = deployBlockBig.Uint64()
+	r.lock.Unlock()
+	return deployBlockBig.Uint64(), nil
+}
+
+// Get a contract's address at a block
+func (r *AddressHistoryResolver) getAddress(contractName string, block uint64) (common.Address, error) {
+	address, err := r.rp.GetAddress(contractName, &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting contract %s address at block %d: %w", contractName, block, err)
+	}
+	return *address, nil
+}
+
+// Get a copy of the history as of the given block
+func (h *ContractHistory) truncate(block uint64) *ContractHistory {
+	truncated := &ContractHistory{
+		Name:          h.Name,
+		Deployments:   []ContractDeployment{},
+		ResolvedBlock: block,
+	}
+	for _, deployment := range h.Deployments {
+		if deployment.StartBlock > block {
+			break
+		}
+		if deployment.EndBlock >= block {
+			deployment.EndBlock = block
+			deployment.IsCurrent = true
+		}
+		truncated.Deployments = append(truncated.Deployments, deployment)
+	}
+	return truncated
+}
+
+// Get every address in the history
+func (h *ContractHistory) GetAddresses() []common.Address {
+	addresses := make([]common.Address, len(h.Deployments))
+	for i, deployment := range h.Deployments {
+		addresses[i] = deployment.Address
+	}
+	return addresses
+}
+
+// Get the deployment that was live at a block
+func (h *ContractHistory) GetDeploymentAtBlock(block uint64) (ContractDeployment, bool) {
+	for _, deployment := range h.Deployments {
+		if deployment.StartBlock <= block && block <= deployment.EndBlock {
+			return deployment, true
+		}
+	}
+	return ContractDeployment{}, false
+}
+
+// Get the deployment with the given address
+func (h *ContractHistory) GetDeploymentForAddress(address common.Address) (ContractDeployment, bool) {
+	for _, deployment := range h.Deployments {
+		if deployment.Address == address {
+			return deployment, true
+		}
+	}
+	return ContractDeployment{}, false
+}
+
+// Get the deployments that were live at any point in a block range
+func (h *ContractHistory) GetDeploymentsInRange(fromBlock uint64, toBlock uint64) []ContractDeployment {
+	deployments := []ContractDeployment{}
+	for _, deployment := range h.Deployments {
+		if deployment.StartBlock <= toBlock && deployment.EndBlock >= fromBlock {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments
+}
@@ -26,16 +26,19 @@ const (
 
 // Contract type wraps go-ethereum bound contract
 type Contract struct {
-	Contract *bind.BoundContract
-	Address  *common.Address
-	ABI      *abi.ABI
-	Client   ExecutionClient
+	Contract            *bind.BoundContract
+	Address             *common.Address
+	ABI                 *abi.ABI
+	Client              ExecutionClient
+	Name                string
+	GasLimitMultipliers *GasLimitMultipliers // If nil, GasLimitMultiplier is used for every method
 }
 
 // Response for gas limits from network and from user request
 type GasInfo struct {
-	EstGasLimit  uint64 `json:"estGasLimit"`
-	SafeGasLimit uint64 `json:"safeGasLimit"`
+	EstGasLimit  uint64    `json:"estGasLimit"`
+	SafeGasLimit uint64    `json:"safeGasLimit"`
+	Costs        *GasCosts `json:"costs,omitempty"` // Nil if the client doesn't provide fee history
 }
 
 // Call a contract method
@@ -57,7 +60,7 @@ func (c *Contract) GetTransactionGasInfo(opts *bind.TransactOpts, method string,
 	}
 
 	// Estimate gas limit
-	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, input)
+	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, method, input)
 
 	if err != nil {
 		return response, fmt.Errorf("Error getting transaction gas info: could not estimate gas limit: %w", err)
@@ -65,19 +68,49 @@ func (c *Contract) GetTransactionGasInfo(opts *bind.TransactOpts, method string,
 	response.EstGasLimit = estGasLimit
 	response.SafeGasLimit = safeGasLimit
 
-	return response, err
+	// Estimate costs if the client provides fee history
+	response.Costs, err = GetOptionalGasCosts(c.Client, estGasLimit, safeGasLimit)
+	if err != nil {
+		return response, fmt.Errorf("Error getting transaction gas info: could not estimate costs: %w", err)
+	}
+
+	return response, nil
 }
 
 // Transact on a contract method and wait for a receipt
 func (c *Contract) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
 
+	// Simulate the transaction instead if requested
+	if simulation := getSimulation(opts); simulation != nil {
+		input, err := c.ABI.Pack(method, params...)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding input data: %w", err)
+		}
+		return nil, c.simulateTransaction(opts, simulation, method, input)
+	}
+
+	// Attach an access list if requested and it saves gas
+	if usesAccessList(opts) {
+		input, err := c.ABI.Pack(method, params...)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding input data: %w", err)
+		}
+		tx, handled, err := c.transactWithAccessList(opts, method, input)
+		if err != nil {
+			return nil, err
+		}
+		if handled {
+			return tx, nil
+		}
+	}
+
 	// Estimate gas limit
 	if opts.GasLimit == 0 {
 		input, err := c.ABI.Pack(method, params...)
 		if err != nil {
 			return nil, fmt.Errorf("error encoding input data: %w", err)
 		}
-		_, safeGasLimit, err := c.estimateGasLimit(opts, input)
+		_, safeGasLimit, err := c.estimateGasLimit(opts, method, input)
 		if err != nil {
 			return nil, err
 		}
@@ -100,22 +133,44 @@ func (c *Contract) GetTransferGasInfo(opts *bind.TransactOpts) (GasInfo, error)
 	response := GasInfo{}
 
 	// Estimate gas limit
-	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, []byte{})
+	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, "", []byte{})
 	if err != nil {
 		return response, fmt.Errorf("Error getting transfer gas info: could not estimate gas limit: %w", err)
 	}
 	response.EstGasLimit = estGasLimit
 	response.SafeGasLimit = safeGasLimit
 
+	// Estimate costs if the client provides fee history
+	response.Costs, err = GetOptionalGasCosts(c.Client, estGasLimit, safeGasLimit)
+	if err != nil {
+		return response, fmt.Errorf("Error getting transfer gas info: could not estimate costs: %w", err)
+	}
+
 	return response, nil
 }
 
 // Transfer ETH to a contract and wait for a receipt
 func (c *Contract) Transfer(opts *bind.TransactOpts) (common.Hash, error) {
 
+	// Simulate the transfer instead if requested
+	if simulation := getSimulation(opts); simulation != nil {
+		return common.Hash{}, c.simulateTransaction(opts, simulation, "", []byte{})
+	}
+
+	// Attach an access list if requested and it saves gas
+	if usesAccessList(opts) {
+		tx, handled, err := c.transactWithAccessList(opts, "", []byte{})
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if handled {
+			return tx.Hash(), nil
+		}
+	}
+
 	// Estimate gas limit
 	if opts.GasLimit == 0 {
-		_, safeGasLimit, err := c.estimateGasLimit(opts, []byte{})
+		_, safeGasLimit, err := c.estimateGasLimit(opts, "", []byte{})
 		if err != nil {
 			return common.Hash{}, err
 		}
@@ -133,7 +188,8 @@ func (c *Contract) Transfer(opts *bind.TransactOpts) (common.Hash, error) {
 }
 
 // Estimate the expected and safe gas limits for a contract transaction
-func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint64, uint64, error) {
+// The safe gas limit is padded with the multiplier registered for the contract method
+func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, method string, input []byte) (uint64, uint64, error) {
 
 	// Estimate gas limit
 	gasLimit, err := c.Client.EstimateGas(context.Background(), ethereum.CallMsg{
@@ -149,14 +205,10 @@ func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint
 	}
 
 	// Pad and return gas limit
-	safeGasLimit := uint64(float64(gasLimit) * GasLimitMultiplier)
 	if gasLimit > MaxGasLimit {
 		return 0, 0, fmt.Errorf("estimated gas of %d is greater than the max gas limit of %d", gasLimit, MaxGasLimit)
 	}
-	if safeGasLimit > MaxGasLimit {
-		safeGasLimit = MaxGasLimit
-	}
-	return gasLimit, safeGasLimit, nil
+	return gasLimit, c.GasLimitMultipliers.getSafeGasLimit(c.Name, method, gasLimit), nil
 
 }
 
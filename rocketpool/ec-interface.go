@@ -49,6 +49,11 @@ type ExecutionClient interface {
 	// a timely execution of a transaction.
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 
+	// FeeHistory retrieves the base fees, gas used ratios and priority fee percentiles
+	// of a range of blocks, ending at lastBlock. If lastBlock is nil, the range ends
+	// at the latest known block.
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+
 	// EstimateGas tries to estimate the gas needed to execute a specific
 	// transaction based on the current pending state of the backend blockchain.
 	// There is no guarantee that this is the true gas limit requirement as other
@@ -103,3 +108,40 @@ type ExecutionClient interface {
 	// no sync currently running, it returns nil.
 	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
 }
+
+// This is the optional interface for execution clients that can trace calls.
+// Simulations use it to collect the events a call would emit; clients without it fall back to eth_call.
+type CallTracer interface {
+
+	// TraceCall simulates a call with the given state overrides applied, returning its output,
+	// any revert error, and the logs it would emit. The block number can be nil, in which case
+	// the call is simulated against the pending block. Clients with RPC access can implement
+	// this with TraceCallWithRpc.
+	TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (*CallTrace, error)
+
+	// TraceStateChanges simulates a call with the given state overrides applied and returns the state
+	// it changes, as overrides that later calls can be simulated on top of. The block number can be nil,
+	// in which case the call is simulated against the pending block. Clients with RPC access can
+	// implement this with TraceStateChangesWithRpc.
+	TraceStateChanges(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (StateOverrides, error)
+}
+
+// This is the optional interface for execution clients that can run eth_call with state overrides.
+// Simulations use it when the client can't trace calls, so they can still report reverts and return data.
+type OverrideCaller interface {
+
+	// CallContractWithOverrides executes a contract call with the given state overrides applied.
+	// The block number can be nil, in which case the call is run against the pending block. Clients
+	// with RPC access can implement this with CallContractWithRpc.
+	CallContractWithOverrides(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) ([]byte, error)
+}
+
+// This is the optional interface for execution clients that can create EIP-2930 access lists.
+// *gethclient.Client implements it, and access list transactors require it.
+type AccessListCreator interface {
+
+	// CreateAccessList tries to create an EIP-2930 access list for a specific transaction
+	// based on the current pending state of the blockchain, returning the list, the gas
+	// used with it, and any VM error the transaction would fail with.
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error)
+}
@@ -0,0 +1,286 @@
+package rocketpool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/hashicorp/go-version"
+)
+
+// A named protocol capability that was introduced, and possibly removed, by an upgrade
+type Feature string
+
+// Known protocol features
+const (
+	// Redstone (v1.1.0)
+	FeatureSmoothingPool  Feature = "smoothingPool"
+	FeatureMerkleRewards  Feature = "merkleRewards"
+	FeatureFeeDistributor Feature = "feeDistributor"
+
+	// Atlas (v1.2.0)
+	FeatureBondReduction     Feature = "bondReduction"
+	FeatureVacantMinipools   Feature = "vacantMinipools"
+	FeatureDepositCredit     Feature = "depositCredit"
+	FeatureLatestReportBlock Feature = "latestReportableBlock"
+
+	// Houston (v1.3.0)
+	FeatureOnChainPDaoVoting     Feature = "onChainPDaoVoting"
+	FeatureRplWithdrawalAddress  Feature = "rplWithdrawalAddress"
+	FeatureSubmissionFrequencies Feature = "submissionFrequencies"
+	FeatureRplLocking            Feature = "rplLocking"
+)
+
+// The protocol versions a feature is available in
+type FeatureRange struct {
+	Introduced *version.Version // nil if the feature was present from the start
+	Removed    *version.Version // nil if the feature is still present
+}
+
+// Create the version ranges of the built-in features
+func getBuiltInFeatures() map[Feature]FeatureRange {
+	return map[Feature]FeatureRange{
+		FeatureSmoothingPool:  newFeatureRange("1.1.0", ""),
+		FeatureMerkleRewards:  newFeatureRange("1.1.0", ""),
+		FeatureFeeDistributor: newFeatureRange("1.1.0", ""),
+
+		FeatureBondReduction:     newFeatureRange("1.2.0", ""),
+		FeatureVacantMinipools:   newFeatureRange("1.2.0", ""),
+		FeatureDepositCredit:     newFeatureRange("1.2.0", ""),
+		FeatureLatestReportBlock: newFeatureRange("", "1.3.0"),
+
+		FeatureOnChainPDaoVoting:     newFeatureRange("1.3.0", ""),
+		FeatureRplWithdrawalAddress:  newFeatureRange("1.3.0", ""),
+		FeatureSubmissionFrequencies: newFeatureRange("1.3.0", ""),
+		FeatureRplLocking:            newFeatureRange("1.3.0", ""),
+	}
+}
+
+// The built-in features, for capabilities created without a registry; never modified
+var builtInFeatures = NewFeatureRegistry()
+
+// The features known to a Rocket Pool instance, keyed by name
+type FeatureRegistry struct {
+	features map[Feature]FeatureRange
+	lock     sync.RWMutex
+}
+
+// The protocol version deployed on a network, and the features it supports
+type Capabilities struct {
+	Version  *version.Version
+	features *FeatureRegistry
+}
+
+// Cached capabilities of the latest block
+type cachedCapabilities struct {
+	capabilities *Capabilities
+	time         int64
+}
+
+// Create a version range for a built-in feature
+func newFeatureRange(introduced string, removed string) FeatureRange {
+	featureRange := FeatureRange{}
+	if introduced != "" {
+		featureRange.Introduced = version.Must(version.NewSemver(introduced))
+	}
+	if removed != "" {
+		featureRange.Removed = version.Must(version.NewSemver(removed))
+	}
+	return featureRange
+}
+
+// Create a registry of the built-in features
+func NewFeatureRegistry() *FeatureRegistry {
+	return &FeatureRegistry{
+		features: getBuiltInFeatures(),
+	}
+}
+
+// Register a feature, or replace the version range of an existing one
+// Empty versions mean the feature was present from the start or is still present
+func (r *FeatureRegistry) Register(feature Feature, introduced string, removed string) error {
+	featureRange := FeatureRange{}
+	var err error
+	if introduced != "" {
+		featureRange.Introduced, err = version.NewSemver(introduced)
+		if err != nil {
+			return fmt.Errorf("error parsing version %s that introduced feature %s: %w", introduced, feature, err)
+		}
+	}
+	if removed != "" {
+		featureRange.Removed, err = version.NewSemver(removed)
+		if err != nil {
+			return fmt.Errorf("error parsing version %s that removed feature %s: %w", removed, feature, err)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.features[feature] = featureRange
+	return nil
+}
+
+// Get the version range of a registered feature
+func (r *FeatureRegistry) GetRange(feature Feature) (FeatureRange, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	featureRange, ok := r.features[feature]
+	return featureRange, ok
+}
+
+// Get the names of all registered features
+func (r *FeatureRegistry) getFeatures() []Feature {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]Feature, 0, len(r.features))
+	for feature := range r.features {
+		names = append(names, feature)
+	}
+	return names
+}
+
+// Create the capabilities of a network running the given protocol version, using the registered features
+func (r *FeatureRegistry) NewCapabilities(protocolVersion *version.Version) *Capabilities {
+	return &Capabilities{
+		Version:  protocolVersion,
+		features: r,
+	}
+}
+
+// Create the capabilities of a network running the given protocol version, using the built-in features
+func NewCapabilities(protocolVersion *version.Version) *Capabilities {
+	return builtInFeatures.NewCapabilities(protocolVersion)
+}
+
+// Check if a feature is available
+// Unregistered features are never available
+func (c *Capabilities) Has(feature Feature) bool {
+	featureRange, ok := c.getFeatureRegistry().GetRange(feature)
+	if !ok {
+		return false
+	}
+	if featureRange.Introduced != nil && c.Version.LessThan(featureRange.Introduced) {
+		return false
+	}
+	if featureRange.Removed != nil && !c.Version.LessThan(featureRange.Removed) {
+		return false
+	}
+	return true
+}
+
+// Get an error if a feature is not available
+func (c *Capabilities) Require(feature Feature) error {
+	if !c.Has(feature) {
+		return fmt.Errorf("feature %s is not available in protocol version %s", feature, c.Version.String())
+	}
+	return nil
+}
+
+// Get all of the available features, sorted by name
+func (c *Capabilities) Features() []Feature {
+	available := []Feature{}
+	for _, feature := range c.getFeatureRegistry().getFeatures() {
+		if c.Has(feature) {
+			available = append(available, feature)
+		}
+	}
+	sort.Slice(available, func(i, j int) bool {
+		return available[i] < available[j]
+	})
+	return available
+}
+
+// Get the registry the capabilities check features against
+func (c *Capabilities) getFeatureRegistry() *FeatureRegistry {
+	if c.features == nil {
+		return builtInFeatures
+	}
+	return c.features
+}
+
+// Get the protocol version and features deployed on the network
+// Capabilities of the latest block are cached
+func (rp *RocketPool) GetCapabilities(opts *bind.CallOpts) (*Capabilities, error) {
+
+	// Check for cached capabilities
+	if opts == nil {
+		rp.capabilitiesLock.RLock()
+		cached := rp.capabilities
+		rp.capabilitiesLock.RUnlock()
+		if cached.capabilities != nil && time.Now().Unix()-cached.time <= CacheTTL {
+			return cached.capabilities, nil
+		}
+	}
+
+	// Detect the version
+	protocolVersion, err := DetectProtocolVersion(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := rp.Features.NewCapabilities(protocolVersion)
+
+	// Cache capabilities
+	if opts == nil {
+		rp.capabilitiesLock.Lock()
+		rp.capabilities = cachedCapabilities{
+			capabilities: capabilities,
+			time:         time.Now().Unix(),
+		}
+		rp.capabilitiesLock.Unlock()
+	}
+
+	return capabilities, nil
+
+}
+
+// Check if a feature is deployed on the network
+func (rp *RocketPool) HasFeature(feature Feature, opts *bind.CallOpts) (bool, error) {
+	capabilities, err := rp.GetCapabilities(opts)
+	if err != nil {
+		return false, err
+	}
+	return capabilities.Has(feature), nil
+}
+
+// Detect the protocol version deployed on the network from its contract versions
+func DetectProtocolVersion(rp *RocketPool, opts *bind.CallOpts) (*version.Version, error) {
+
+	// Get the node manager version
+	nodeMgrAddress, err := rp.GetAddress("rocketNodeManager", opts)
+	if err != nil {
+		return nil, err
+	}
+	nodeMgrVersion, err := GetContractVersion(rp, *nodeMgrAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error checking node manager version: %w", err)
+	}
+
+	// Check for v1.3 (Houston)
+	if nodeMgrVersion > 3 {
+		return version.NewSemver("1.3.0")
+	}
+
+	// Check for v1.2 (Atlas)
+	nodeStakingAddress, err := rp.GetAddress("rocketNodeStaking", opts)
+	if err != nil {
+		return nil, err
+	}
+	nodeStakingVersion, err := GetContractVersion(rp, *nodeStakingAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error checking node staking version: %w", err)
+	}
+	if nodeStakingVersion > 3 {
+		return version.NewSemver("1.2.0")
+	}
+
+	// Check for v1.1 (Redstone)
+	if nodeMgrVersion > 1 {
+		return version.NewSemver("1.1.0")
+	}
+
+	// v1.0 (Classic)
+	return version.NewSemver("1.0.0")
+
+}
@@ -0,0 +1,176 @@
+package rocketpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Fee estimation settings
+const (
+	FeeHistoryBlockCount   uint64  = 20
+	LowPriorityPercentile  float64 = 10
+	MidPriorityPercentile  float64 = 50
+	HighPriorityPercentile float64 = 90
+
+	// JSON-RPC error code for unknown methods
+	methodNotFoundErrorCode int = -32601
+)
+
+// The fees and expected cost of a transaction at a priority level
+type GasCost struct {
+	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *big.Int `json:"maxFeePerGas"`
+	EstCost              *big.Int `json:"estCost"`
+	MaxCost              *big.Int `json:"maxCost"`
+}
+
+// The expected cost of a transaction at low, medium and high priority
+type GasCosts struct {
+	BaseFee *big.Int `json:"baseFee"`
+	Low     GasCost  `json:"low"`
+	Medium  GasCost  `json:"medium"`
+	High    GasCost  `json:"high"`
+}
+
+// Gas limit multipliers for a Rocket Pool instance's transactions, keyed by contract name or contract and method
+type GasLimitMultipliers struct {
+	multipliers map[string]float64
+	lock        sync.RWMutex
+}
+
+// Create a new, empty set of gas limit multipliers
+func NewGasLimitMultipliers() *GasLimitMultipliers {
+	return &GasLimitMultipliers{
+		multipliers: map[string]float64{},
+	}
+}
+
+// Set the gas limit multiplier for a contract's transactions
+// An empty method sets the default for every method of the contract; a multiplier of 0 removes the setting
+func (m *GasLimitMultipliers) Set(contractName string, method string, multiplier float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := getGasLimitMultiplierKey(contractName, method)
+	if multiplier == 0 {
+		delete(m.multipliers, key)
+		return
+	}
+	m.multipliers[key] = multiplier
+}
+
+// Get the gas limit multiplier for a contract method
+// Falls back to the contract's multiplier, then to GasLimitMultiplier
+func (m *GasLimitMultipliers) Get(contractName string, method string) float64 {
+	if m == nil {
+		return GasLimitMultiplier
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if multiplier, ok := m.multipliers[getGasLimitMultiplierKey(contractName, method)]; ok {
+		return multiplier
+	}
+	if multiplier, ok := m.multipliers[getGasLimitMultiplierKey(contractName, "")]; ok {
+		return multiplier
+	}
+	return GasLimitMultiplier
+}
+
+// Get the registry key for a gas limit multiplier
+func getGasLimitMultiplierKey(contractName string, method string) string {
+	if method == "" {
+		return contractName
+	}
+	return contractName + "." + method
+}
+
+// Pad an estimated gas limit with the multiplier for a contract method
+func (m *GasLimitMultipliers) getSafeGasLimit(contractName string, method string, gasLimit uint64) uint64 {
+	safeGasLimit := uint64(float64(gasLimit) * m.Get(contractName, method))
+	if safeGasLimit > MaxGasLimit {
+		safeGasLimit = MaxGasLimit
+	}
+	return safeGasLimit
+}
+
+// Get the expected cost of a transaction at each priority level from the recent fee history
+func GetGasCosts(client ExecutionClient, estGasLimit uint64, safeGasLimit uint64) (*GasCosts, error) {
+
+	// Get the fee history
+	feeHistory, err := client.FeeHistory(context.Background(), FeeHistoryBlockCount, nil, []float64{LowPriorityPercentile, MidPriorityPercentile, HighPriorityPercentile})
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee history: %w", err)
+	}
+
+	// The last base fee is the one for the next block
+	baseFee := big.NewInt(0)
+	if len(feeHistory.BaseFee) > 0 && feeHistory.BaseFee[len(feeHistory.BaseFee)-1] != nil {
+		baseFee = feeHistory.BaseFee[len(feeHistory.BaseFee)-1]
+	}
+
+	// Get the costs at each priority level
+	costs := &GasCosts{
+		BaseFee: baseFee,
+	}
+	for i, cost := range []*GasCost{&costs.Low, &costs.Medium, &costs.High} {
+		priorityFee := getMedianReward(feeHistory.Reward, feeHistory.GasUsedRatio, i)
+		maxFee := new(big.Int).Add(priorityFee, new(big.Int).Mul(baseFee, big.NewInt(2)))
+		*cost = GasCost{
+			MaxPriorityFeePerGas: priorityFee,
+			MaxFeePerGas:         maxFee,
+			EstCost:              new(big.Int).Mul(new(big.Int).Add(baseFee, priorityFee), new(big.Int).SetUint64(estGasLimit)),
+			MaxCost:              new(big.Int).Mul(maxFee, new(big.Int).SetUint64(safeGasLimit)),
+		}
+	}
+	return costs, nil
+
+}
+
+// Get the expected cost of a transaction, or nil if the client doesn't support eth_feeHistory
+// Gas limits are still useful on their own, so an unsupported method isn't an error, but any other failure is
+func GetOptionalGasCosts(client ExecutionClient, estGasLimit uint64, safeGasLimit uint64) (*GasCosts, error) {
+	costs, err := GetGasCosts(client, estGasLimit, safeGasLimit)
+	if err != nil {
+		if isMethodUnsupported(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return costs, nil
+}
+
+// Check if an RPC error is because the client doesn't provide the method
+func isMethodUnsupported(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "does not exist/is not available") || strings.Contains(message, "method not found") || strings.Contains(message, "not supported")
+}
+
+// Get the median priority fee at a reward percentile, ignoring empty blocks
+func getMedianReward(rewards [][]*big.Int, gasUsedRatios []float64, percentileIndex int) *big.Int {
+	fees := []*big.Int{}
+	for i, blockRewards := range rewards {
+		if i < len(gasUsedRatios) && gasUsedRatios[i] == 0 {
+			continue
+		}
+		if percentileIndex < len(blockRewards) && blockRewards[percentileIndex] != nil {
+			fees = append(fees, blockRewards[percentileIndex])
+		}
+	}
+	if len(fees) == 0 {
+		return big.NewInt(0)
+	}
+	sort.Slice(fees, func(i, j int) bool {
+		return fees[i].Cmp(fees[j]) < 0
+	})
+	return new(big.Int).Set(fees[len(fees)/2])
+}
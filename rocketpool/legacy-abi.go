@@ -0,0 +1,169 @@
+package rocketpool
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/hashicorp/go-version"
+)
+
+// The ABIs of contracts replaced by each upgrade, stored as abis/<version>/<contract name>.json
+// New releases are imported with the generator in abis/importer
+//
+//go:embed abis/*/*.json
+var legacyAbiFiles embed.FS
+
+// Legacy ABI JSON and parsed ABIs, keyed by version and contract name
+var legacyAbiJson map[string]map[string][]byte
+var legacyAbis = map[string]*abi.ABI{}
+var legacyAbisLock sync.RWMutex
+var legacyAbisLoad sync.Once
+var legacyAbisLoadErr error
+
+// Get the ABI of a contract as it was in the given protocol version
+func GetLegacyABI(rpVersion string, contractName string) (*abi.ABI, error) {
+	if err := loadLegacyAbis(); err != nil {
+		return nil, err
+	}
+	key := getLegacyAbiKey(rpVersion, contractName)
+
+	// Check for a parsed ABI
+	legacyAbisLock.RLock()
+	parsed, ok := legacyAbis[key]
+	abiJson, exists := legacyAbiJson[rpVersion][contractName]
+	legacyAbisLock.RUnlock()
+	if ok {
+		return parsed, nil
+	}
+	if !exists {
+		return nil, fmt.Errorf("no v%s ABI for contract %s", rpVersion, contractName)
+	}
+
+	// Parse and cache the ABI
+	abiParsed, err := abi.JSON(bytes.NewReader(abiJson))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing v%s contract %s ABI: %w", rpVersion, contractName, err)
+	}
+	legacyAbisLock.Lock()
+	legacyAbis[key] = &abiParsed
+	legacyAbisLock.Unlock()
+	return &abiParsed, nil
+}
+
+// Get the ABI JSON of a contract as it was in the given protocol version
+func GetLegacyABIJSON(rpVersion string, contractName string) ([]byte, bool) {
+	if err := loadLegacyAbis(); err != nil {
+		return nil, false
+	}
+	legacyAbisLock.RLock()
+	defer legacyAbisLock.RUnlock()
+	abiJson, exists := legacyAbiJson[rpVersion][contractName]
+	return abiJson, exists
+}
+
+// Get the protocol versions with legacy ABIs, oldest first
+func GetLegacyABIVersions() ([]string, error) {
+	if err := loadLegacyAbis(); err != nil {
+		return nil, err
+	}
+	legacyAbisLock.RLock()
+	versions := make([]*version.Version, 0, len(legacyAbiJson))
+	for rpVersion := range legacyAbiJson {
+		parsed, err := version.NewSemver(rpVersion)
+		if err != nil {
+			legacyAbisLock.RUnlock()
+			return nil, fmt.Errorf("error parsing legacy ABI version %s: %w", rpVersion, err)
+		}
+		versions = append(versions, parsed)
+	}
+	legacyAbisLock.RUnlock()
+
+	sort.Sort(version.Collection(versions))
+	names := make([]string, len(versions))
+	for i, parsed := range versions {
+		names[i] = parsed.Original()
+	}
+	return names, nil
+}
+
+// Get the names of the contracts with legacy ABIs in the given protocol version, sorted by name
+func GetLegacyABIContracts(rpVersion string) ([]string, error) {
+	if err := loadLegacyAbis(); err != nil {
+		return nil, err
+	}
+	legacyAbisLock.RLock()
+	defer legacyAbisLock.RUnlock()
+	names := make([]string, 0, len(legacyAbiJson[rpVersion]))
+	for contractName := range legacyAbiJson[rpVersion] {
+		names = append(names, contractName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Register the ABI of a contract in the given protocol version, or replace an existing one
+// This is intended for networks with upgrades that aren't part of a release yet, such as devnets
+func RegisterLegacyABI(rpVersion string, contractName string, abiJson []byte) error {
+	if err := loadLegacyAbis(); err != nil {
+		return err
+	}
+	abiParsed, err := abi.JSON(bytes.NewReader(abiJson))
+	if err != nil {
+		return fmt.Errorf("error parsing v%s contract %s ABI: %w", rpVersion, contractName, err)
+	}
+
+	legacyAbisLock.Lock()
+	defer legacyAbisLock.Unlock()
+	if _, exists := legacyAbiJson[rpVersion]; !exists {
+		legacyAbiJson[rpVersion] = map[string][]byte{}
+	}
+	legacyAbiJson[rpVersion][contractName] = abiJson
+	legacyAbis[getLegacyAbiKey(rpVersion, contractName)] = &abiParsed
+	return nil
+}
+
+// Read the embedded ABI files into the registry
+func loadLegacyAbis() error {
+	legacyAbisLoad.Do(func() {
+		abiJson := map[string]map[string][]byte{}
+		legacyAbisLoadErr = fs.WalkDir(legacyAbiFiles, "abis", func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || path.Ext(filePath) != ".json" {
+				return nil
+			}
+			contents, err := legacyAbiFiles.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+			rpVersion := path.Base(path.Dir(filePath))
+			contractName := strings.TrimSuffix(path.Base(filePath), ".json")
+			if _, exists := abiJson[rpVersion]; !exists {
+				abiJson[rpVersion] = map[string][]byte{}
+			}
+			abiJson[rpVersion][contractName] = contents
+			return nil
+		})
+		if legacyAbisLoadErr != nil {
+			legacyAbisLoadErr = fmt.Errorf("error reading embedded legacy ABIs: %w", legacyAbisLoadErr)
+			return
+		}
+		legacyAbisLock.Lock()
+		legacyAbiJson = abiJson
+		legacyAbisLock.Unlock()
+	})
+	return legacyAbisLoadErr
+}
+
+// Get the registry key for a legacy ABI
+func getLegacyAbiKey(rpVersion string, contractName string) string {
+	return rpVersion + "/" + contractName
+}
@@ -0,0 +1,127 @@
+package rocketpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The Multicall2 tryAggregate method, used to preload contracts in a single request
+const preloadMulticallABI = `[{"inputs":[{"internalType":"bool","name":"requireSuccess","type":"bool"},{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall2.Call[]","name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall2.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"nonpayable","type":"function"}]`
+
+// A call in a Multicall2 aggregate
+type preloadCall struct {
+	Target   common.Address `json:"target"`
+	CallData []byte         `json:"callData"`
+}
+
+// Load the addresses and ABIs of the given contracts into the cache
+// If a multicall contract address is set, every address and ABI is fetched in a single request; otherwise the contracts are loaded individually
+func (rp *RocketPool) Preload(contractNames ...string) error {
+
+	// Skip contracts that are already cached
+	names := []string{}
+	for _, contractName := range contractNames {
+		if cached, ok := rp.getCachedContract(contractName); ok && time.Now().Unix()-cached.time <= CacheTTL {
+			continue
+		}
+		names = append(names, contractName)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if rp.MulticallAddress == nil {
+		_, err := rp.GetContracts(nil, names...)
+		return err
+	}
+
+	// Build the RocketStorage calls
+	storageAbi := rp.RocketStorageContract.ABI
+	calls := make([]preloadCall, 0, len(names)*2)
+	for _, contractName := range names {
+		addressData, err := storageAbi.Pack("getAddress", crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)))
+		if err != nil {
+			return fmt.Errorf("error packing contract %s address call: %w", contractName, err)
+		}
+		abiData, err := storageAbi.Pack("getString", crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)))
+		if err != nil {
+			return fmt.Errorf("error packing contract %s ABI call: %w", contractName, err)
+		}
+		calls = append(calls,
+			preloadCall{Target: *rp.RocketStorageContract.Address, CallData: addressData},
+			preloadCall{Target: *rp.RocketStorageContract.Address, CallData: abiData},
+		)
+	}
+
+	// Run the multicall
+	mcAbi, err := abi.JSON(strings.NewReader(preloadMulticallABI))
+	if err != nil {
+		return err
+	}
+	callData, err := mcAbi.Pack("tryAggregate", true, calls)
+	if err != nil {
+		return fmt.Errorf("error packing preload multicall: %w", err)
+	}
+	response, err := rp.Client.CallContract(context.Background(), ethereum.CallMsg{To: rp.MulticallAddress, Data: callData}, nil)
+	if err != nil {
+		return fmt.Errorf("error preloading contracts: %w", err)
+	}
+	unpacked, err := mcAbi.Unpack("tryAggregate", response)
+	if err != nil {
+		return fmt.Errorf("error unpacking preload multicall response: %w", err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	})).(*[]struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	})
+	if len(results) != len(calls) {
+		return fmt.Errorf("preload multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	// Decode and cache the contracts
+	now := time.Now().Unix()
+	for i, contractName := range names {
+		addressResult, abiResult := results[i*2], results[i*2+1]
+		if !addressResult.Success || !abiResult.Success {
+			return fmt.Errorf("error preloading contract %s: the RocketStorage call failed", contractName)
+		}
+		var address common.Address
+		if err := storageAbi.UnpackIntoInterface(&address, "getAddress", addressResult.ReturnData); err != nil {
+			return fmt.Errorf("error decoding contract %s address: %w", contractName, err)
+		}
+		var abiEncoded string
+		if err := storageAbi.UnpackIntoInterface(&abiEncoded, "getString", abiResult.ReturnData); err != nil {
+			return fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+		}
+		contractAbi, err := DecodeAbi(abiEncoded)
+		if err != nil {
+			return fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+		}
+
+		rp.setCachedAddress(contractName, cachedAddress{address: &address, time: now})
+		rp.setCachedABI(contractName, cachedABI{abi: contractAbi, time: now})
+		rp.setCachedContract(contractName, cachedContract{
+			contract: &Contract{
+				Contract:            bind.NewBoundContract(address, *contractAbi, rp.Client, rp.Client, rp.Client),
+				Address:             &address,
+				ABI:                 contractAbi,
+				Client:              rp.Client,
+				Name:                contractName,
+				GasLimitMultipliers: rp.GasLimitMultipliers,
+			},
+			time: now,
+		})
+	}
+	return nil
+
+}
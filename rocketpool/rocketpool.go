@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/rocket-pool/rocketpool-go/contracts"
 )
@@ -38,12 +39,19 @@ type RocketPool struct {
 	RocketStorage         *contracts.RocketStorage
 	RocketStorageContract *Contract
 	VersionManager        *VersionManager
+	AddressHistory        *AddressHistoryResolver
+	GasLimitMultipliers   *GasLimitMultipliers
+	Features              *FeatureRegistry
+	MulticallAddress      *common.Address
 	addresses             map[string]cachedAddress
 	abis                  map[string]cachedABI
 	contracts             map[string]cachedContract
+	capabilities          cachedCapabilities
 	addressesLock         sync.RWMutex
 	abisLock              sync.RWMutex
 	contractsLock         sync.RWMutex
+	capabilitiesLock      sync.RWMutex
+	loads                 singleflight.Group
 }
 
 // Create new contract manager
@@ -56,15 +64,18 @@ func NewRocketPool(client ExecutionClient, rocketStorageAddress common.Address)
 	}
 
 	// Create a Contract for it
+	gasLimitMultipliers := NewGasLimitMultipliers()
 	rsAbi, err := abi.JSON(strings.NewReader(contracts.RocketStorageABI))
 	if err != nil {
 		return nil, err
 	}
 	contract := &Contract{
-		Contract: bind.NewBoundContract(rocketStorageAddress, rsAbi, client, client, client),
-		Address:  &rocketStorageAddress,
-		ABI:      &rsAbi,
-		Client:   client,
+		Contract:            bind.NewBoundContract(rocketStorageAddress, rsAbi, client, client, client),
+		Address:             &rocketStorageAddress,
+		ABI:                 &rsAbi,
+		Client:              client,
+		Name:                "rocketStorage",
+		GasLimitMultipliers: gasLimitMultipliers,
 	}
 
 	// Create and return
@@ -72,11 +83,14 @@ func NewRocketPool(client ExecutionClient, rocketStorageAddress common.Address)
 		Client:                client,
 		RocketStorage:         rocketStorage,
 		RocketStorageContract: contract,
+		GasLimitMultipliers:   gasLimitMultipliers,
+		Features:              NewFeatureRegistry(),
 		addresses:             make(map[string]cachedAddress),
 		abis:                  make(map[string]cachedABI),
 		contracts:             make(map[string]cachedContract),
 	}
 	rp.VersionManager = NewVersionManager(rp)
+	rp.AddressHistory = NewAddressHistoryResolver(rp)
 
 	return rp, nil
 
@@ -96,22 +110,25 @@ func (rp *RocketPool) GetAddress(contractName string, opts *bind.CallOpts) (*com
 		}
 	}
 
-	// Get address
-	address, err := rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)))
-	if err != nil {
-		return nil, fmt.Errorf("error loading contract %s address: %w", contractName, err)
-	}
-
-	// Cache address
+	// Get address; concurrent lookups of the latest address share a single request
 	if opts == nil {
-		rp.setCachedAddress(contractName, cachedAddress{
-			address: &address,
-			time:    time.Now().Unix(),
+		address, err, _ := rp.loads.Do("address:"+contractName, func() (interface{}, error) {
+			address, err := rp.loadAddress(contractName, nil)
+			if err != nil {
+				return nil, err
+			}
+			rp.setCachedAddress(contractName, cachedAddress{
+				address: address,
+				time:    time.Now().Unix(),
+			})
+			return address, nil
 		})
+		if err != nil {
+			return nil, err
+		}
+		return address.(*common.Address), nil
 	}
-
-	// Return
-	return &address, nil
+	return rp.loadAddress(contractName, opts)
 
 }
 
@@ -157,28 +174,25 @@ func (rp *RocketPool) GetABI(contractName string, opts *bind.CallOpts) (*abi.ABI
 		}
 	}
 
-	// Get ABI
-	abiEncoded, err := rp.RocketStorage.GetString(opts, crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)))
-	if err != nil {
-		return nil, fmt.Errorf("error loading contract %s ABI: %w", contractName, err)
-	}
-
-	// Decode ABI
-	abi, err := DecodeAbi(abiEncoded)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
-	}
-
-	// Cache ABI
+	// Get ABI; concurrent lookups of the latest ABI share a single request
 	if opts == nil {
-		rp.setCachedABI(contractName, cachedABI{
-			abi:  abi,
-			time: time.Now().Unix(),
+		contractAbi, err, _ := rp.loads.Do("abi:"+contractName, func() (interface{}, error) {
+			contractAbi, err := rp.loadABI(contractName, nil)
+			if err != nil {
+				return nil, err
+			}
+			rp.setCachedABI(contractName, cachedABI{
+				abi:  contractAbi,
+				time: time.Now().Unix(),
+			})
+			return contractAbi, nil
 		})
+		if err != nil {
+			return nil, err
+		}
+		return contractAbi.(*abi.ABI), nil
 	}
-
-	// Return
-	return abi, nil
+	return rp.loadABI(contractName, opts)
 
 }
 func (rp *RocketPool) GetABIs(opts *bind.CallOpts, contractNames ...string) ([]*abi.ABI, error) {
@@ -223,44 +237,25 @@ func (rp *RocketPool) GetContract(contractName string, opts *bind.CallOpts) (*Co
 		}
 	}
 
-	// Data
-	var wg errgroup.Group
-	var address *common.Address
-	var abi *abi.ABI
-
-	// Load data
-	wg.Go(func() error {
-		var err error
-		address, err = rp.GetAddress(contractName, opts)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		abi, err = rp.GetABI(contractName, opts)
-		return err
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return nil, err
-	}
-
-	// Create contract
-	contract := &Contract{
-		Contract: bind.NewBoundContract(*address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  address,
-		ABI:      abi,
-		Client:   rp.Client,
+	// Get contract; concurrent lookups of the latest contract share a single request
+	if opts == nil {
+		contract, err, _ := rp.loads.Do("contract:"+contractName, func() (interface{}, error) {
+			contract, err := rp.loadContract(contractName, nil)
+			if err != nil {
+				return nil, err
+			}
+			rp.setCachedContract(contractName, cachedContract{
+				contract: contract,
+				time:     time.Now().Unix(),
+			})
+			return contract, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return contract.(*Contract), nil
 	}
-
-	// Cache contract
-	rp.setCachedContract(contractName, cachedContract{
-		contract: contract,
-		time:     time.Now().Unix(),
-	})
-
-	// Return
-	return contract, nil
+	return rp.loadContract(contractName, opts)
 
 }
 func (rp *RocketPool) GetContracts(opts *bind.CallOpts, contractNames ...string) ([]*Contract, error) {
@@ -291,6 +286,65 @@ func (rp *RocketPool) GetContracts(opts *bind.CallOpts, contractNames ...string)
 
 }
 
+// Load a contract address from RocketStorage
+func (rp *RocketPool) loadAddress(contractName string, opts *bind.CallOpts) (*common.Address, error) {
+	address, err := rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)))
+	if err != nil {
+		return nil, fmt.Errorf("error loading contract %s address: %w", contractName, err)
+	}
+	return &address, nil
+}
+
+// Load and decode a contract ABI from RocketStorage
+func (rp *RocketPool) loadABI(contractName string, opts *bind.CallOpts) (*abi.ABI, error) {
+	abiEncoded, err := rp.RocketStorage.GetString(opts, crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)))
+	if err != nil {
+		return nil, fmt.Errorf("error loading contract %s ABI: %w", contractName, err)
+	}
+	abi, err := DecodeAbi(abiEncoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+	}
+	return abi, nil
+}
+
+// Load a contract's address and ABI and bind it
+func (rp *RocketPool) loadContract(contractName string, opts *bind.CallOpts) (*Contract, error) {
+
+	// Data
+	var wg errgroup.Group
+	var address *common.Address
+	var abi *abi.ABI
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		address, err = rp.GetAddress(contractName, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		abi, err = rp.GetABI(contractName, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Create contract
+	return &Contract{
+		Contract:            bind.NewBoundContract(*address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                contractName,
+		GasLimitMultipliers: rp.GasLimitMultipliers,
+	}, nil
+
+}
+
 // Create a Rocket Pool contract instance
 func (rp *RocketPool) MakeContract(contractName string, address common.Address, opts *bind.CallOpts) (*Contract, error) {
 
@@ -302,10 +356,12 @@ func (rp *RocketPool) MakeContract(contractName string, address common.Address,
 
 	// Create and return
 	return &Contract{
-		Contract: bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  &address,
-		ABI:      abi,
-		Client:   rp.Client,
+		Contract:            bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             &address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                contractName,
+		GasLimitMultipliers: rp.GasLimitMultipliers,
 	}, nil
 
 }
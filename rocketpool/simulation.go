@@ -0,0 +1,691 @@
+package rocketpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Selector of the Panic(uint256) error raised by failed Solidity assertions
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// Returned by write functions run with a simulation transactor, since no transaction is built or sent
+// The outcome is recorded in the transactor's simulation instead
+var ErrSimulated = errors.New("the transaction was simulated instead of being sent")
+
+// Overrides to apply to an account's state during a simulation
+type AccountOverride struct {
+	Nonce     *uint64                     `json:"nonce,omitempty"`
+	Code      []byte                      `json:"code,omitempty"`
+	Balance   *big.Int                    `json:"balance,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// State overrides for a simulation, keyed by account
+type StateOverrides map[common.Address]AccountOverride
+
+// Get a copy of the overrides with another set applied on top of them
+// An account override that replaces the whole state discards any earlier storage overrides for that account
+func (o StateOverrides) Merge(other StateOverrides) StateOverrides {
+	merged := StateOverrides{}
+	for address, override := range o {
+		override.State = copyStorage(override.State)
+		override.StateDiff = copyStorage(override.StateDiff)
+		merged[address] = override
+	}
+	for address, override := range other {
+		existing, exists := merged[address]
+		if !exists {
+			override.State = copyStorage(override.State)
+			override.StateDiff = copyStorage(override.StateDiff)
+			merged[address] = override
+			continue
+		}
+		if override.Nonce != nil {
+			existing.Nonce = override.Nonce
+		}
+		if override.Code != nil {
+			existing.Code = override.Code
+		}
+		if override.Balance != nil {
+			existing.Balance = override.Balance
+		}
+		if override.State != nil {
+			existing.State = copyStorage(override.State)
+			existing.StateDiff = nil
+		}
+		for slot, value := range override.StateDiff {
+			if existing.State != nil {
+				existing.State[slot] = value
+				continue
+			}
+			if existing.StateDiff == nil {
+				existing.StateDiff = map[common.Hash]common.Hash{}
+			}
+			existing.StateDiff[slot] = value
+		}
+		merged[address] = existing
+	}
+	return merged
+}
+
+// Copy a set of storage overrides
+func copyStorage(storage map[common.Hash]common.Hash) map[common.Hash]common.Hash {
+	if storage == nil {
+		return nil
+	}
+	copied := make(map[common.Hash]common.Hash, len(storage))
+	for slot, value := range storage {
+		copied[slot] = value
+	}
+	return copied
+}
+
+// The result of tracing a simulated call
+type CallTrace struct {
+	Output  []byte      `json:"output"`
+	Error   string      `json:"error"`
+	GasUsed uint64      `json:"gasUsed"`
+	Logs    []types.Log `json:"logs"`
+}
+
+// An event that a simulated transaction would emit
+type SimulatedEvent struct {
+	Address common.Address         `json:"address"`
+	Name    string                 `json:"name"`
+	Values  map[string]interface{} `json:"values"`
+	Log     types.Log              `json:"log"`
+}
+
+// The outcome of simulating a transaction
+// Events and gas usage are only available if the call was traced, which requires a CallTracer client
+type SimulationResult struct {
+	Method       string           `json:"method"`
+	Success      bool             `json:"success"`
+	RevertReason string           `json:"revertReason"`
+	ReturnData   hexutil.Bytes    `json:"returnData"`
+	Traced       bool             `json:"traced"`
+	GasUsed      uint64           `json:"gasUsed"`
+	Events       []SimulatedEvent `json:"events"`
+}
+
+// A set of simulated transactions, collected by a simulation transactor
+type Simulation struct {
+	overrides StateOverrides
+	results   []*SimulationResult
+	lock      sync.Mutex
+}
+
+// Context key for simulation transactors
+type simulationKey struct{}
+
+// Get a copy of the transactor that simulates transactions instead of sending them
+// Any write function can be run with it; the results are recorded in the returned simulation and the function returns ErrSimulated
+func NewSimulationTransactor(opts *bind.TransactOpts, overrides StateOverrides) (*bind.TransactOpts, *Simulation) {
+	simulation := &Simulation{
+		overrides: overrides,
+		results:   []*SimulationResult{},
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	simOpts := *opts
+	simOpts.Context = context.WithValue(ctx, simulationKey{}, simulation)
+	simOpts.NoSend = true
+	return &simOpts, simulation
+}
+
+// Get the results of the transactions simulated so far, in order
+func (s *Simulation) Results() []*SimulationResult {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	results := make([]*SimulationResult, len(s.results))
+	copy(results, s.results)
+	return results
+}
+
+// Get the simulation attached to a transactor, if there is one
+func getSimulation(opts *bind.TransactOpts) *Simulation {
+	if opts == nil || opts.Context == nil {
+		return nil
+	}
+	simulation, _ := opts.Context.Value(simulationKey{}).(*Simulation)
+	return simulation
+}
+
+// Simulate a contract method against the pending block without sending a transaction
+func (c *Contract) Simulate(opts *bind.TransactOpts, overrides StateOverrides, method string, params ...interface{}) (*SimulationResult, error) {
+	input, err := c.ABI.Pack(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding input data: %w", err)
+	}
+	result, err := c.simulate(opts, nil, overrides, input)
+	if err != nil {
+		return nil, err
+	}
+	result.Method = method
+	return result, nil
+}
+
+// Simulate raw call data against this contract, such as a proposal payload, without sending a transaction
+// A nil block number simulates against the pending block
+func (c *Contract) SimulateData(opts *bind.TransactOpts, blockNumber *big.Int, overrides StateOverrides, data []byte) (*SimulationResult, error) {
+	result, err := c.simulate(opts, blockNumber, overrides, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= 4 {
+		if method, err := c.ABI.MethodById(data[:4]); err == nil {
+			result.Method = method.RawName
+		}
+	}
+	return result, nil
+}
+
+// Simulate a call and record its result in a simulation
+// No transaction is built, so ErrSimulated is returned in place of one
+func (c *Contract) simulateTransaction(opts *bind.TransactOpts, simulation *Simulation, method string, input []byte) error {
+	result, err := c.simulate(opts, nil, simulation.overrides, input)
+	if err != nil {
+		return err
+	}
+	result.Method = method
+
+	simulation.lock.Lock()
+	simulation.results = append(simulation.results, result)
+	simulation.lock.Unlock()
+	return ErrSimulated
+}
+
+// Run the simulation of a call against a block, or the pending block if none is provided
+// The call is traced if the client supports it, and run with eth_call otherwise
+func (c *Contract) simulate(opts *bind.TransactOpts, blockNumber *big.Int, overrides StateOverrides, input []byte) (*SimulationResult, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		gasLimit = MaxGasLimit
+	}
+	call := ethereum.CallMsg{
+		From:     opts.From,
+		To:       c.Address,
+		Gas:      gasLimit,
+		GasPrice: big.NewInt(0), // use 0 gwei for simulation
+		Value:    opts.Value,
+		Data:     input,
+	}
+	tracer, err := getCallTracer(c.Client)
+	if err != nil {
+		return c.simulateWithCall(ctx, call, blockNumber, overrides)
+	}
+	trace, err := tracer.TraceCall(ctx, call, blockNumber, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("error simulating transaction: %w", c.normalizeErrorMessage(err))
+	}
+
+	// Handle reverts
+	result := &SimulationResult{
+		Success:    trace.Error == "",
+		ReturnData: trace.Output,
+		Traced:     true,
+		GasUsed:    trace.GasUsed,
+		Events:     []SimulatedEvent{},
+	}
+	if !result.Success {
+		result.RevertReason = decodeRevertReason(trace.Output, c.ABI)
+		if result.RevertReason == "" {
+			result.RevertReason = trace.Error
+		}
+		return result, nil
+	}
+
+	// Decode the events emitted by this contract's ABI
+	for _, log := range trace.Logs {
+		event := SimulatedEvent{
+			Address: log.Address,
+			Log:     log,
+		}
+		if len(log.Topics) > 0 {
+			if abiEvent, err := c.ABI.EventByID(log.Topics[0]); err == nil {
+				values := map[string]interface{}{}
+				if err := decodeEvent(abiEvent, log, values); err == nil {
+					event.Name = abiEvent.Name
+					event.Values = values
+				}
+			}
+		}
+		result.Events = append(result.Events, event)
+	}
+	return result, nil
+}
+
+// Run the simulation of a call with eth_call, which reports the return data or revert reason but not the events
+func (c *Contract) simulateWithCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (*SimulationResult, error) {
+	result := &SimulationResult{
+		Events: []SimulatedEvent{},
+	}
+	output, err := callWithOverrides(ctx, c.Client, call, blockNumber, overrides)
+	if err == nil {
+		result.Success = true
+		result.ReturnData = output
+		return result, nil
+	}
+
+	// Handle reverts
+	revertData, isRevert := getRevertData(err)
+	if !isRevert {
+		return nil, fmt.Errorf("error simulating transaction: %w", c.normalizeErrorMessage(err))
+	}
+	result.ReturnData = revertData
+	result.RevertReason = decodeRevertReason(revertData, c.ABI)
+	if result.RevertReason == "" {
+		result.RevertReason = err.Error()
+	}
+	return result, nil
+}
+
+// Run a contract call with eth_call and a set of state overrides, against the pending block if no block number is provided
+// Overrides require a client that can apply them, either with eth_call or by tracing
+func callWithOverrides(ctx context.Context, client ExecutionClient, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) ([]byte, error) {
+	if caller, ok := client.(OverrideCaller); ok {
+		return caller.CallContractWithOverrides(ctx, call, blockNumber, overrides)
+	}
+	if len(overrides) > 0 {
+		tracer, ok := client.(CallTracer)
+		if !ok {
+			return nil, fmt.Errorf("the execution client does not support state overrides")
+		}
+		trace, err := tracer.TraceCall(ctx, call, blockNumber, overrides)
+		if err != nil {
+			return nil, err
+		}
+		if trace.Error != "" {
+			return nil, &revertError{message: trace.Error, data: trace.Output}
+		}
+		return trace.Output, nil
+	}
+	if pendingCaller, ok := client.(bind.PendingContractCaller); ok && blockNumber == nil {
+		return pendingCaller.PendingCallContract(ctx, call)
+	}
+	return client.CallContract(ctx, call, blockNumber)
+}
+
+// A reverted call, with the revert data in the same form RPC clients report it
+type revertError struct {
+	message string
+	data    []byte
+}
+
+func (e *revertError) Error() string {
+	return e.message
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return hexutil.Encode(e.data)
+}
+
+// Get the revert data from a failed call's error, if the call reverted
+func getRevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if encoded, ok := dataErr.ErrorData().(string); ok {
+			if data, err := hexutil.Decode(encoded); err == nil {
+				return data, true
+			}
+		}
+	}
+	if strings.Contains(err.Error(), "execution reverted") {
+		return []byte{}, true
+	}
+	return nil, false
+}
+
+// Decode an event's indexed and non-indexed values
+func decodeEvent(event *abi.Event, log types.Log, values map[string]interface{}) error {
+	if len(log.Data) > 0 {
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return err
+		}
+	}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	return abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:])
+}
+
+// Decode the revert reason from a failed call's return data
+func decodeRevertReason(data []byte, contractAbi *abi.ABI) string {
+	if len(data) < 4 {
+		return ""
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if bytes.Equal(data[:4], panicSelector) && len(data) >= 36 {
+		return fmt.Sprintf("panic: code 0x%x", new(big.Int).SetBytes(data[4:36]))
+	}
+	if contractAbi != nil {
+		for _, abiError := range contractAbi.Errors {
+			if !bytes.Equal(data[:4], abiError.ID[:4]) {
+				continue
+			}
+			if values, err := abiError.Unpack(data); err == nil {
+				return fmt.Sprintf("%s%v", abiError.Name, values)
+			}
+			return abiError.Name
+		}
+	}
+	return ""
+}
+
+// =============================
+// === State Override Client ===
+// =============================
+
+// An execution client that runs contract calls on top of a set of state overrides
+type overrideClient struct {
+	ExecutionClient
+	overrides StateOverrides
+}
+
+// Get an execution client that runs every contract call on top of a set of state overrides, such as the state changes
+// of a simulated transaction; contract bindings and multicalls created with it read the overridden state
+// Calls need a client that supports state overrides (an OverrideCaller or CallTracer), and a nil block number runs them against the pending block instead of the latest one
+func NewOverrideClient(client ExecutionClient, overrides StateOverrides) ExecutionClient {
+	return &overrideClient{
+		ExecutionClient: client,
+		overrides:       overrides,
+	}
+}
+
+func (c *overrideClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	output, err := c.CallContractWithOverrides(ctx, call, blockNumber, nil)
+	if err == nil {
+		return output, nil
+	}
+	if revertData, isRevert := getRevertData(err); isRevert {
+		if reason := decodeRevertReason(revertData, nil); reason != "" {
+			return nil, fmt.Errorf("execution reverted: %s", reason)
+		}
+	}
+	return nil, err
+}
+
+func (c *overrideClient) CallContractWithOverrides(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) ([]byte, error) {
+	return callWithOverrides(ctx, c.ExecutionClient, call, blockNumber, c.overrides.Merge(overrides))
+}
+
+func (c *overrideClient) TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (*CallTrace, error) {
+	tracer, err := getCallTracer(c.ExecutionClient)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.TraceCall(ctx, call, blockNumber, c.overrides.Merge(overrides))
+}
+
+func (c *overrideClient) TraceStateChanges(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (StateOverrides, error) {
+	tracer, err := getCallTracer(c.ExecutionClient)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.TraceStateChanges(ctx, call, blockNumber, c.overrides.Merge(overrides))
+}
+
+// Get the call tracer of an execution client, if it supports tracing
+// Override clients can only trace if the client they wrap can
+func getCallTracer(client ExecutionClient) (CallTracer, error) {
+	if wrapper, ok := client.(*overrideClient); ok {
+		if _, err := getCallTracer(wrapper.ExecutionClient); err != nil {
+			return nil, err
+		}
+	}
+	tracer, ok := client.(CallTracer)
+	if !ok {
+		return nil, fmt.Errorf("the execution client does not support call tracing")
+	}
+	return tracer, nil
+}
+
+// ==================
+// === RPC Tracer ===
+// ==================
+
+// Call arguments for eth_call and debug_traceCall
+type traceCallArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas,omitempty"`
+	GasPrice *hexutil.Big    `json:"gasPrice,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// Serialized account overrides for eth_call and debug_traceCall
+type traceAccountOverride struct {
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      hexutil.Bytes               `json:"code,omitempty"`
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// A call frame returned by the callTracer
+type traceCallFrame struct {
+	Output  hexutil.Bytes    `json:"output"`
+	Error   string           `json:"error"`
+	GasUsed hexutil.Uint64   `json:"gasUsed"`
+	Logs    []traceCallLog   `json:"logs"`
+	Calls   []traceCallFrame `json:"calls"`
+}
+type traceCallLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Position hexutil.Uint   `json:"position"`
+}
+
+// The result of the prestateTracer in diff mode
+type traceStateDiff struct {
+	Pre  map[common.Address]traceAccountState `json:"pre"`
+	Post map[common.Address]traceAccountState `json:"post"`
+}
+
+// An account's state as reported by the prestateTracer
+type traceAccountState struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   *uint64                     `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// Trace a call with debug_traceCall and the built-in callTracer, for CallTracer implementations backed by an RPC client
+// A nil block number simulates against the pending block
+func TraceCallWithRpc(ctx context.Context, client *rpc.Client, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (*CallTrace, error) {
+
+	// Run the trace
+	var frame traceCallFrame
+	args, blockTag, config := getTraceCallRequest(call, blockNumber, overrides, "callTracer", map[string]interface{}{
+		"withLog": true,
+	})
+	if err := client.CallContext(ctx, &frame, "debug_traceCall", args, blockTag, config); err != nil {
+		return nil, err
+	}
+
+	// Flatten the logs of every successful frame in execution order
+	trace := &CallTrace{
+		Output:  frame.Output,
+		Error:   frame.Error,
+		GasUsed: uint64(frame.GasUsed),
+		Logs:    []types.Log{},
+	}
+	collectTraceLogs(&frame, &trace.Logs)
+	return trace, nil
+
+}
+
+// Trace the state changes of a call with debug_traceCall and the built-in prestateTracer in diff mode, for CallTracer
+// implementations backed by an RPC client; the changes are returned as overrides that later calls can be simulated on top of
+// A nil block number simulates against the pending block
+func TraceStateChangesWithRpc(ctx context.Context, client *rpc.Client, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) (StateOverrides, error) {
+
+	// Run the trace
+	var diff traceStateDiff
+	args, blockTag, config := getTraceCallRequest(call, blockNumber, overrides, "prestateTracer", map[string]interface{}{
+		"diffMode": true,
+	})
+	if err := client.CallContext(ctx, &diff, "debug_traceCall", args, blockTag, config); err != nil {
+		return nil, err
+	}
+
+	// Convert the post-state of each account to overrides
+	changes := StateOverrides{}
+	for address, post := range diff.Post {
+		change := AccountOverride{
+			Code:      post.Code,
+			StateDiff: map[common.Hash]common.Hash{},
+		}
+		if post.Nonce != nil {
+			change.Nonce = post.Nonce
+		}
+		if post.Balance != nil {
+			change.Balance = (*big.Int)(post.Balance)
+		}
+		for slot, value := range post.Storage {
+			change.StateDiff[slot] = value
+		}
+		changes[address] = change
+	}
+
+	// Slots that were cleared are only in the pre-state, as are accounts that were deleted
+	for address, pre := range diff.Pre {
+		change, exists := changes[address]
+		if !exists {
+			var nonce uint64
+			change = AccountOverride{
+				Nonce:     &nonce,
+				Balance:   big.NewInt(0),
+				StateDiff: map[common.Hash]common.Hash{},
+			}
+		}
+		for slot := range pre.Storage {
+			if _, exists := change.StateDiff[slot]; !exists {
+				change.StateDiff[slot] = common.Hash{}
+			}
+		}
+		changes[address] = change
+	}
+	return changes, nil
+
+}
+
+// Run a contract call with eth_call and a set of state overrides, for OverrideCaller implementations backed by an RPC client
+// A nil block number runs the call against the pending block
+func CallContractWithRpc(ctx context.Context, client *rpc.Client, call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides) ([]byte, error) {
+	var output hexutil.Bytes
+	args, blockTag := getCallArgs(call, blockNumber)
+	params := []interface{}{args, blockTag}
+	if len(overrides) > 0 {
+		params = append(params, getOverrideArgs(overrides))
+	}
+	if err := client.CallContext(ctx, &output, "eth_call", params...); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Build the arguments of a debug_traceCall request for a tracer
+func getTraceCallRequest(call ethereum.CallMsg, blockNumber *big.Int, overrides StateOverrides, tracer string, tracerConfig map[string]interface{}) (traceCallArgs, string, map[string]interface{}) {
+	args, blockTag := getCallArgs(call, blockNumber)
+	config := map[string]interface{}{
+		"tracer":       tracer,
+		"tracerConfig": tracerConfig,
+	}
+	if len(overrides) > 0 {
+		config["stateOverrides"] = getOverrideArgs(overrides)
+	}
+	return args, blockTag, config
+}
+
+// Build the call arguments and block tag of an eth_call or debug_traceCall request
+func getCallArgs(call ethereum.CallMsg, blockNumber *big.Int) (traceCallArgs, string) {
+	args := traceCallArgs{
+		From: call.From,
+		To:   call.To,
+		Gas:  hexutil.Uint64(call.Gas),
+		Data: call.Data,
+	}
+	if call.GasPrice != nil {
+		args.GasPrice = (*hexutil.Big)(call.GasPrice)
+	}
+	if call.Value != nil {
+		args.Value = (*hexutil.Big)(call.Value)
+	}
+	blockTag := "pending"
+	if blockNumber != nil {
+		blockTag = hexutil.EncodeBig(blockNumber)
+	}
+	return args, blockTag
+}
+
+// Serialize a set of state overrides for an RPC request
+func getOverrideArgs(overrides StateOverrides) map[common.Address]traceAccountOverride {
+	stateOverrides := map[common.Address]traceAccountOverride{}
+	for address, override := range overrides {
+		serialized := traceAccountOverride{
+			Code:      override.Code,
+			State:     override.State,
+			StateDiff: override.StateDiff,
+		}
+		if override.Nonce != nil {
+			nonce := hexutil.Uint64(*override.Nonce)
+			serialized.Nonce = &nonce
+		}
+		if override.Balance != nil {
+			serialized.Balance = (*hexutil.Big)(override.Balance)
+		}
+		stateOverrides[address] = serialized
+	}
+	return stateOverrides
+}
+
+// Collect the logs of a call frame and its successful subcalls
+// Each log's position is the number of subcalls made before it was emitted, which preserves the execution order
+func collectTraceLogs(frame *traceCallFrame, logs *[]types.Log) {
+	if frame.Error != "" {
+		return
+	}
+	nextCall := 0
+	for _, log := range frame.Logs {
+		for ; nextCall < int(log.Position) && nextCall < len(frame.Calls); nextCall++ {
+			collectTraceLogs(&frame.Calls[nextCall], logs)
+		}
+		*logs = append(*logs, types.Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+		})
+	}
+	for ; nextCall < len(frame.Calls); nextCall++ {
+		collectTraceLogs(&frame.Calls[nextCall], logs)
+	}
+}
@@ -2,8 +2,10 @@ package rocketpool
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,7 +16,7 @@ import (
 type LegacyVersionWrapper interface {
 	GetVersion() *version.Version
 	GetVersionedContractName(contractName string) (string, bool)
-	GetEncodedABI(contractName string) string
+	GetABI(contractName string) (*abi.ABI, error)
 	GetContract(contractName string, opts *bind.CallOpts) (*Contract, error)
 	GetContractWithAddress(contractName string, address common.Address) (*Contract, error)
 }
@@ -25,19 +27,93 @@ type VersionManager struct {
 	V1_1_0     LegacyVersionWrapper
 	V1_2_0     LegacyVersionWrapper
 
-	rp *RocketPool
+	rp                  *RocketPool
+	legacyContracts     map[string]*Contract
+	legacyContractsLock sync.RWMutex
 }
 
 func NewVersionManager(rp *RocketPool) *VersionManager {
 	return &VersionManager{
-		V1_0_0:     newLegacyVersionWrapper_v1_0_0(rp),
-		V1_1_0_RC1: newLegacyVersionWrapper_v1_1_0_rc1(rp),
-		V1_1_0:     newLegacyVersionWrapper_v1_1_0(rp),
-		V1_2_0:     newLegacyVersionWrapper_v1_2_0(rp),
-		rp:         rp,
+		V1_0_0:          newLegacyVersionWrapper_v1_0_0(rp),
+		V1_1_0_RC1:      newLegacyVersionWrapper_v1_1_0_rc1(rp),
+		V1_1_0:          newLegacyVersionWrapper_v1_1_0(rp),
+		V1_2_0:          newLegacyVersionWrapper_v1_2_0(rp),
+		rp:              rp,
+		legacyContracts: map[string]*Contract{},
 	}
 }
 
+// Get the legacy version wrappers, oldest first
+func (m *VersionManager) GetLegacyVersions() []LegacyVersionWrapper {
+	return []LegacyVersionWrapper{m.V1_0_0, m.V1_1_0_RC1, m.V1_1_0, m.V1_2_0}
+}
+
+// Get the contract with the provided name as it was deployed at the block in the call options
+// If the contract live at that block has since been replaced by an upgrade, it's bound with the ABI of the legacy version it belongs to;
+// otherwise, or if no block is provided, this is the same as rp.GetContract
+func (m *VersionManager) GetContractAtBlock(contractName string, opts *bind.CallOpts) (*Contract, error) {
+	if opts == nil || opts.BlockNumber == nil {
+		return m.rp.GetContract(contractName, opts)
+	}
+
+	// Get the address that was live at the block
+	address, err := m.rp.GetAddress(contractName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the legacy version that the contract belongs to, if any
+	wrapper, legacyName, err := m.getLegacyVersionForAddress(contractName, *address)
+	if err != nil {
+		return nil, err
+	}
+	if wrapper == nil {
+		return m.rp.GetContract(contractName, opts)
+	}
+
+	// Check for a cached binding
+	m.legacyContractsLock.RLock()
+	contract, ok := m.legacyContracts[legacyName]
+	m.legacyContractsLock.RUnlock()
+	if ok {
+		return contract, nil
+	}
+
+	// Create and cache the binding; legacy contracts never change, so it doesn't expire
+	contract, err = wrapper.GetContractWithAddress(contractName, *address)
+	if err != nil {
+		return nil, err
+	}
+	m.legacyContractsLock.Lock()
+	m.legacyContracts[legacyName] = contract
+	m.legacyContractsLock.Unlock()
+	return contract, nil
+}
+
+// Get the version wrapper for a contract address, if that address was replaced by one of the legacy upgrades
+func (m *VersionManager) GetLegacyVersionForAddress(contractName string, address common.Address) (LegacyVersionWrapper, error) {
+	wrapper, _, err := m.getLegacyVersionForAddress(contractName, address)
+	return wrapper, err
+}
+
+// Match an address against the addresses upgrades registered for each legacy version of a contract
+func (m *VersionManager) getLegacyVersionForAddress(contractName string, address common.Address) (LegacyVersionWrapper, string, error) {
+	for _, wrapper := range m.GetLegacyVersions() {
+		legacyName, exists := wrapper.GetVersionedContractName(contractName)
+		if !exists {
+			continue
+		}
+		legacyAddress, err := m.rp.GetAddress(legacyName, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("error loading v%s contract %s address: %w", wrapper.GetVersion().String(), contractName, err)
+		}
+		if *legacyAddress != (common.Address{}) && *legacyAddress == address {
+			return wrapper, legacyName, nil
+		}
+	}
+	return nil, "", nil
+}
+
 // Get the contract with the provided name and version wrapper
 func getLegacyContract(rp *RocketPool, contractName string, m LegacyVersionWrapper, opts *bind.CallOpts) (*Contract, error) {
 
@@ -69,17 +145,18 @@ func getLegacyContract(rp *RocketPool, contractName string, m LegacyVersionWrapp
 	}
 
 	// If we're here, we have a legacy contract
-	abiEncoded := m.GetEncodedABI(contractName)
-	abi, err := DecodeAbi(abiEncoded)
+	abi, err := m.GetABI(contractName)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+		return nil, fmt.Errorf("error loading contract %s ABI: %w", contractName, err)
 	}
 
 	contract := &Contract{
-		Contract: bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  &address,
-		ABI:      abi,
-		Client:   rp.Client,
+		Contract:            bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             &address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                contractName,
+		GasLimitMultipliers: rp.GasLimitMultipliers,
 	}
 
 	return contract, nil
@@ -89,17 +166,18 @@ func getLegacyContract(rp *RocketPool, contractName string, m LegacyVersionWrapp
 // Get the contract with the provided name, address, and version wrapper
 func getLegacyContractWithAddress(rp *RocketPool, contractName string, address common.Address, m LegacyVersionWrapper) (*Contract, error) {
 
-	abiEncoded := m.GetEncodedABI(contractName)
-	abi, err := DecodeAbi(abiEncoded)
+	abi, err := m.GetABI(contractName)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+		return nil, fmt.Errorf("error loading contract %s ABI: %w", contractName, err)
 	}
 
 	contract := &Contract{
-		Contract: bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
-		Address:  &address,
-		ABI:      abi,
-		Client:   rp.Client,
+		Contract:            bind.NewBoundContract(address, *abi, rp.Client, rp.Client, rp.Client),
+		Address:             &address,
+		ABI:                 abi,
+		Client:              rp.Client,
+		Name:                contractName,
+		GasLimitMultipliers: rp.GasLimitMultipliers,
 	}
 
 	return contract, nil
@@ -0,0 +1,228 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A single change in a multi-setting proposal
+type SettingChange struct {
+	Setting *Setting    `json:"setting"`
+	Value   interface{} `json:"value"`
+}
+
+// Builds a Protocol DAO proposal that changes several settings at once
+// Every change is checked against the registry as it's added, so a proposal can be validated, simulated and summarized
+// before the voting tree is generated or the proposal bond is locked
+type ProposalBuilder struct {
+	capabilities *rocketpool.Capabilities
+	changes      []SettingChange
+	errs         []error
+}
+
+// Create a new proposal builder
+// If capabilities are provided, settings that don't exist in that protocol version are rejected
+func NewProposalBuilder(capabilities *rocketpool.Capabilities) *ProposalBuilder {
+	return &ProposalBuilder{
+		capabilities: capabilities,
+		changes:      []SettingChange{},
+		errs:         []error{},
+	}
+}
+
+// Add a change to a uint256 setting
+func (b *ProposalBuilder) SetUint(contractName string, path string, value *big.Int) *ProposalBuilder {
+	return b.Set(contractName, path, value)
+}
+
+// Add a change to a bool setting
+func (b *ProposalBuilder) SetBool(contractName string, path string, value bool) *ProposalBuilder {
+	return b.Set(contractName, path, value)
+}
+
+// Add a change to an address setting
+func (b *ProposalBuilder) SetAddress(contractName string, path string, value common.Address) *ProposalBuilder {
+	return b.Set(contractName, path, value)
+}
+
+// Add a change to a setting, parsing its value from a string in the setting's unit (see Setting.Parse)
+func (b *ProposalBuilder) SetString(contractName string, path string, value string) *ProposalBuilder {
+	setting, err := GetSetting(contractName, path)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	parsedValue, err := setting.Parse(value)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.addChange(setting, parsedValue)
+}
+
+// Add a change to a setting
+func (b *ProposalBuilder) Set(contractName string, path string, value interface{}) *ProposalBuilder {
+	setting, err := GetSetting(contractName, path)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.addChange(setting, value)
+}
+
+// Add a change to a setting identified by path alone (see FindSetting)
+func (b *ProposalBuilder) SetPath(path string, value interface{}) *ProposalBuilder {
+	setting, err := FindSetting(path)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.addChange(setting, value)
+}
+
+// Check a change and add it to the proposal
+func (b *ProposalBuilder) addChange(setting *Setting, value interface{}) *ProposalBuilder {
+	if !setting.ChangeableBy(DaoProtocol) {
+		b.errs = append(b.errs, fmt.Errorf("setting %s can't be changed by the %s", setting.String(), DaoProtocol.String()))
+		return b
+	}
+	if b.capabilities != nil && !setting.IsAvailable(b.capabilities) {
+		b.errs = append(b.errs, fmt.Errorf("setting %s requires %s, which protocol version %s doesn't support", setting.String(), setting.Feature, b.capabilities.Version.Original()))
+		return b
+	}
+	for _, change := range b.changes {
+		if change.Setting == setting {
+			b.errs = append(b.errs, fmt.Errorf("setting %s is changed more than once", setting.String()))
+			return b
+		}
+	}
+	if err := setting.Validate(value); err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.changes = append(b.changes, SettingChange{Setting: setting, Value: value})
+	return b
+}
+
+// Get the changes in the proposal
+func (b *ProposalBuilder) GetChanges() []SettingChange {
+	changes := make([]SettingChange, len(b.changes))
+	copy(changes, b.changes)
+	return changes
+}
+
+// Check the proposal for errors, returning all of them at once
+func (b *ProposalBuilder) Validate() error {
+	if len(b.errs) == 1 {
+		return b.errs[0]
+	}
+	if len(b.errs) > 1 {
+		messages := make([]string, len(b.errs))
+		for i, err := range b.errs {
+			messages[i] = err.Error()
+		}
+		return fmt.Errorf("the proposal has %d errors:\n%s", len(b.errs), strings.Join(messages, "\n"))
+	}
+	if len(b.changes) == 0 {
+		return fmt.Errorf("the proposal doesn't change any settings")
+	}
+	return nil
+}
+
+// Get the proposal arguments, in the form ProposeSetMulti takes them
+func (b *ProposalBuilder) GetArgs() ([]string, []string, []types.ProposalSettingType, []any, error) {
+	if err := b.Validate(); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	contractNames := make([]string, len(b.changes))
+	settingPaths := make([]string, len(b.changes))
+	settingTypes := make([]types.ProposalSettingType, len(b.changes))
+	values := make([]any, len(b.changes))
+	for i, change := range b.changes {
+		contractNames[i] = change.Setting.ContractName
+		settingPaths[i] = change.Setting.Path
+		settingTypes[i] = change.Setting.Type
+		values[i] = change.Value
+	}
+	return contractNames, settingPaths, settingTypes, values, nil
+}
+
+// Get the proposal's payload
+func (b *ProposalBuilder) GetPayload(rp *rocketpool.RocketPool) ([]byte, error) {
+	contractNames, settingPaths, settingTypes, values, err := b.GetArgs()
+	if err != nil {
+		return nil, err
+	}
+	return protocol.GetProposeSetMultiPayload(rp, contractNames, settingPaths, settingTypes, values)
+}
+
+// Simulate the proposal's execution against the latest block to verify it won't revert
+func (b *ProposalBuilder) Simulate(rp *rocketpool.RocketPool) error {
+	payload, err := b.GetPayload(rp)
+	if err != nil {
+		return err
+	}
+	if err := protocol.SimulateProposalExecution(rp, payload); err != nil {
+		return fmt.Errorf("error simulating proposal execution: %w", err)
+	}
+	return nil
+}
+
+// Get a human-readable summary of the proposal, one line per change
+func (b *ProposalBuilder) Summary() string {
+	lines := make([]string, len(b.changes))
+	for i, change := range b.changes {
+		lines[i] = fmt.Sprintf("Set %s to %s%s", change.Setting.String(), change.Setting.Format(change.Value), securityCouncilNote(change.Setting))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Get a human-readable summary of the proposal, including the current value of each setting
+func (b *ProposalBuilder) SummaryWithCurrentValues(rp *rocketpool.RocketPool, opts *bind.CallOpts) (string, error) {
+	lines := make([]string, len(b.changes))
+	for i, change := range b.changes {
+		currentValue, err := change.Setting.GetValue(rp, opts)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = fmt.Sprintf("Set %s from %s to %s%s", change.Setting.String(), change.Setting.Format(currentValue), change.Setting.Format(change.Value), securityCouncilNote(change.Setting))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Get a note for settings the security council can also change
+func securityCouncilNote(setting *Setting) string {
+	if setting.ChangeableBy(DaoSecurityCouncil) {
+		return " (the security council can also change this setting)"
+	}
+	return ""
+}
+
+// Estimate the gas of submitting the proposal
+func (b *ProposalBuilder) EstimateProposeGas(rp *rocketpool.RocketPool, message string, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	contractNames, settingPaths, settingTypes, values, err := b.GetArgs()
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return protocol.EstimateProposeSetMultiGas(rp, message, contractNames, settingPaths, settingTypes, values, blockNumber, treeNodes, opts)
+}
+
+// Submit the proposal, after simulating its execution
+func (b *ProposalBuilder) Propose(rp *rocketpool.RocketPool, message string, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	if err := b.Simulate(rp); err != nil {
+		return 0, common.Hash{}, err
+	}
+	contractNames, settingPaths, settingTypes, values, err := b.GetArgs()
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	return protocol.ProposeSetMulti(rp, message, contractNames, settingPaths, settingTypes, values, blockNumber, treeNodes, opts)
+}
@@ -0,0 +1,119 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/security"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Estimate the gas of proposing a new value for a setting to the Protocol DAO
+func EstimateProposeProtocolSettingGas(rp *rocketpool.RocketPool, setting *Setting, value interface{}, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	if err := checkProposal(setting, DaoProtocol, value); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return protocol.EstimateProposeSetUintGas(rp, message, setting.ContractName, setting.Path, value.(*big.Int), blockNumber, treeNodes, opts)
+	case types.ProposalSettingType_Bool:
+		return protocol.EstimateProposeSetBoolGas(rp, message, setting.ContractName, setting.Path, value.(bool), blockNumber, treeNodes, opts)
+	default:
+		return protocol.EstimateProposeSetAddressGas(rp, message, setting.ContractName, setting.Path, value.(common.Address), blockNumber, treeNodes, opts)
+	}
+}
+
+// Propose a new value for a setting to the Protocol DAO
+func ProposeProtocolSetting(rp *rocketpool.RocketPool, setting *Setting, value interface{}, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	if err := checkProposal(setting, DaoProtocol, value); err != nil {
+		return 0, common.Hash{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return protocol.ProposeSetUint(rp, message, setting.ContractName, setting.Path, value.(*big.Int), blockNumber, treeNodes, opts)
+	case types.ProposalSettingType_Bool:
+		return protocol.ProposeSetBool(rp, message, setting.ContractName, setting.Path, value.(bool), blockNumber, treeNodes, opts)
+	default:
+		return protocol.ProposeSetAddress(rp, message, setting.ContractName, setting.Path, value.(common.Address), blockNumber, treeNodes, opts)
+	}
+}
+
+// Estimate the gas of proposing a new value for a setting to the Oracle DAO
+func EstimateProposeOracleDaoSettingGas(rp *rocketpool.RocketPool, setting *Setting, value interface{}, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	if err := checkProposal(setting, DaoOracle, value); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return trustednode.EstimateProposeSetUintGas(rp, message, setting.ContractName, setting.Path, value.(*big.Int), opts)
+	case types.ProposalSettingType_Bool:
+		return trustednode.EstimateProposeSetBoolGas(rp, message, setting.ContractName, setting.Path, value.(bool), opts)
+	default:
+		return rocketpool.GasInfo{}, fmt.Errorf("the Oracle DAO can't propose address settings")
+	}
+}
+
+// Propose a new value for a setting to the Oracle DAO
+func ProposeOracleDaoSetting(rp *rocketpool.RocketPool, setting *Setting, value interface{}, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	if err := checkProposal(setting, DaoOracle, value); err != nil {
+		return 0, common.Hash{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return trustednode.ProposeSetUint(rp, message, setting.ContractName, setting.Path, value.(*big.Int), opts)
+	case types.ProposalSettingType_Bool:
+		return trustednode.ProposeSetBool(rp, message, setting.ContractName, setting.Path, value.(bool), opts)
+	default:
+		return 0, common.Hash{}, fmt.Errorf("the Oracle DAO can't propose address settings")
+	}
+}
+
+// Estimate the gas of proposing a new value for a setting to the security council
+func EstimateProposeSecurityCouncilSettingGas(rp *rocketpool.RocketPool, setting *Setting, value interface{}, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	if err := checkProposal(setting, DaoSecurityCouncil, value); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return security.EstimateProposeSetUintGas(rp, message, setting.SecurityNamespace, setting.Path, value.(*big.Int), opts)
+	case types.ProposalSettingType_Bool:
+		return security.EstimateProposeSetBoolGas(rp, message, setting.SecurityNamespace, setting.Path, value.(bool), opts)
+	default:
+		return rocketpool.GasInfo{}, fmt.Errorf("the security council can't propose address settings")
+	}
+}
+
+// Propose a new value for a setting to the security council
+func ProposeSecurityCouncilSetting(rp *rocketpool.RocketPool, setting *Setting, value interface{}, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	if err := checkProposal(setting, DaoSecurityCouncil, value); err != nil {
+		return 0, common.Hash{}, err
+	}
+	message := fmt.Sprintf("set %s", setting.Path)
+	switch setting.Type {
+	case types.ProposalSettingType_Uint256:
+		return security.ProposeSetUint(rp, message, setting.SecurityNamespace, setting.Path, value.(*big.Int), opts)
+	case types.ProposalSettingType_Bool:
+		return security.ProposeSetBool(rp, message, setting.SecurityNamespace, setting.Path, value.(bool), opts)
+	default:
+		return 0, common.Hash{}, fmt.Errorf("the security council can't propose address settings")
+	}
+}
+
+// Check that a DAO can propose a value for a setting
+func checkProposal(setting *Setting, dao Dao, value interface{}) error {
+	if !setting.ChangeableBy(dao) {
+		return fmt.Errorf("setting %s can't be changed by the %s", setting.String(), dao.String())
+	}
+	return setting.Validate(value)
+}
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -187,10 +186,6 @@ func EstimateProposeLotReservePriceRatioGas(rp *rocketpool.RocketPool, value *bi
 }
 
 // Get contracts
-var auctionSettingsContractLock sync.Mutex
-
 func getAuctionSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	auctionSettingsContractLock.Lock()
-	defer auctionSettingsContractLock.Unlock()
 	return rp.GetContract(AuctionSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -159,10 +158,6 @@ func EstimateProposeDepositFeeGas(rp *rocketpool.RocketPool, value *big.Int, blo
 }
 
 // Get contracts
-var depositSettingsContractLock sync.Mutex
-
 func getDepositSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	depositSettingsContractLock.Lock()
-	defer depositSettingsContractLock.Unlock()
 	return rp.GetContract(DepositSettingsContractName, opts)
 }
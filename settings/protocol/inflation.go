@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
@@ -13,7 +12,9 @@ import (
 
 // Config
 const (
-	InflationSettingsContractName string = "rocketDAOProtocolSettingsInflation"
+	InflationSettingsContractName         string = "rocketDAOProtocolSettingsInflation"
+	InflationIntervalRateSettingPath      string = "rpl.inflation.interval.rate"
+	InflationIntervalStartTimeSettingPath string = "rpl.inflation.interval.start"
 )
 
 // RPL inflation rate per interval
@@ -56,10 +57,6 @@ func GetInflationStartTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint
 }
 
 // Get contracts
-var inflationSettingsContractLock sync.Mutex
-
 func getInflationSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	inflationSettingsContractLock.Lock()
-	defer inflationSettingsContractLock.Unlock()
 	return rp.GetContract(InflationSettingsContractName, opts)
 }
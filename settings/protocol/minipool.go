@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -154,10 +153,6 @@ func EstimateProposeMinipoolUserDistributeWindowLengthGas(rp *rocketpool.RocketP
 }
 
 // Get contracts
-var minipoolSettingsContractLock sync.Mutex
-
 func getMinipoolSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	minipoolSettingsContractLock.Lock()
-	defer minipoolSettingsContractLock.Unlock()
 	return rp.GetContract(MinipoolSettingsContractName, opts)
 }
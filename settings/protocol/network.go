@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -372,10 +371,6 @@ func EstimateProposeSubmitRewardsEnabledGas(rp *rocketpool.RocketPool, value boo
 }
 
 // Get contracts
-var networkSettingsContractLock sync.Mutex
-
 func getNetworkSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	networkSettingsContractLock.Lock()
-	defer networkSettingsContractLock.Unlock()
 	return rp.GetContract(NetworkSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -166,10 +165,6 @@ func GetMaximumPerMinipoolStakeRaw(rp *rocketpool.RocketPool, opts *bind.CallOpt
 }
 
 // Get contracts
-var nodeSettingsContractLock sync.Mutex
-
 func getNodeSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	nodeSettingsContractLock.Lock()
-	defer nodeSettingsContractLock.Unlock()
 	return rp.GetContract(NodeSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -246,10 +245,6 @@ func EstimateProposeProposalMaxBlockAgeGas(rp *rocketpool.RocketPool, value *big
 }
 
 // Get contracts
-var proposalsSettingsContractLock sync.Mutex
-
 func getProposalsSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	proposalsSettingsContractLock.Lock()
-	defer proposalsSettingsContractLock.Unlock()
 	return rp.GetContract(ProposalsSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -126,10 +125,6 @@ func EstimateProposeRewardsClaimIntervalTimeGas(rp *rocketpool.RocketPool, value
 }
 
 // Get contracts
-var rewardsSettingsContractLock sync.Mutex
-
 func getRewardsSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rewardsSettingsContractLock.Lock()
-	defer rewardsSettingsContractLock.Unlock()
 	return rp.GetContract(RewardsSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package protocol
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -119,10 +118,6 @@ func EstimateProposeSecurityProposalActionTimeGas(rp *rocketpool.RocketPool, val
 }
 
 // Get contracts
-var securitySettingsContractLock sync.Mutex
-
 func getSecuritySettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	securitySettingsContractLock.Lock()
-	defer securitySettingsContractLock.Unlock()
 	return rp.GetContract(SecuritySettingsContractName, opts)
 }
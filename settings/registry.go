@@ -0,0 +1,321 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The unit a setting's value is stored in
+type SettingUnit string
+
+const (
+	UnitNone     SettingUnit = ""         // Booleans and addresses
+	UnitSeconds  SettingUnit = "seconds"  // A duration in seconds
+	UnitFraction SettingUnit = "fraction" // A fraction with 18 decimal places, where 1e18 is 100%
+	UnitEth      SettingUnit = "eth"      // An amount of ETH in wei
+	UnitRpl      SettingUnit = "rpl"      // An amount of RPL in wei
+	UnitBlocks   SettingUnit = "blocks"   // A number of blocks
+	UnitCount    SettingUnit = "count"    // A plain number
+)
+
+// A DAO that can change settings
+type Dao string
+
+const (
+	DaoProtocol        Dao = "pdao"
+	DaoOracle          Dao = "odao"
+	DaoSecurityCouncil Dao = "security"
+)
+
+// Get the DAO's display name
+func (d Dao) String() string {
+	switch d {
+	case DaoProtocol:
+		return "Protocol DAO"
+	case DaoOracle:
+		return "Oracle DAO"
+	case DaoSecurityCouncil:
+		return "security council"
+	}
+	return string(d)
+}
+
+// A setting that can be changed by a DAO proposal
+type Setting struct {
+	ContractName string                    `json:"contractName"`
+	Path         string                    `json:"path"`
+	Getter       string                    `json:"getter"`
+	Type         types.ProposalSettingType `json:"type"`
+	Unit         SettingUnit               `json:"unit"`
+	Description  string                    `json:"description"`
+
+	// The bounds the latest contract enforces when the setting is changed, if any
+	Min *big.Int `json:"min,omitempty"`
+	Max *big.Int `json:"max,omitempty"`
+
+	// The DAOs that can change the setting
+	Daos []Dao `json:"daos"`
+
+	// The security council refers to settings by namespace rather than contract name
+	SecurityNamespace string `json:"securityNamespace,omitempty"`
+
+	// The feature that introduced the setting, if it wasn't present from the start
+	Feature rocketpool.Feature `json:"feature,omitempty"`
+}
+
+// Check if a DAO can change the setting
+func (s *Setting) ChangeableBy(dao Dao) bool {
+	for _, settingDao := range s.Daos {
+		if settingDao == dao {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the setting exists in a protocol version
+func (s *Setting) IsAvailable(capabilities *rocketpool.Capabilities) bool {
+	return s.Feature == "" || capabilities.Has(s.Feature)
+}
+
+// Get the setting's full name, which is unique across contracts
+func (s *Setting) String() string {
+	return fmt.Sprintf("%s/%s", s.ContractName, s.Path)
+}
+
+// Security council namespaces
+const (
+	auctionNamespace  string = "auction"
+	depositNamespace  string = "deposit"
+	minipoolNamespace string = "minipool"
+	networkNamespace  string = "network"
+	nodeNamespace     string = "node"
+)
+
+// Get a fraction as wei, from a percentage with two decimal places (e.g. 5100 is 51%)
+func basisPoints(value int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(value), big.NewInt(1e14))
+}
+
+// Get a duration as a number of seconds
+func seconds(value time.Duration) *big.Int {
+	return big.NewInt(int64(value / time.Second))
+}
+
+// Protocol DAO setting
+func pdao(contractName string, path string, getter string, settingType types.ProposalSettingType, unit SettingUnit, description string) *Setting {
+	return &Setting{
+		ContractName: contractName,
+		Path:         path,
+		Getter:       getter,
+		Type:         settingType,
+		Unit:         unit,
+		Description:  description,
+		Daos:         []Dao{DaoProtocol},
+	}
+}
+
+// Oracle DAO setting
+func odao(contractName string, path string, getter string, settingType types.ProposalSettingType, unit SettingUnit, description string) *Setting {
+	return &Setting{
+		ContractName: contractName,
+		Path:         path,
+		Getter:       getter,
+		Type:         settingType,
+		Unit:         unit,
+		Description:  description,
+		Daos:         []Dao{DaoOracle},
+	}
+}
+
+// Allow the security council to change a setting
+func (s *Setting) withSecurityCouncil(namespace string) *Setting {
+	s.Daos = append(s.Daos, DaoSecurityCouncil)
+	s.SecurityNamespace = namespace
+	return s
+}
+
+// Set the bounds the contract enforces on a setting
+func (s *Setting) withBounds(min *big.Int, max *big.Int) *Setting {
+	s.Min = min
+	s.Max = max
+	return s
+}
+
+// Set the feature that introduced a setting
+func (s *Setting) since(feature rocketpool.Feature) *Setting {
+	s.Feature = feature
+	return s
+}
+
+const (
+	uintType = types.ProposalSettingType_Uint256
+	boolType = types.ProposalSettingType_Bool
+	day      = 24 * time.Hour
+	week     = 7 * day
+)
+
+// Every setting, in contract order
+var registry = []*Setting{
+	// Auction
+	pdao(psettings.AuctionSettingsContractName, psettings.CreateLotEnabledSettingPath, "getCreateLotEnabled", boolType, UnitNone, "Whether lot creation is enabled").withSecurityCouncil(auctionNamespace),
+	pdao(psettings.AuctionSettingsContractName, psettings.BidOnLotEnabledSettingPath, "getBidOnLotEnabled", boolType, UnitNone, "Whether bidding on lots is enabled").withSecurityCouncil(auctionNamespace),
+	pdao(psettings.AuctionSettingsContractName, psettings.LotMinimumEthValueSettingPath, "getLotMinimumEthValue", uintType, UnitEth, "The minimum lot size"),
+	pdao(psettings.AuctionSettingsContractName, psettings.LotMaximumEthValueSettingPath, "getLotMaximumEthValue", uintType, UnitEth, "The maximum lot size"),
+	pdao(psettings.AuctionSettingsContractName, psettings.LotDurationSettingPath, "getLotDuration", uintType, UnitSeconds, "The lot duration"),
+	pdao(psettings.AuctionSettingsContractName, psettings.LotStartingPriceRatioSettingPath, "getStartingPriceRatio", uintType, UnitFraction, "The starting price relative to the current RPL price"),
+	pdao(psettings.AuctionSettingsContractName, psettings.LotReservePriceRatioSettingPath, "getReservePriceRatio", uintType, UnitFraction, "The reserve price relative to the current RPL price"),
+
+	// Deposit
+	pdao(psettings.DepositSettingsContractName, psettings.DepositEnabledSettingPath, "getDepositEnabled", boolType, UnitNone, "Whether deposits into the deposit pool are enabled").withSecurityCouncil(depositNamespace),
+	pdao(psettings.DepositSettingsContractName, psettings.AssignDepositsEnabledSettingPath, "getAssignDepositsEnabled", boolType, UnitNone, "Whether deposits are assigned to minipools").withSecurityCouncil(depositNamespace),
+	pdao(psettings.DepositSettingsContractName, psettings.MinimumDepositSettingPath, "getMinimumDeposit", uintType, UnitEth, "The minimum deposit into the deposit pool"),
+	pdao(psettings.DepositSettingsContractName, psettings.MaximumDepositPoolSizeSettingPath, "getMaximumDepositPoolSize", uintType, UnitEth, "The maximum size of the deposit pool"),
+	pdao(psettings.DepositSettingsContractName, psettings.MaximumDepositAssignmentsSettingPath, "getMaximumDepositAssignments", uintType, UnitCount, "The maximum number of minipools assigned per deposit"),
+	pdao(psettings.DepositSettingsContractName, psettings.MaximumSocializedDepositAssignmentsSettingPath, "getMaximumDepositSocialisedAssignments", uintType, UnitCount, "The maximum number of socialized minipool assignments per deposit").since(rocketpool.FeatureVacantMinipools),
+	pdao(psettings.DepositSettingsContractName, psettings.DepositFeeSettingPath, "getDepositFee", uintType, UnitFraction, "The fee taken from deposits into the deposit pool").withBounds(nil, basisPoints(100)),
+
+	// Inflation
+	pdao(psettings.InflationSettingsContractName, psettings.InflationIntervalRateSettingPath, "getInflationIntervalRate", uintType, UnitCount, "The RPL supply multiplier applied each inflation interval, with 18 decimal places"),
+	pdao(psettings.InflationSettingsContractName, psettings.InflationIntervalStartTimeSettingPath, "getInflationIntervalStartTime", uintType, UnitCount, "The time RPL inflation starts, as a Unix timestamp"),
+
+	// Minipool
+	pdao(psettings.MinipoolSettingsContractName, psettings.MinipoolSubmitWithdrawableEnabledSettingPath, "getSubmitWithdrawableEnabled", boolType, UnitNone, "Whether minipool withdrawable events can be submitted").withSecurityCouncil(minipoolNamespace),
+	pdao(psettings.MinipoolSettingsContractName, psettings.MinipoolLaunchTimeoutSettingPath, "getLaunchTimeout", uintType, UnitSeconds, "How long a prelaunch minipool can wait before it times out").withBounds(seconds(12*time.Hour), nil),
+	pdao(psettings.MinipoolSettingsContractName, psettings.BondReductionEnabledSettingPath, "getBondReductionEnabled", boolType, UnitNone, "Whether minipool bond reductions are enabled").withSecurityCouncil(minipoolNamespace).since(rocketpool.FeatureBondReduction),
+	pdao(psettings.MinipoolSettingsContractName, psettings.MaximumMinipoolCountSettingPath, "getMaximumCount", uintType, UnitCount, "The maximum number of minipools on the network"),
+	pdao(psettings.MinipoolSettingsContractName, psettings.MinipoolUserDistributeWindowStartSettingPath, "getUserDistributeWindowStart", uintType, UnitSeconds, "How long after a distribution is started that a user can finish it").since(rocketpool.FeatureBondReduction),
+	pdao(psettings.MinipoolSettingsContractName, psettings.MinipoolUserDistributeWindowLengthSettingPath, "getUserDistributeWindowLength", uintType, UnitSeconds, "How long a user has to finish a distribution").since(rocketpool.FeatureBondReduction),
+
+	// Network
+	pdao(psettings.NetworkSettingsContractName, psettings.NodeConsensusThresholdSettingPath, "getNodeConsensusThreshold", uintType, UnitFraction, "The fraction of Oracle DAO members that must agree on a submission").withBounds(basisPoints(5100), nil),
+	pdao(psettings.NetworkSettingsContractName, psettings.SubmitBalancesEnabledSettingPath, "getSubmitBalancesEnabled", boolType, UnitNone, "Whether network balance submissions are enabled").withSecurityCouncil(networkNamespace),
+	pdao(psettings.NetworkSettingsContractName, psettings.SubmitBalancesFrequencySettingPath, "getSubmitBalancesFrequency", uintType, UnitSeconds, "How often network balances are submitted").withBounds(seconds(time.Hour), nil).since(rocketpool.FeatureSubmissionFrequencies),
+	pdao(psettings.NetworkSettingsContractName, psettings.SubmitPricesEnabledSettingPath, "getSubmitPricesEnabled", boolType, UnitNone, "Whether RPL price submissions are enabled").withSecurityCouncil(networkNamespace),
+	pdao(psettings.NetworkSettingsContractName, psettings.SubmitPricesFrequencySettingPath, "getSubmitPricesFrequency", uintType, UnitSeconds, "How often the RPL price is submitted").withBounds(seconds(time.Hour), nil).since(rocketpool.FeatureSubmissionFrequencies),
+	pdao(psettings.NetworkSettingsContractName, psettings.MinimumNodeFeeSettingPath, "getMinimumNodeFee", uintType, UnitFraction, "The minimum node commission rate").withBounds(basisPoints(500), basisPoints(2000)),
+	pdao(psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, "getTargetNodeFee", uintType, UnitFraction, "The target node commission rate").withBounds(basisPoints(500), basisPoints(2000)),
+	pdao(psettings.NetworkSettingsContractName, psettings.MaximumNodeFeeSettingPath, "getMaximumNodeFee", uintType, UnitFraction, "The maximum node commission rate").withBounds(basisPoints(500), basisPoints(2000)),
+	pdao(psettings.NetworkSettingsContractName, psettings.NodeFeeDemandRangeSettingPath, "getNodeFeeDemandRange", uintType, UnitEth, "The range of node demand the commission rate is based on"),
+	pdao(psettings.NetworkSettingsContractName, psettings.TargetRethCollateralRateSettingPath, "getTargetRethCollateralRate", uintType, UnitFraction, "The target collateralization rate of the rETH contract"),
+	pdao(psettings.NetworkSettingsContractName, psettings.NetworkPenaltyThresholdSettingPath, "getNodePenaltyThreshold", uintType, UnitFraction, "The fraction of Oracle DAO members that must agree on a penalty").withBounds(basisPoints(5100), nil),
+	pdao(psettings.NetworkSettingsContractName, psettings.NetworkPenaltyPerRateSettingPath, "getPerPenaltyRate", uintType, UnitFraction, "The penalty applied per penalty submission"),
+	pdao(psettings.NetworkSettingsContractName, psettings.SubmitRewardsEnabledSettingPath, "getSubmitRewardsEnabled", boolType, UnitNone, "Whether rewards tree submissions are enabled").withSecurityCouncil(networkNamespace).since(rocketpool.FeatureMerkleRewards),
+
+	// Node
+	pdao(psettings.NodeSettingsContractName, psettings.NodeRegistrationEnabledSettingPath, "getRegistrationEnabled", boolType, UnitNone, "Whether node registration is enabled").withSecurityCouncil(nodeNamespace),
+	pdao(psettings.NodeSettingsContractName, psettings.SmoothingPoolRegistrationEnabledSettingPath, "getSmoothingPoolRegistrationEnabled", boolType, UnitNone, "Whether nodes can join the Smoothing Pool").withSecurityCouncil(nodeNamespace).since(rocketpool.FeatureSmoothingPool),
+	pdao(psettings.NodeSettingsContractName, psettings.NodeDepositEnabledSettingPath, "getDepositEnabled", boolType, UnitNone, "Whether node deposits are enabled").withSecurityCouncil(nodeNamespace),
+	pdao(psettings.NodeSettingsContractName, psettings.VacantMinipoolsEnabledSettingPath, "getVacantMinipoolsEnabled", boolType, UnitNone, "Whether vacant minipools can be created for solo migration").withSecurityCouncil(nodeNamespace).since(rocketpool.FeatureVacantMinipools),
+	pdao(psettings.NodeSettingsContractName, psettings.MinimumPerMinipoolStakeSettingPath, "getMinimumPerMinipoolStake", uintType, UnitFraction, "The minimum RPL stake per minipool as a fraction of borrowed ETH"),
+	pdao(psettings.NodeSettingsContractName, psettings.MaximumPerMinipoolStakeSettingPath, "getMaximumPerMinipoolStake", uintType, UnitFraction, "The maximum RPL stake per minipool as a fraction of bonded ETH"),
+
+	// Proposals
+	pdao(psettings.ProposalsSettingsContractName, psettings.VotePhase1TimeSettingPath, "getVotePhase1Time", uintType, UnitSeconds, "How long the first voting phase lasts").withBounds(seconds(week), seconds(4*week)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.VotePhase2TimeSettingPath, "getVotePhase2Time", uintType, UnitSeconds, "How long the second voting phase lasts").withBounds(seconds(week), seconds(4*week)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.VoteDelayTimeSettingPath, "getVoteDelayTime", uintType, UnitSeconds, "How long after creation voting on a proposal starts").withBounds(seconds(week), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ExecuteTimeSettingPath, "getExecuteTime", uintType, UnitSeconds, "How long a passed proposal can be executed for").withBounds(seconds(week), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ProposalBondSettingPath, "getProposalBond", uintType, UnitRpl, "The RPL locked when creating a proposal").since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ChallengeBondSettingPath, "getChallengeBond", uintType, UnitRpl, "The RPL locked when challenging a proposal").since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ChallengePeriodSettingPath, "getChallengePeriod", uintType, UnitSeconds, "How long a proposer has to respond to a challenge").withBounds(seconds(30*time.Minute), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ProposalQuorumSettingPath, "getProposalQuorum", uintType, UnitFraction, "The fraction of voting power that must vote for a proposal to pass").withBounds(basisPoints(1500), basisPoints(7500)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ProposalVetoQuorumSettingPath, "getProposalVetoQuorum", uintType, UnitFraction, "The fraction of voting power that must veto a proposal to veto it").withBounds(basisPoints(5100), basisPoints(7500)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.ProposalsSettingsContractName, psettings.ProposalMaxBlockAgeSettingPath, "getProposalMaxBlockAge", uintType, UnitBlocks, "The maximum age of the block a proposal's voting power is taken from").withBounds(big.NewInt(129), big.NewInt(7199)).since(rocketpool.FeatureOnChainPDaoVoting),
+
+	// Rewards
+	pdao(psettings.RewardsSettingsContractName, psettings.RewardsClaimIntervalPeriodsSettingPath, "getRewardsClaimIntervalTime", uintType, UnitSeconds, "How long each rewards interval lasts"),
+
+	// Security
+	pdao(psettings.SecuritySettingsContractName, psettings.SecurityMembersQuorumSettingPath, "getQuorum", uintType, UnitFraction, "The fraction of security council members that must vote for a proposal to pass").withBounds(basisPoints(5100), basisPoints(7500)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.SecuritySettingsContractName, psettings.SecurityMembersLeaveTimeSettingPath, "getLeaveTime", uintType, UnitSeconds, "How long a security council member must wait to leave after requesting it").withBounds(seconds(week), seconds(4*week)).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.SecuritySettingsContractName, psettings.SecurityProposalVoteTimeSettingPath, "getVoteTime", uintType, UnitSeconds, "How long security council proposals can be voted on").withBounds(seconds(day), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.SecuritySettingsContractName, psettings.SecurityProposalExecuteTimeSettingPath, "getExecuteTime", uintType, UnitSeconds, "How long a passed security council proposal can be executed for").withBounds(seconds(day), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+	pdao(psettings.SecuritySettingsContractName, psettings.SecurityProposalActionTimeSettingPath, "getActionTime", uintType, UnitSeconds, "How long a security council member has to act on an executed proposal").withBounds(seconds(day), nil).since(rocketpool.FeatureOnChainPDaoVoting),
+
+	// Oracle DAO members
+	odao(tnsettings.MembersSettingsContractName, tnsettings.QuorumSettingPath, "getQuorum", uintType, UnitFraction, "The fraction of members that must vote for a proposal to pass").withBounds(big.NewInt(1), basisPoints(9000)),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.RPLBondSettingPath, "getRPLBond", uintType, UnitRpl, "The RPL bond each member must provide"),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.MinipoolUnbondedMaxSettingPath, "getMinipoolUnbondedMax", uintType, UnitCount, "The maximum number of unbonded minipools a member can run"),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.MinipoolUnbondedMinFeeSettingPath, "getMinipoolUnbondedMinFee", uintType, UnitFraction, "The node commission rate at which members can create unbonded minipools"),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.ChallengeCooldownSettingPath, "getChallengeCooldown", uintType, UnitSeconds, "How long a member must wait between challenges"),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.ChallengeWindowSettingPath, "getChallengeWindow", uintType, UnitSeconds, "How long a challenged member has to respond"),
+	odao(tnsettings.MembersSettingsContractName, tnsettings.ChallengeCostSettingPath, "getChallengeCost", uintType, UnitEth, "The fee for a non-member to challenge a member"),
+
+	// Oracle DAO minipools
+	odao(tnsettings.MinipoolSettingsContractName, tnsettings.ScrubPeriodPath, "getScrubPeriod", uintType, UnitSeconds, "How long the scrub check lasts before a minipool can stake"),
+	odao(tnsettings.MinipoolSettingsContractName, tnsettings.PromotionScrubPeriodPath, "getPromotionScrubPeriod", uintType, UnitSeconds, "How long the scrub check lasts before a vacant minipool can be promoted").since(rocketpool.FeatureVacantMinipools),
+	odao(tnsettings.MinipoolSettingsContractName, tnsettings.ScrubPenaltyEnabledPath, "getScrubPenaltyEnabled", boolType, UnitNone, "Whether scrubbed minipools are penalized"),
+	odao(tnsettings.MinipoolSettingsContractName, tnsettings.BondReductionWindowStartPath, "getBondReductionWindowStart", uintType, UnitSeconds, "How long after starting a bond reduction that it can be finished").since(rocketpool.FeatureBondReduction),
+	odao(tnsettings.MinipoolSettingsContractName, tnsettings.BondReductionWindowLengthPath, "getBondReductionWindowLength", uintType, UnitSeconds, "How long a node has to finish a bond reduction").since(rocketpool.FeatureBondReduction),
+
+	// Oracle DAO proposals
+	odao(tnsettings.ProposalsSettingsContractName, tnsettings.CooldownTimeSettingPath, "getCooldownTime", uintType, UnitSeconds, "How long a member must wait between proposals"),
+	odao(tnsettings.ProposalsSettingsContractName, tnsettings.VoteTimeSettingPath, "getVoteTime", uintType, UnitSeconds, "How long proposals can be voted on"),
+	odao(tnsettings.ProposalsSettingsContractName, tnsettings.VoteDelayTimeSettingPath, "getVoteDelayTime", uintType, UnitSeconds, "How long after creation voting on a proposal starts"),
+	odao(tnsettings.ProposalsSettingsContractName, tnsettings.ExecuteTimeSettingPath, "getExecuteTime", uintType, UnitSeconds, "How long a passed proposal can be executed for"),
+	odao(tnsettings.ProposalsSettingsContractName, tnsettings.ActionTimeSettingPath, "getActionTime", uintType, UnitSeconds, "How long a member has to act on an executed proposal"),
+}
+
+// Get every setting
+func GetSettings() []*Setting {
+	settings := make([]*Setting, len(registry))
+	copy(settings, registry)
+	return settings
+}
+
+// Get the settings that a DAO can change
+func GetSettingsForDao(dao Dao) []*Setting {
+	settings := []*Setting{}
+	for _, setting := range registry {
+		if setting.ChangeableBy(dao) {
+			settings = append(settings, setting)
+		}
+	}
+	return settings
+}
+
+// Get the settings on a contract
+func GetContractSettings(contractName string) []*Setting {
+	settings := []*Setting{}
+	for _, setting := range registry {
+		if setting.ContractName == contractName {
+			settings = append(settings, setting)
+		}
+	}
+	return settings
+}
+
+// Get a setting by contract name and path
+func GetSetting(contractName string, path string) (*Setting, error) {
+	for _, setting := range registry {
+		if setting.ContractName == contractName && setting.Path == path {
+			return setting, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown setting %s on contract %s", path, contractName)
+}
+
+// Get a setting by path alone
+// Some paths are used by more than one contract (e.g. proposal.execute.time), so those must be looked up with GetSetting
+func FindSetting(path string) (*Setting, error) {
+	var found *Setting
+	for _, setting := range registry {
+		if setting.Path != path {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("setting path %s is ambiguous; it's used by both %s and %s", path, found.ContractName, setting.ContractName)
+		}
+		found = setting
+	}
+	if found == nil {
+		return nil, fmt.Errorf("unknown setting %s", path)
+	}
+	return found, nil
+}
@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -159,10 +158,6 @@ func EstimateProposeChallengeCostGas(rp *rocketpool.RocketPool, value *big.Int,
 }
 
 // Get contracts
-var membersSettingsContractLock sync.Mutex
-
 func getMembersSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	membersSettingsContractLock.Lock()
-	defer membersSettingsContractLock.Unlock()
 	return rp.GetContract(MembersSettingsContractName, opts)
 }
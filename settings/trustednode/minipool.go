@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -118,10 +117,6 @@ func EstimateProposeBondReductionWindowLengthGas(rp *rocketpool.RocketPool, valu
 }
 
 // Get contracts
-var minipoolSettingsContractLock sync.Mutex
-
 func getMinipoolSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	minipoolSettingsContractLock.Lock()
-	defer minipoolSettingsContractLock.Unlock()
 	return rp.GetContract(MinipoolSettingsContractName, opts)
 }
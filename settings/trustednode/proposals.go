@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -118,10 +117,6 @@ func EstimateProposeProposalActionTimeGas(rp *rocketpool.RocketPool, value uint6
 }
 
 // Get contracts
-var proposalsSettingsContractLock sync.Mutex
-
 func getProposalsSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	proposalsSettingsContractLock.Lock()
-	defer proposalsSettingsContractLock.Unlock()
 	return rp.GetContract(ProposalsSettingsContractName, opts)
 }
@@ -3,7 +3,6 @@ package trustednode
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
@@ -30,10 +29,6 @@ func GetNetworkEnabled(rp *rocketpool.RocketPool, network *big.Int, opts *bind.C
 }
 
 // Get contracts
-var rewardsSettingsContractLock sync.Mutex
-
 func getRewardsSettingsContract(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rewardsSettingsContractLock.Lock()
-	defer rewardsSettingsContractLock.Unlock()
 	return rp.GetContract(RewardsSettingsContractName, opts)
 }
@@ -0,0 +1,202 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Check that a value can be proposed for the setting
+// Uint256 settings take a *big.Int, bool settings take a bool, and address settings take a common.Address
+func (s *Setting) Validate(value interface{}) error {
+	switch s.Type {
+	case types.ProposalSettingType_Uint256:
+		uintValue, ok := value.(*big.Int)
+		if !ok || uintValue == nil {
+			return fmt.Errorf("setting %s requires a *big.Int value but got %T", s.String(), value)
+		}
+		if uintValue.Sign() < 0 {
+			return fmt.Errorf("setting %s can't be negative", s.String())
+		}
+		if s.Min != nil && uintValue.Cmp(s.Min) < 0 {
+			return fmt.Errorf("setting %s must be at least %s but got %s", s.String(), s.Format(s.Min), s.Format(uintValue))
+		}
+		if s.Max != nil && uintValue.Cmp(s.Max) > 0 {
+			return fmt.Errorf("setting %s must be at most %s but got %s", s.String(), s.Format(s.Max), s.Format(uintValue))
+		}
+		if s.Unit == UnitFraction && uintValue.Cmp(eth.EthToWei(1)) > 0 {
+			return fmt.Errorf("setting %s is a fraction and can't be more than 100%% but got %s", s.String(), s.Format(uintValue))
+		}
+	case types.ProposalSettingType_Bool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("setting %s requires a bool value but got %T", s.String(), value)
+		}
+	case types.ProposalSettingType_Address:
+		if _, ok := value.(common.Address); !ok {
+			return fmt.Errorf("setting %s requires an address value but got %T", s.String(), value)
+		}
+	default:
+		return fmt.Errorf("setting %s has unknown type %d", s.String(), s.Type)
+	}
+	return nil
+}
+
+// Format a value of the setting for display, in its unit
+func (s *Setting) Format(value interface{}) string {
+	switch typedValue := value.(type) {
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case common.Address:
+		return typedValue.Hex()
+	case *big.Int:
+		if typedValue == nil {
+			return "<nil>"
+		}
+		switch s.Unit {
+		case UnitSeconds:
+			if !typedValue.IsInt64() || typedValue.Int64() > int64(maxDurationSeconds) {
+				return fmt.Sprintf("%s seconds", typedValue.String())
+			}
+			return (time.Duration(typedValue.Int64()) * time.Second).String()
+		case UnitFraction:
+			return formatDecimal(typedValue, 16) + "%"
+		case UnitEth:
+			return formatDecimal(typedValue, 18) + " ETH"
+		case UnitRpl:
+			return formatDecimal(typedValue, 18) + " RPL"
+		case UnitBlocks:
+			return typedValue.String() + " blocks"
+		}
+		return typedValue.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// The longest duration a time.Duration can hold, in seconds
+const maxDurationSeconds = time.Duration(1<<63-1) / time.Second
+
+// Parse a value of the setting from a string, such as user input
+// Durations can be given as a number of seconds or a Go duration (e.g. 72h), fractions as a decimal or a percentage (e.g. 0.05 or 5%),
+// and ETH and RPL amounts in whole tokens; the parsed value isn't validated against the setting's bounds
+func (s *Setting) Parse(value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch s.Type {
+	case types.ProposalSettingType_Bool:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s as a bool for setting %s: %w", value, s.String(), err)
+		}
+		return boolValue, nil
+	case types.ProposalSettingType_Address:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("%s is not a valid address for setting %s", value, s.String())
+		}
+		return common.HexToAddress(value), nil
+	case types.ProposalSettingType_Uint256:
+		uintValue, err := s.parseUint(value)
+		if err != nil {
+			return nil, err
+		}
+		return uintValue, nil
+	}
+	return nil, fmt.Errorf("setting %s has unknown type %d", s.String(), s.Type)
+}
+
+// Parse a uint256 value of the setting in its unit
+func (s *Setting) parseUint(value string) (*big.Int, error) {
+	// Raw integers are always allowed, except for token amounts and fractions which are given in whole units
+	if s.Unit != UnitFraction && s.Unit != UnitEth && s.Unit != UnitRpl {
+		if intValue, ok := new(big.Int).SetString(value, 10); ok {
+			return intValue, nil
+		}
+	}
+
+	switch s.Unit {
+	case UnitSeconds:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s as a duration for setting %s: %w", value, s.String(), err)
+		}
+		return seconds(duration), nil
+	case UnitFraction:
+		decimals := 18
+		if strings.HasSuffix(value, "%") {
+			value = strings.TrimSpace(strings.TrimSuffix(value, "%"))
+			decimals = 16
+		}
+		fraction, err := parseDecimal(value, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s as a fraction for setting %s: %w", value, s.String(), err)
+		}
+		return fraction, nil
+	case UnitEth, UnitRpl:
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(value, " ETH"), " RPL"))
+		amount, err := parseDecimal(value, 18)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s as a token amount for setting %s: %w", value, s.String(), err)
+		}
+		return amount, nil
+	}
+	return nil, fmt.Errorf("error parsing %s as an integer for setting %s", value, s.String())
+}
+
+// Parse a decimal string into an integer with the given number of decimal places, without losing precision
+func parseDecimal(value string, decimals int) (*big.Int, error) {
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a decimal number", value)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !rat.IsInt() {
+		return nil, fmt.Errorf("%s has more than %d decimal places", value, decimals)
+	}
+	return rat.Num(), nil
+}
+
+// Format an integer with the given number of decimal places as a decimal string, without trailing zeros
+func formatDecimal(value *big.Int, decimals int) string {
+	rat := new(big.Rat).SetFrac(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	formatted := rat.FloatString(decimals)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	return formatted
+}
+
+// Get the setting's current value from its contract
+func (s *Setting) GetValue(rp *rocketpool.RocketPool, opts *bind.CallOpts) (interface{}, error) {
+	contract, err := rp.GetContract(s.ContractName, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch s.Type {
+	case types.ProposalSettingType_Uint256:
+		value := new(*big.Int)
+		if err := contract.Call(opts, value, s.Getter); err != nil {
+			return nil, fmt.Errorf("error getting setting %s: %w", s.String(), err)
+		}
+		return *value, nil
+	case types.ProposalSettingType_Bool:
+		value := new(bool)
+		if err := contract.Call(opts, value, s.Getter); err != nil {
+			return nil, fmt.Errorf("error getting setting %s: %w", s.String(), err)
+		}
+		return *value, nil
+	case types.ProposalSettingType_Address:
+		value := new(common.Address)
+		if err := contract.Call(opts, value, s.Getter); err != nil {
+			return nil, fmt.Errorf("error getting setting %s: %w", s.String(), err)
+		}
+		return *value, nil
+	}
+	return nil, fmt.Errorf("setting %s has unknown type %d", s.String(), s.Type)
+}
@@ -0,0 +1,81 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// A signer backed by a raw private key held in memory
+type PrivateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// Create a new private key signer
+func NewPrivateKeySigner(privateKey *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Create a new private key signer from a hex-encoded private key
+func NewPrivateKeySignerFromHex(privateKeyHex string) (*PrivateKeySigner, error) {
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %w", err)
+	}
+	return NewPrivateKeySigner(privateKey), nil
+}
+
+// Get the signer's address
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// Sign a transaction
+func (s *PrivateKeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
+
+// A signer backed by an account in a local go-ethereum keystore
+type KeystoreSigner struct {
+	keystore   *keystore.KeyStore
+	account    accounts.Account
+	passphrase string
+}
+
+// Create a new keystore signer for the account with the given address
+func NewKeystoreSigner(ks *keystore.KeyStore, address common.Address, passphrase string) (*KeystoreSigner, error) {
+	account, err := ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("error finding account %s in keystore: %w", address.Hex(), err)
+	}
+	return &KeystoreSigner{
+		keystore:   ks,
+		account:    account,
+		passphrase: passphrase,
+	}, nil
+}
+
+// Get the signer's address
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+// Sign a transaction
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signedTx, err := s.keystore.SignTxWithPassphrase(s.account, s.passphrase, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction with keystore account %s: %w", s.account.Address.Hex(), err)
+	}
+	return signedTx, nil
+}
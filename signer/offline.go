@@ -0,0 +1,319 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Settings
+const (
+	SafeBatchVersion     string = "1.0"
+	SafeTxBuilderVersion string = "1.16.3"
+)
+
+// A transaction that was built by a write function but not signed or sent
+type UnsignedTransaction struct {
+	ChainID              *big.Int         `json:"chainId"`
+	From                 common.Address   `json:"from"`
+	To                   *common.Address  `json:"to"`
+	Data                 hexutil.Bytes    `json:"data"`
+	Value                *big.Int         `json:"value"`
+	Nonce                uint64           `json:"nonce"`
+	GasLimit             uint64           `json:"gasLimit"`
+	MaxFeePerGas         *big.Int         `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *big.Int         `json:"maxPriorityFeePerGas,omitempty"`
+	GasPrice             *big.Int         `json:"gasPrice,omitempty"`
+	AccessList           types.AccessList `json:"accessList,omitempty"`
+	tx                   *types.Transaction
+}
+
+// A signer that captures transactions instead of signing them, for air-gapped wallets and multisigs
+// Run any write function with its transactor and the fully-built transactions are collected rather than sent
+type UnsignedTxCollector struct {
+	address      common.Address
+	chainID      *big.Int
+	transactions []UnsignedTransaction
+	nextNonce    *uint64
+	lock         sync.Mutex
+}
+
+// Create a new collector for transactions that will be sent from the given address
+func NewUnsignedTxCollector(address common.Address, chainID *big.Int) *UnsignedTxCollector {
+	return &UnsignedTxCollector{
+		address:      address,
+		chainID:      chainID,
+		transactions: []UnsignedTransaction{},
+	}
+}
+
+// Get the address transactions are built for
+func (c *UnsignedTxCollector) Address() common.Address {
+	return c.address
+}
+
+// Record the transaction and return it without a signature
+// None of the collected transactions are sent, so each one after the first takes the nonce after the last collected one
+func (c *UnsignedTxCollector) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	unsignedTx := UnsignedTransaction{
+		ChainID:    chainID,
+		From:       c.address,
+		To:         tx.To(),
+		Data:       tx.Data(),
+		Value:      tx.Value(),
+		Nonce:      tx.Nonce(),
+		GasLimit:   tx.Gas(),
+		AccessList: tx.AccessList(),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		unsignedTx.MaxFeePerGas = tx.GasFeeCap()
+		unsignedTx.MaxPriorityFeePerGas = tx.GasTipCap()
+	} else {
+		unsignedTx.GasPrice = tx.GasPrice()
+	}
+
+	// Rebuild the transaction if its nonce was already used by a collected one
+	if c.nextNonce != nil && unsignedTx.Nonce < *c.nextNonce {
+		unsignedTx.Nonce = *c.nextNonce
+		tx = unsignedTx.Transaction()
+	}
+	unsignedTx.tx = tx
+	nextNonce := unsignedTx.Nonce + 1
+	c.nextNonce = &nextNonce
+
+	c.transactions = append(c.transactions, unsignedTx)
+	return tx, nil
+}
+
+// Get a transactor that builds transactions into this collector without sending them
+func (c *UnsignedTxCollector) GetTransactor() (*bind.TransactOpts, error) {
+	opts, err := NewTransactor(c, c.chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.NoSend = true
+	return opts, nil
+}
+
+// Get the transactions collected so far
+func (c *UnsignedTxCollector) Transactions() []UnsignedTransaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	transactions := make([]UnsignedTransaction, len(c.transactions))
+	copy(transactions, c.transactions)
+	return transactions
+}
+
+// Clear the collected transactions
+func (c *UnsignedTxCollector) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.transactions = []UnsignedTransaction{}
+	c.nextNonce = nil
+}
+
+// Get the transaction in go-ethereum form
+func (u UnsignedTransaction) Transaction() *types.Transaction {
+	if u.tx != nil {
+		return u.tx
+	}
+	if u.MaxFeePerGas != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    u.ChainID,
+			Nonce:      u.Nonce,
+			GasTipCap:  u.MaxPriorityFeePerGas,
+			GasFeeCap:  u.MaxFeePerGas,
+			Gas:        u.GasLimit,
+			To:         u.To,
+			Value:      u.Value,
+			Data:       u.Data,
+			AccessList: u.AccessList,
+		})
+	}
+	if len(u.AccessList) > 0 {
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    u.ChainID,
+			Nonce:      u.Nonce,
+			GasPrice:   u.GasPrice,
+			Gas:        u.GasLimit,
+			To:         u.To,
+			Value:      u.Value,
+			Data:       u.Data,
+			AccessList: u.AccessList,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    u.Nonce,
+		GasPrice: u.GasPrice,
+		Gas:      u.GasLimit,
+		To:       u.To,
+		Value:    u.Value,
+		Data:     u.Data,
+	})
+}
+
+// Get the raw RLP-encoded signing payload of the transaction
+// This is the exact preimage an offline wallet hashes and signs for the transaction's chain
+func (u UnsignedTransaction) RLP() ([]byte, error) {
+	tx := u.Transaction()
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		payload, err := rlp.EncodeToBytes([]interface{}{
+			u.ChainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding transaction: %w", err)
+		}
+		return append([]byte{types.DynamicFeeTxType}, payload...), nil
+
+	case types.AccessListTxType:
+		payload, err := rlp.EncodeToBytes([]interface{}{
+			u.ChainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding transaction: %w", err)
+		}
+		return append([]byte{types.AccessListTxType}, payload...), nil
+
+	case types.LegacyTxType:
+		payload, err := rlp.EncodeToBytes([]interface{}{
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			u.ChainID, uint(0), uint(0),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding transaction: %w", err)
+		}
+		return payload, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+}
+
+// Check that a signed transaction matches this one and was signed by its sender
+func (u UnsignedTransaction) VerifySigned(signedTx *types.Transaction) error {
+	if !transactionsMatch(u.Transaction(), signedTx, u.ChainID) {
+		return fmt.Errorf("signed transaction does not match the unsigned transaction")
+	}
+	return verifySender(signedTx, u.ChainID, u.From)
+}
+
+// A Safe Transaction Builder batch file
+type SafeBatch struct {
+	Version      string                 `json:"version"`
+	ChainID      string                 `json:"chainId"`
+	CreatedAt    int64                  `json:"createdAt"`
+	Meta         SafeBatchMeta          `json:"meta"`
+	Transactions []SafeBatchTransaction `json:"transactions"`
+}
+type SafeBatchMeta struct {
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	TxBuilderVersion        string `json:"txBuilderVersion"`
+	CreatedFromSafeAddress  string `json:"createdFromSafeAddress"`
+	CreatedFromOwnerAddress string `json:"createdFromOwnerAddress"`
+}
+type SafeBatchTransaction struct {
+	To                   string             `json:"to"`
+	Value                string             `json:"value"`
+	Data                 string             `json:"data"`
+	ContractMethod       *json.RawMessage   `json:"contractMethod"`
+	ContractInputsValues *map[string]string `json:"contractInputsValues"`
+}
+
+// Export transactions as a Safe Transaction Builder batch that the Safe at the given address can import and execute
+func CreateSafeBatch(name string, description string, safeAddress common.Address, chainID *big.Int, transactions []UnsignedTransaction) (SafeBatch, error) {
+	batch := SafeBatch{
+		Version:   SafeBatchVersion,
+		ChainID:   chainID.String(),
+		CreatedAt: time.Now().UnixMilli(),
+		Meta: SafeBatchMeta{
+			Name:                   name,
+			Description:            description,
+			TxBuilderVersion:       SafeTxBuilderVersion,
+			CreatedFromSafeAddress: safeAddress.Hex(),
+		},
+		Transactions: make([]SafeBatchTransaction, len(transactions)),
+	}
+	for i, tx := range transactions {
+		if tx.To == nil {
+			return SafeBatch{}, fmt.Errorf("transaction %d is a contract creation, which Safe batches do not support", i)
+		}
+		if tx.From != safeAddress {
+			return SafeBatch{}, fmt.Errorf("transaction %d was built for %s instead of the Safe at %s", i, tx.From.Hex(), safeAddress.Hex())
+		}
+		value := big.NewInt(0)
+		if tx.Value != nil {
+			value = tx.Value
+		}
+		batch.Transactions[i] = SafeBatchTransaction{
+			To:    tx.To.Hex(),
+			Value: value.String(),
+			Data:  hexutil.Encode(tx.Data),
+		}
+	}
+	return batch, nil
+}
+
+// Decode a raw signed transaction returned by an offline wallet
+func DecodeSignedTransaction(rawTx []byte) (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	v, r, s := tx.RawSignatureValues()
+	if v.Sign() == 0 && r.Sign() == 0 && s.Sign() == 0 {
+		return nil, fmt.Errorf("transaction is not signed")
+	}
+	return tx, nil
+}
+
+// Decode a hex-encoded raw signed transaction returned by an offline wallet
+func DecodeSignedTransactionHex(rawTxHex string) (*types.Transaction, error) {
+	rawTx, err := hexutil.Decode(rawTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction hex: %w", err)
+	}
+	return DecodeSignedTransaction(rawTx)
+}
+
+// Broadcast a signed transaction to the network
+func SendSignedTransaction(client rocketpool.ExecutionClient, signedTx *types.Transaction) (common.Hash, error) {
+	if err := client.SendTransaction(context.Background(), signedTx); err != nil {
+		return common.Hash{}, fmt.Errorf("error sending signed transaction: %w", err)
+	}
+	return signedTx.Hash(), nil
+}
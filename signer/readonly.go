@@ -0,0 +1,32 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A signer that only knows its address, used for watch-only nodes
+// Gas estimation still works with it, but any attempt to sign a transaction is refused
+type ReadOnlySigner struct {
+	address common.Address
+}
+
+// Create a new read-only signer
+func NewReadOnlySigner(address common.Address) *ReadOnlySigner {
+	return &ReadOnlySigner{
+		address: address,
+	}
+}
+
+// Get the signer's address
+func (s *ReadOnlySigner) Address() common.Address {
+	return s.address
+}
+
+// Refuse to sign the transaction
+func (s *ReadOnlySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, ErrReadOnly
+}
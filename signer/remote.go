@@ -0,0 +1,151 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The JSON-RPC method used by Web3Signer (and compatible signers) to sign transactions
+const remoteSignTransactionMethod string = "eth_signTransaction"
+
+// Transaction arguments for eth_signTransaction
+type remoteTransactionArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value"`
+	Data                 hexutil.Bytes     `json:"data"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+// A signer that delegates signing to a remote host speaking the Web3Signer eth_signTransaction API
+// The key never leaves the signing host; only the transaction and its signed result travel over the wire
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// Create a new remote signer for the given address using the signer at the provided URL
+func NewRemoteSigner(url string, address common.Address) (*RemoteSigner, error) {
+	return NewRemoteSignerWithHttpClient(url, address, http.DefaultClient)
+}
+
+// Create a new remote signer with a custom HTTP client (e.g. one configured for TLS client authentication)
+func NewRemoteSignerWithHttpClient(url string, address common.Address, httpClient *http.Client) (*RemoteSigner, error) {
+	client, err := rpc.DialHTTPWithClient(url, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to remote signer at %s: %w", url, err)
+	}
+	return &RemoteSigner{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+// Get the signer's address
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// Sign a transaction on the remote host
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Build the request
+	args := remoteTransactionArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Data:    tx.Data(),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+	if tx.Type() != types.LegacyTxType {
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	}
+
+	// Sign it
+	var rawTx hexutil.Bytes
+	if err := s.client.CallContext(ctx, &rawTx, remoteSignTransactionMethod, args); err != nil {
+		return nil, fmt.Errorf("error signing transaction with remote signer: %w", err)
+	}
+
+	// Decode the signed transaction
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction from remote signer: %w", err)
+	}
+
+	// Make sure the signer didn't change anything or sign it with a different key
+	if !transactionsMatch(tx, signedTx, chainID) {
+		return nil, fmt.Errorf("remote signer returned a transaction that does not match the request")
+	}
+	if err := verifySender(signedTx, chainID, s.address); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+
+}
+
+// Close the connection to the remote signer
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// Check if the signed transaction carries the same payload and fees as the one that was requested, for the given chain
+func transactionsMatch(requested *types.Transaction, signed *types.Transaction, chainID *big.Int) bool {
+	if requested.Type() != signed.Type() ||
+		requested.Nonce() != signed.Nonce() ||
+		requested.Gas() != signed.Gas() ||
+		requested.GasPrice().Cmp(signed.GasPrice()) != 0 ||
+		requested.GasFeeCap().Cmp(signed.GasFeeCap()) != 0 ||
+		requested.GasTipCap().Cmp(signed.GasTipCap()) != 0 ||
+		requested.Value().Cmp(signed.Value()) != 0 ||
+		signed.ChainId().Cmp(chainID) != 0 ||
+		!bytes.Equal(requested.Data(), signed.Data()) ||
+		!accessListsMatch(requested.AccessList(), signed.AccessList()) {
+		return false
+	}
+	if requested.To() == nil || signed.To() == nil {
+		return requested.To() == signed.To()
+	}
+	return *requested.To() == *signed.To()
+}
+
+// Check if two access lists cover the same addresses and storage keys, in the same order
+func accessListsMatch(requested types.AccessList, signed types.AccessList) bool {
+	if len(requested) != len(signed) {
+		return false
+	}
+	for i, tuple := range requested {
+		if tuple.Address != signed[i].Address || len(tuple.StorageKeys) != len(signed[i].StorageKeys) {
+			return false
+		}
+		for j, key := range tuple.StorageKeys {
+			if key != signed[i].StorageKeys[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
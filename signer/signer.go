@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Errors
+var (
+	ErrReadOnly        = errors.New("signer is read-only and cannot sign transactions")
+	ErrAddressMismatch = errors.New("transaction sender does not match the signer's address")
+)
+
+// This is the common interface for anything that can sign transactions on behalf of a single address.
+// It decouples the transaction path from the location of the key (in-process, keystore, or a remote signing host).
+type Signer interface {
+	// The address that transactions will be signed for
+	Address() common.Address
+
+	// Sign the transaction for the given chain and return the signed copy
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Create a transactor for the given signer, which can be passed to any write function in the library
+// Signing uses the transactor's Context, so replacing it (e.g. with a timeout) also bounds remote signing requests
+func NewTransactor(signer Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	address := signer.Address()
+	opts := &bind.TransactOpts{
+		From:    address,
+		Context: context.Background(),
+	}
+	opts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != address {
+			return nil, bind.ErrNotAuthorized
+		}
+		ctx := opts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return signer.SignTx(ctx, tx, chainID)
+	}
+	return opts, nil
+}
+
+// Check that a signed transaction was signed by the expected address for the given chain
+func verifySender(tx *types.Transaction, chainID *big.Int, expected common.Address) error {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return fmt.Errorf("error recovering transaction sender: %w", err)
+	}
+	if sender != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrAddressMismatch, expected.Hex(), sender.Hex())
+	}
+	return nil
+}
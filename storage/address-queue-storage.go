@@ -51,11 +51,12 @@ func GetAddressQueueIndexOf(rp *rocketpool.RocketPool, opts *bind.CallOpts, key
 	return (*index).Int64(), nil
 }
 
-// Get contracts
+// Lock for loading the address queue storage contract
+//
+// Deprecated: contract loading is synchronised by the RocketPool contract registry, so this lock is no longer used
 var AddressQueueStorageLock sync.Mutex
 
+// Get contracts
 func getAddressQueueStorage(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	AddressQueueStorageLock.Lock()
-	defer AddressQueueStorageLock.Unlock()
 	return rp.GetContract("addressQueueStorage", opts)
 }
@@ -0,0 +1,186 @@
+package accesslist
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/signer"
+
+	"github.com/rocket-pool/rocketpool-go/tests"
+)
+
+const testAbi string = `[{"type":"function","name":"distributeBalance","stateMutability":"nonpayable","inputs":[],"outputs":[]}]`
+
+// Execution client stand-in with fixed gas estimates and no access list support
+type plainClient struct {
+	rocketpool.ExecutionClient
+	gas         uint64
+	gasWithList uint64
+	sent        []*types.Transaction
+}
+
+// Execution client stand-in that creates a fixed access list
+type accessListClient struct {
+	plainClient
+	accessList types.AccessList
+}
+
+func (c *accessListClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	return &c.accessList, c.gasWithList, "", nil
+}
+
+func (c *plainClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	if len(call.AccessList) > 0 {
+		return c.gasWithList, nil
+	}
+	return c.gas, nil
+}
+func (c *plainClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 7, nil
+}
+func (c *plainClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+func (c *plainClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(10e9)}, nil
+}
+func (c *plainClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func newTestContract(t *testing.T, client rocketpool.ExecutionClient) *rocketpool.Contract {
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	return &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsedAbi, client, client, client),
+		Address:  &address,
+		ABI:      &parsedAbi,
+		Client:   client,
+	}
+}
+
+func newTestTransactor(t *testing.T) *bind.TransactOpts {
+	keySigner, err := signer.NewPrivateKeySignerFromHex(tests.AccountPrivateKeys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := signer.NewTransactor(keySigner, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+func TestAccessListAttached(t *testing.T) {
+
+	// The access list saves gas
+	client := &accessListClient{
+		plainClient: plainClient{gas: 100000, gasWithList: 90000},
+		accessList: types.AccessList{{
+			Address:     common.HexToAddress("0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"),
+			StorageKeys: []common.Hash{common.HexToHash("0x01")},
+		}},
+	}
+	contract := newTestContract(t, client)
+	opts, err := rocketpool.NewAccessListTransactor(client, newTestTransactor(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gasInfo, err := contract.GetTransactionAccessListGasInfo(opts, "distributeBalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gasInfo.UseAccessList || gasInfo.SafeGasLimitWithList != 135000 {
+		t.Errorf("Incorrect access list gas info %+v", gasInfo)
+	}
+
+	// Send a transaction
+	tx, err := contract.Transact(opts, "distributeBalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.sent) != 1 || client.sent[0].Hash() != tx.Hash() {
+		t.Fatal("Transaction was not sent")
+	}
+	if tx.Type() != types.DynamicFeeTxType || len(tx.AccessList()) != 1 {
+		t.Errorf("Access list was not attached to the transaction")
+	}
+	if tx.Gas() != 135000 || tx.Nonce() != 7 || tx.GasFeeCap().Cmp(big.NewInt(21e9)) != 0 {
+		t.Errorf("Incorrect transaction parameters: gas %d, nonce %d, max fee %s", tx.Gas(), tx.Nonce(), tx.GasFeeCap())
+	}
+
+	// Transactions that are only built aren't sent
+	opts.NoSend = true
+	if _, err := contract.Transact(opts, "distributeBalance"); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.sent) != 1 {
+		t.Error("Transaction was sent with NoSend set")
+	}
+
+}
+
+func TestAccessListSkipped(t *testing.T) {
+
+	// The access list costs more than it saves
+	client := &accessListClient{
+		plainClient: plainClient{gas: 100000, gasWithList: 102400},
+		accessList: types.AccessList{{
+			Address: common.HexToAddress("0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"),
+		}},
+	}
+	contract := newTestContract(t, client)
+	opts, err := rocketpool.NewAccessListTransactor(client, newTestTransactor(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gasInfo, err := contract.GetTransactionAccessListGasInfo(opts, "distributeBalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasInfo.UseAccessList {
+		t.Error("Access list was used even though it does not save gas")
+	}
+
+}
+
+func TestAccessListUnsupported(t *testing.T) {
+
+	// The client can't create access lists
+	client := &plainClient{gas: 100000}
+	contract := newTestContract(t, client)
+	if _, err := rocketpool.NewAccessListTransactor(client, newTestTransactor(t)); err == nil {
+		t.Error("Access list transactor was created for a client without access list support")
+	}
+
+	// Transactors created for another client can't send through it
+	opts, err := rocketpool.NewAccessListTransactor(&accessListClient{}, newTestTransactor(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := contract.GetTransactionAccessListGasInfo(opts, "distributeBalance"); err == nil {
+		t.Error("Access list gas info was returned by a client without access list support")
+	}
+	if _, err := contract.Transact(opts, "distributeBalance"); err == nil {
+		t.Error("Transaction was sent without the requested access list")
+	}
+	if len(client.sent) != 0 {
+		t.Errorf("%d transactions were sent", len(client.sent))
+	}
+
+}
@@ -0,0 +1,265 @@
+package addresshistory
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/contracts"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+const testAbi string = `[{"type":"function","name":"version","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}]`
+
+var (
+	storageAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	firstAddress   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	secondAddress  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+// An address change in the fake storage contract
+type addressChange struct {
+	block   uint64
+	address common.Address
+}
+
+// Execution client stand-in that serves RocketStorage reads from an address schedule
+type storageClient struct {
+	rocketpool.ExecutionClient
+	t           *testing.T
+	storageAbi  abi.ABI
+	encodedAbi  string
+	deployBlock uint64
+	latestBlock uint64
+	changes     []addressChange
+	queries     []ethereum.FilterQuery
+	archiveUsed bool
+}
+
+func newStorageClient(t *testing.T, deployBlock uint64, latestBlock uint64, changes []addressChange) *storageClient {
+	storageAbi, err := abi.JSON(strings.NewReader(contracts.RocketStorageABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedAbi, err := rocketpool.EncodeAbiStr(testAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &storageClient{
+		t:           t,
+		storageAbi:  storageAbi,
+		encodedAbi:  encodedAbi,
+		deployBlock: deployBlock,
+		latestBlock: latestBlock,
+		changes:     changes,
+	}
+}
+
+func (c *storageClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.latestBlock, nil
+}
+
+func (c *storageClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := c.storageAbi.MethodById(call.Data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	block := c.latestBlock
+	if blockNumber != nil {
+		block = blockNumber.Uint64()
+		if block != c.latestBlock {
+			c.archiveUsed = true
+		}
+	}
+	switch method.Name {
+	case "getAddress":
+		address := common.Address{}
+		for _, change := range c.changes {
+			if change.block <= block {
+				address = change.address
+			}
+		}
+		return method.Outputs.Pack(address)
+	case "getString":
+		return method.Outputs.Pack(c.encodedAbi)
+	case "getUint":
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		if args[0].([32]byte) != crypto.Keccak256Hash([]byte("deploy.block")) {
+			c.t.Fatalf("unexpected getUint key %x", args[0])
+		}
+		return method.Outputs.Pack(new(big.Int).SetUint64(c.deployBlock))
+	}
+	c.t.Fatalf("unexpected storage call %s", method.Name)
+	return nil, nil
+}
+
+// Serves a ContractUpgraded log from the first address for upgrade queries, and nothing for anything else
+func (c *storageClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.queries = append(c.queries, query)
+	if len(query.Topics) == 2 {
+		return []types.Log{{Topics: []common.Hash{{}, query.Topics[1][0], common.BytesToHash(firstAddress.Bytes())}}}, nil
+	}
+	return []types.Log{}, nil
+}
+
+func TestHistoryFindsUpgrades(t *testing.T) {
+
+	client := newStorageClient(t, 100, 1000, []addressChange{
+		{block: 100, address: firstAddress},
+		{block: 537, address: secondAddress},
+	})
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Resolve the history
+	history, err := rp.AddressHistory.GetHistory("rocketNetworkPrices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Deployments) != 2 {
+		t.Fatalf("Incorrect deployment count: expected 2, got %d", len(history.Deployments))
+	}
+	first := history.Deployments[0]
+	if first.Address != firstAddress || first.StartBlock != 100 || first.EndBlock != 536 || first.IsCurrent {
+		t.Errorf("Incorrect first deployment: %+v", first)
+	}
+	second := history.Deployments[1]
+	if second.Address != secondAddress || second.StartBlock != 537 || second.EndBlock != 1000 || !second.IsCurrent {
+		t.Errorf("Incorrect second deployment: %+v", second)
+	}
+	if second.ABI == nil || len(second.ABI.Methods) != 1 {
+		t.Error("Deployment ABI was not loaded")
+	}
+
+	// Look up deployments
+	if deployment, ok := history.GetDeploymentAtBlock(536); !ok || deployment.Address != firstAddress {
+		t.Errorf("Incorrect deployment at block 536: %+v", deployment)
+	}
+	if deployment, ok := history.GetDeploymentAtBlock(537); !ok || deployment.Address != secondAddress {
+		t.Errorf("Incorrect deployment at block 537: %+v", deployment)
+	}
+	if _, ok := history.GetDeploymentAtBlock(50); ok {
+		t.Error("Found a deployment before the deploy block")
+	}
+	if deployments := history.GetDeploymentsInRange(200, 300); len(deployments) != 1 || deployments[0].Address != firstAddress {
+		t.Errorf("Incorrect deployments in range 200-300: %+v", deployments)
+	}
+	if deployments := history.GetDeploymentsInRange(500, 600); len(deployments) != 2 {
+		t.Errorf("Incorrect deployment count in range 500-600: %d", len(deployments))
+	}
+
+}
+
+func TestHistoryAtEarlierBlock(t *testing.T) {
+
+	client := newStorageClient(t, 100, 1000, []addressChange{
+		{block: 100, address: firstAddress},
+		{block: 537, address: secondAddress},
+	})
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rp.AddressHistory.GetHistory("rocketNetworkPrices"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cached history is truncated to the requested block
+	history, err := rp.AddressHistory.GetHistoryAtBlock("rocketNetworkPrices", 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Deployments) != 1 {
+		t.Fatalf("Incorrect deployment count: expected 1, got %d", len(history.Deployments))
+	}
+	if deployment := history.Deployments[0]; deployment.EndBlock != 400 || !deployment.IsCurrent {
+		t.Errorf("Incorrect truncated deployment: %+v", deployment)
+	}
+
+}
+
+func TestHistoryOfLateContract(t *testing.T) {
+
+	// Contracts added by an upgrade have no address at the deploy block
+	client := newStorageClient(t, 100, 1000, []addressChange{
+		{block: 800, address: secondAddress},
+	})
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addresses, err := rp.AddressHistory.GetAddresses("rocketDAOProtocolProposal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 1 || addresses[0] != secondAddress {
+		t.Errorf("Incorrect addresses: %v", addresses)
+	}
+
+}
+
+func TestFilterContractLogsFromUpgrades(t *testing.T) {
+
+	client := newStorageClient(t, 100, 1000, []addressChange{
+		{block: 100, address: firstAddress},
+		{block: 537, address: secondAddress},
+	})
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The resolver is disabled by default, so only the latest state is read
+	if rp.AddressHistory.IsEnabled() {
+		t.Fatal("Address history resolver is enabled by default")
+	}
+	if _, err := eth.FilterContractLogs(rp, "rocketNetworkPrices", eth.FilterQuery{}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if client.archiveUsed {
+		t.Error("Historical state was read without the resolver enabled")
+	}
+	query := client.queries[len(client.queries)-1]
+	if len(query.Addresses) != 2 || query.Addresses[0] != firstAddress || query.Addresses[1] != secondAddress {
+		t.Errorf("Incorrect log addresses: %v", query.Addresses)
+	}
+
+}
+
+func TestFilterContractLogsFromHistory(t *testing.T) {
+
+	client := newStorageClient(t, 100, 1000, []addressChange{
+		{block: 100, address: firstAddress},
+		{block: 537, address: secondAddress},
+	})
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp.AddressHistory.SetEnabled(true)
+
+	// Only the deployments live during the range are queried
+	if _, err := eth.FilterContractLogs(rp, "rocketNetworkPrices", eth.FilterQuery{FromBlock: big.NewInt(600)}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.queries) != 1 {
+		t.Fatalf("Incorrect query count: expected 1, got %d", len(client.queries))
+	}
+	if addresses := client.queries[0].Addresses; len(addresses) != 1 || addresses[0] != secondAddress {
+		t.Errorf("Incorrect log addresses: %v", addresses)
+	}
+
+}
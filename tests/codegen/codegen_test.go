@@ -0,0 +1,128 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/rocket-pool/rocketpool-go/codegen"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+const testAbi string = `[
+	{"type":"function","name":"getNodeCount","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getNodeDetails","stateMutability":"view","inputs":[{"name":"_nodeAddress","type":"address"}],"outputs":[{"name":"exists","type":"bool"},{"name":"timezone","type":"string"}]},
+	{"type":"function","name":"getUnnamed","stateMutability":"view","inputs":[{"name":"type","type":"uint8"}],"outputs":[{"name":"","type":"bytes32"},{"name":"","type":"address[]"}]},
+	{"type":"function","name":"getMinipool","stateMutability":"view","inputs":[{"name":"","type":"uint256"}],"outputs":[{"name":"","type":"tuple","components":[{"name":"minipoolAddress","type":"address"},{"name":"balance","type":"uint256"}]}]},
+	{"type":"function","name":"deposit","stateMutability":"payable","inputs":[{"name":"_bondAmount","type":"uint256"},{"name":"_validatorPubkey","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"setTimezone","stateMutability":"nonpayable","inputs":[{"name":"opts","type":"string"}],"outputs":[]}
+]`
+
+// Get the names of the methods and types declared in a generated wrapper
+func getDeclarations(t *testing.T, source []byte) map[string]bool {
+	file, err := parser.ParseFile(token.NewFileSet(), "wrapper.go", source, 0)
+	if err != nil {
+		t.Fatalf("Error parsing generated wrapper: %s\n%s", err.Error(), source)
+	}
+	declarations := map[string]bool{}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			declarations[decl.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					declarations[typeSpec.Name.Name] = true
+				}
+			}
+		}
+	}
+	return declarations
+}
+
+func TestGenerateWrapper(t *testing.T) {
+
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := codegen.GenerateWrapper("bindings", codegen.ContractAbi{Name: "rocketTestManager", ABI: &parsedAbi})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(source), "// Code generated") {
+		t.Error("Generated wrapper is missing its generated code header")
+	}
+
+	// Check the generated declarations
+	declarations := getDeclarations(t, source)
+	for _, name := range []string{
+		"RocketTestManager", "NewRocketTestManager", "NewRocketTestManagerFromContract",
+		"GetNodeCount", "AddGetNodeCount",
+		"GetNodeDetails", "AddGetNodeDetails", "RocketTestManagerGetNodeDetailsOutput",
+		"GetUnnamed",
+		"GetMinipool", "AddGetMinipool",
+		"Deposit", "EstimateDepositGas",
+		"SetTimezone", "EstimateSetTimezoneGas",
+	} {
+		if !declarations[name] {
+			t.Errorf("%s was not generated", name)
+		}
+	}
+
+	// Outputs without names can't be unpacked by multicall
+	if declarations["AddGetUnnamed"] {
+		t.Error("Multicall method was generated for unnamed outputs")
+	}
+
+}
+
+func TestGeneratedWrappersBuild(t *testing.T) {
+
+	// Generate wrappers for the test ABI and every embedded legacy ABI
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractAbis := []codegen.ContractAbi{{Name: "rocketTestManager", ABI: &parsedAbi}}
+	versions, err := rocketpool.GetLegacyABIVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rpVersion := range versions {
+		contractNames, err := rocketpool.GetLegacyABIContracts(rpVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, contractName := range contractNames {
+			contractAbi, err := rocketpool.GetLegacyABI(rpVersion, contractName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			contractAbis = append(contractAbis, codegen.ContractAbi{Name: contractName, ABI: contractAbi})
+		}
+
+		// The output package needs to be inside the module so it can import it
+		dir, err := os.MkdirTemp(".", "bindings")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		if _, err := codegen.WriteWrappers(dir, "bindings", contractAbis); err != nil {
+			t.Fatal(err)
+		}
+		output, err := exec.Command("go", "vet", "./"+filepath.Base(dir)).CombinedOutput()
+		if err != nil {
+			t.Fatalf("Generated v%s wrappers don't build: %s\n%s", rpVersion, err.Error(), output)
+		}
+		contractAbis = contractAbis[:1]
+	}
+
+}
@@ -0,0 +1,105 @@
+package delegation
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol/delegation"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+var (
+	nodeA = common.HexToAddress("0x0a")
+	nodeB = common.HexToAddress("0x0b")
+	nodeC = common.HexToAddress("0x0c")
+	nodeD = common.HexToAddress("0x0d")
+)
+
+func TestBuildDelegationGraph(t *testing.T) {
+
+	graph := delegation.BuildDelegationGraph(100, []delegation.NodeDelegation{
+		{NodeAddress: nodeA, VotingPower: eth.EthToWei(30), Delegate: nodeA, VotingInitialized: true},
+		{NodeAddress: nodeB, VotingPower: eth.EthToWei(10), Delegate: nodeA, VotingInitialized: true},
+		{NodeAddress: nodeC, VotingPower: eth.EthToWei(20), Delegate: nodeC, VotingInitialized: true},
+		{NodeAddress: nodeD, VotingPower: eth.EthToWei(0), Delegate: common.Address{}, VotingInitialized: false},
+	})
+
+	if graph.TotalVotingPower.Cmp(eth.EthToWei(60)) != 0 || graph.SelfDelegatedNodeCount != 2 {
+		t.Errorf("Incorrect totals: %s, %d self-delegated", graph.TotalVotingPower, graph.SelfDelegatedNodeCount)
+	}
+	if len(graph.UninitializedNodes) != 1 || graph.UninitializedNodes[0] != nodeD {
+		t.Errorf("Incorrect uninitialized nodes: %v", graph.UninitializedNodes)
+	}
+	if len(graph.Delegates) != 2 || graph.Delegates[0].Address != nodeA {
+		t.Fatalf("Incorrect delegates: %+v", graph.Delegates)
+	}
+
+	delegate := graph.Delegates[0]
+	if delegate.DelegatedVotingPower.Cmp(eth.EthToWei(40)) != 0 || delegate.DelegatorCount != 1 || delegate.Delegators[0] != nodeB {
+		t.Errorf("Incorrect delegate: %+v", delegate)
+	}
+	if delegate.SelfDelegationRatio != 0.75 {
+		t.Errorf("Incorrect self-delegation ratio: %f", delegate.SelfDelegationRatio)
+	}
+	if share := graph.Delegates[1].VotingPowerShare; share < 0.333 || share > 0.334 {
+		t.Errorf("Incorrect voting power share: %f", share)
+	}
+
+	if delegates, reached := graph.GetDelegatesForVotingPower(eth.EthToWei(50)); !reached || len(delegates) != 2 {
+		t.Errorf("Incorrect delegates for voting power: %t, %d", reached, len(delegates))
+	}
+	if _, reached := graph.GetDelegatesForVotingPower(eth.EthToWei(61)); reached {
+		t.Error("Unreachable voting power was reached")
+	}
+
+}
+
+func TestDiffDelegationGraphs(t *testing.T) {
+
+	oldGraph := delegation.BuildDelegationGraph(100, []delegation.NodeDelegation{
+		{NodeAddress: nodeA, VotingPower: eth.EthToWei(30), Delegate: nodeA, VotingInitialized: true},
+		{NodeAddress: nodeB, VotingPower: eth.EthToWei(10), Delegate: nodeA, VotingInitialized: true},
+		{NodeAddress: nodeC, VotingPower: eth.EthToWei(20), Delegate: nodeC, VotingInitialized: true},
+	})
+	newGraph := delegation.BuildDelegationGraph(200, []delegation.NodeDelegation{
+		{NodeAddress: nodeA, VotingPower: eth.EthToWei(30), Delegate: nodeA, VotingInitialized: true},
+		{NodeAddress: nodeB, VotingPower: eth.EthToWei(10), Delegate: nodeC, VotingInitialized: true},
+		{NodeAddress: nodeC, VotingPower: eth.EthToWei(20), Delegate: nodeC, VotingInitialized: true},
+		{NodeAddress: nodeD, VotingPower: eth.EthToWei(5), Delegate: nodeD, VotingInitialized: true},
+	})
+
+	diff := delegation.DiffDelegationGraphs(oldGraph, newGraph)
+	if len(diff.NodeChanges) != 2 {
+		t.Fatalf("Incorrect node changes: %+v", diff.NodeChanges)
+	}
+	if change := diff.NodeChanges[0]; change.NodeAddress != nodeB || change.OldDelegate != nodeA || change.NewDelegate != nodeC {
+		t.Errorf("Incorrect delegate change: %+v", change)
+	}
+	if change := diff.NodeChanges[1]; change.NodeAddress != nodeD || !change.IsNew {
+		t.Errorf("Incorrect new node: %+v", change)
+	}
+
+	if len(diff.DelegateChanges) != 3 {
+		t.Fatalf("Incorrect delegate changes: %+v", diff.DelegateChanges)
+	}
+	for _, change := range diff.DelegateChanges {
+		switch change.Address {
+		case nodeA:
+			if len(change.LostDelegators) != 1 || change.NewDelegatedVotingPower.Cmp(eth.EthToWei(30)) != 0 {
+				t.Errorf("Incorrect change for the old delegate: %+v", change)
+			}
+		case nodeC:
+			if len(change.GainedDelegators) != 1 || change.NewDelegatorCount != 1 {
+				t.Errorf("Incorrect change for the new delegate: %+v", change)
+			}
+		case nodeD:
+			if change.OldDelegatedVotingPower.Sign() != 0 {
+				t.Errorf("Incorrect change for the added delegate: %+v", change)
+			}
+		default:
+			t.Errorf("Unexpected delegate change: %+v", change)
+		}
+	}
+
+}
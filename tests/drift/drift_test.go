@@ -0,0 +1,160 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/rocket-pool/rocketpool-go/utils/drift"
+)
+
+const baselineAbi string = `[
+	{"type":"function","name":"getRPLPrice","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getPricesBlock","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"submitPrices","stateMutability":"nonpayable","inputs":[{"name":"_block","type":"uint256"},{"name":"_rplPrice","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"PricesSubmitted","inputs":[{"name":"from","type":"address","indexed":true},{"name":"block","type":"uint256","indexed":false}]},
+	{"type":"event","name":"PricesUpdated","inputs":[{"name":"block","type":"uint256","indexed":false}]}
+]`
+
+const deployedAbi string = `[
+	{"type":"function","name":"getRPLPrice","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint128"}]},
+	{"type":"function","name":"submitPrices","stateMutability":"nonpayable","inputs":[{"name":"_block","type":"uint256"},{"name":"_slotTimestamp","type":"uint256"},{"name":"_rplPrice","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"PricesSubmitted","inputs":[{"name":"from","type":"address","indexed":true},{"name":"block","type":"uint256","indexed":true}]}
+]`
+
+func parseAbi(t *testing.T, abiString string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &parsed
+}
+
+// Check that a usage was found in the library's source
+func hasUsage(usages []drift.Usage, contractName string, member string, kind drift.UsageKind, packageName string) bool {
+	for _, usage := range usages {
+		if usage.Contract == contractName && usage.Member == member && usage.Kind == kind && usage.Package == packageName {
+			return true
+		}
+	}
+	return false
+}
+
+func TestScanUsages(t *testing.T) {
+
+	usages, err := drift.ScanUsages("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []struct {
+		contract string
+		member   string
+		kind     drift.UsageKind
+		pkg      string
+	}{
+		{"rocketNetworkPrices", "getRPLPrice", drift.UsageCall, "network"},                                   // package getter
+		{"rocketNetworkPrices", "submitPrices", drift.UsageTransact, "network"},                              // transaction
+		{"rocketNetworkPrices", "PricesSubmitted", drift.UsageEvent, "network"},                              // event lookup
+		{"rocketNetworkPrices", "getRPLPrice", drift.UsageMulticall, "utils/state"},                          // network contracts field
+		{"rocketMinipool", "getStatus", drift.UsageCall, "minipool"},                                         // contract held by a type
+		{"rocketDAOProtocolSettingsNetwork", "getSubmitPricesEnabled", drift.UsageCall, "settings/protocol"}, // constant contract name
+	} {
+		if !hasUsage(usages, expected.contract, expected.member, expected.kind, expected.pkg) {
+			t.Errorf("Usage of %s.%s (%s) in %s was not found", expected.contract, expected.member, expected.kind, expected.pkg)
+		}
+	}
+
+	// Test files aren't library code
+	for _, usage := range usages {
+		if strings.HasPrefix(usage.Package, "tests") {
+			t.Errorf("Usage found in test package %s", usage.Package)
+			break
+		}
+	}
+
+}
+
+func TestCheckAbis(t *testing.T) {
+
+	usages := []drift.Usage{
+		{Contract: "rocketNetworkPrices", Member: "getRPLPrice", Kind: drift.UsageCall, ArgCount: 0},
+		{Contract: "rocketNetworkPrices", Member: "getPricesBlock", Kind: drift.UsageCall, ArgCount: 0},
+		{Contract: "rocketNetworkPrices", Member: "submitPrices", Kind: drift.UsageTransact, ArgCount: 2},
+		{Contract: "rocketNetworkPrices", Member: "submitPrices", Kind: drift.UsageTransact, ArgCount: -1},
+		{Contract: "rocketNetworkPrices", Member: "PricesSubmitted", Kind: drift.UsageEvent, ArgCount: -1},
+		{Contract: "rocketNetworkPrices", Member: "PricesUpdated", Kind: drift.UsageEvent, ArgCount: -1},
+		{Contract: "rocketNetworkFees", Member: "getNodeFee", Kind: drift.UsageCall, ArgCount: 0},
+	}
+	deployed := map[string]*abi.ABI{"rocketNetworkPrices": parseAbi(t, deployedAbi)}
+	baseline := map[string]*abi.ABI{"rocketNetworkPrices": parseAbi(t, baselineAbi)}
+
+	// Without a baseline, only missing members and argument counts can be checked
+	report := drift.CheckAbis(usages, deployed, nil)
+	expected := map[drift.IssueType]int{
+		drift.IssueMissingContract: 1,
+		drift.IssueMissingMethod:   1,
+		drift.IssueArgumentCount:   1,
+		drift.IssueMissingEvent:    1,
+	}
+	checkIssues(t, report, expected)
+
+	// With a baseline, changed signatures are reported too
+	report = drift.CheckAbis(usages, deployed, baseline)
+	expected[drift.IssueInputsChanged] = 1
+	expected[drift.IssueOutputsChanged] = 1
+	expected[drift.IssueEventChanged] = 1
+	checkIssues(t, report, expected)
+	if report.CheckedUsages != len(usages) {
+		t.Errorf("Incorrect checked usage count: expected %d, got %d", len(usages), report.CheckedUsages)
+	}
+	for _, issue := range report.Issues {
+		if issue.Type == drift.IssueInputsChanged && len(issue.Usages) != 2 {
+			t.Errorf("Changed inputs issue should group both usages, got %d", len(issue.Usages))
+		}
+	}
+
+	// A matching network has no issues
+	report = drift.CheckAbis(usages[:2], baseline, baseline)
+	if report.HasIssues() {
+		t.Errorf("Unexpected issues:\n%s", report.String())
+	}
+
+}
+
+func checkIssues(t *testing.T, report drift.Report, expected map[drift.IssueType]int) {
+	counts := map[drift.IssueType]int{}
+	for _, issue := range report.Issues {
+		counts[issue.Type]++
+	}
+	for issueType, count := range expected {
+		if counts[issueType] != count {
+			t.Errorf("Incorrect %s issue count: expected %d, got %d\n%s", issueType, count, counts[issueType], report.String())
+		}
+	}
+	if len(report.Issues) != len(counts) && len(counts) != len(expected) {
+		t.Errorf("Unexpected issues:\n%s", report.String())
+	}
+}
+
+func TestCheckAbisWithoutBaseline(t *testing.T) {
+
+	// Usages that match the deployed ABIs
+	usages := []drift.Usage{
+		{Contract: "rocketNetworkPrices", Member: "getRPLPrice", Kind: drift.UsageCall, ArgCount: 0},
+		{Contract: "rocketNetworkPrices", Member: "submitPrices", Kind: drift.UsageTransact, ArgCount: 3},
+		{Contract: "rocketNetworkPrices", Member: "submitPrices", Kind: drift.UsageTransact, ArgCount: -1},
+		{Contract: "rocketNetworkPrices", Member: "PricesSubmitted", Kind: drift.UsageEvent, ArgCount: -1},
+	}
+	deployed := map[string]*abi.ABI{"rocketNetworkPrices": parseAbi(t, deployedAbi)}
+
+	// Without a baseline, the checks don't rely on older ABIs and find nothing
+	report := drift.CheckAbis(usages, deployed, nil)
+	if report.HasIssues() {
+		t.Errorf("Unexpected issues:\n%s", report.String())
+	}
+	if report.CheckedUsages != len(usages) {
+		t.Errorf("Incorrect checked usage count: expected %d, got %d", len(usages), report.CheckedUsages)
+	}
+
+}
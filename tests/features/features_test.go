@@ -0,0 +1,77 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+func TestCapabilities(t *testing.T) {
+
+	atlas := rocketpool.NewCapabilities(version.Must(version.NewSemver("1.2.0")))
+	houston := rocketpool.NewCapabilities(version.Must(version.NewSemver("1.3.0")))
+
+	// Features are available from the version that introduced them
+	if !atlas.Has(rocketpool.FeatureBondReduction) || !houston.Has(rocketpool.FeatureBondReduction) {
+		t.Error("Bond reduction is not available on Atlas and Houston")
+	}
+	if atlas.Has(rocketpool.FeatureOnChainPDaoVoting) || !houston.Has(rocketpool.FeatureOnChainPDaoVoting) {
+		t.Error("On-chain pDAO voting is not limited to Houston")
+	}
+	if err := atlas.Require(rocketpool.FeatureRplWithdrawalAddress); err == nil {
+		t.Error("Atlas supports RPL withdrawal addresses")
+	}
+
+	// Features are unavailable from the version that removed them
+	if !atlas.Has(rocketpool.FeatureLatestReportBlock) || houston.Has(rocketpool.FeatureLatestReportBlock) {
+		t.Error("Latest reportable block was not removed in Houston")
+	}
+
+	// Unregistered features are never available
+	if houston.Has("nonexistent") {
+		t.Error("Unregistered feature is available")
+	}
+
+}
+
+func TestRegisterFeature(t *testing.T) {
+
+	// Register the next upgrade's feature as data
+	feature := rocketpool.Feature("testFeature")
+	registry := rocketpool.NewFeatureRegistry()
+	if err := registry.Register(feature, "1.4.0", ""); err != nil {
+		t.Fatal(err)
+	}
+	houston := registry.NewCapabilities(version.Must(version.NewSemver("1.3.0")))
+	next := registry.NewCapabilities(version.Must(version.NewSemver("1.4.0")))
+	if houston.Has(feature) || !next.Has(feature) {
+		t.Error("Registered feature is not limited to its version")
+	}
+	if !next.Has(rocketpool.FeatureRplLocking) {
+		t.Error("Registry is missing the built-in features")
+	}
+	found := false
+	for _, available := range next.Features() {
+		if available == feature {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Registered feature is not listed")
+	}
+
+	if err := registry.Register(feature, "not a version", ""); err == nil {
+		t.Error("Invalid version was accepted")
+	}
+
+	// Registries aren't shared between instances
+	if rocketpool.NewFeatureRegistry().NewCapabilities(next.Version).Has(feature) {
+		t.Error("Registered feature leaked into another registry")
+	}
+	if rocketpool.NewCapabilities(next.Version).Has(feature) {
+		t.Error("Registered feature leaked into the built-in features")
+	}
+
+}
@@ -0,0 +1,216 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+const testAbi string = `[
+	{"type":"function","name":"distributeBalance","stateMutability":"nonpayable","inputs":[],"outputs":[]},
+	{"type":"function","name":"stake","stateMutability":"payable","inputs":[],"outputs":[]}
+]`
+
+// Execution client stand-in with a fixed gas estimate and fee history
+type feeClient struct {
+	rocketpool.ExecutionClient
+	gas uint64
+}
+
+func (c *feeClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return c.gas, nil
+}
+func (c *feeClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	gwei := func(amount int64) *big.Int {
+		return big.NewInt(amount * 1e9)
+	}
+	return &ethereum.FeeHistory{
+		OldestBlock: big.NewInt(100),
+		Reward: [][]*big.Int{
+			{gwei(1), gwei(2), gwei(5)},
+			{gwei(0), gwei(0), gwei(0)}, // empty block
+			{gwei(1), gwei(3), gwei(6)},
+			{gwei(2), gwei(4), gwei(7)},
+		},
+		BaseFee:      []*big.Int{gwei(8), gwei(9), gwei(8), gwei(9), gwei(10)},
+		GasUsedRatio: []float64{0.5, 0, 0.4, 0.6},
+	}, nil
+}
+
+// Execution client stand-in without eth_feeHistory support
+type noFeeClient struct {
+	feeClient
+}
+
+func (c *noFeeClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return nil, fmt.Errorf("the method eth_feeHistory does not exist/is not available")
+}
+
+// Execution client stand-in whose fee history requests fail
+type failingFeeClient struct {
+	feeClient
+}
+
+func (c *failingFeeClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func TestGasLimitMultipliers(t *testing.T) {
+
+	multipliers := rocketpool.NewGasLimitMultipliers()
+	if multipliers.Get("rocketMinipool", "stake") != rocketpool.GasLimitMultiplier {
+		t.Error("Incorrect default multiplier")
+	}
+	multipliers.Set("rocketMinipool", "", 1.2)
+	multipliers.Set("rocketMinipool", "distributeBalance", 2)
+	if multipliers.Get("rocketMinipool", "stake") != 1.2 {
+		t.Error("Contract multiplier was not applied")
+	}
+	if multipliers.Get("rocketMinipool", "distributeBalance") != 2 {
+		t.Error("Method multiplier was not applied")
+	}
+	multipliers.Set("rocketMinipool", "distributeBalance", 0)
+	if multipliers.Get("rocketMinipool", "distributeBalance") != 1.2 {
+		t.Error("Method multiplier was not removed")
+	}
+
+	// Multipliers aren't shared between instances
+	if rocketpool.NewGasLimitMultipliers().Get("rocketMinipool", "stake") != rocketpool.GasLimitMultiplier {
+		t.Error("Multipliers were shared between instances")
+	}
+
+}
+
+func TestTransactionGasInfo(t *testing.T) {
+
+	multipliers := rocketpool.NewGasLimitMultipliers()
+	multipliers.Set("rocketMinipool", "distributeBalance", 2)
+
+	// Create the contract
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &feeClient{gas: 100000}
+	address := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	contract := &rocketpool.Contract{
+		Contract:            bind.NewBoundContract(address, parsedAbi, client, client, client),
+		Address:             &address,
+		ABI:                 &parsedAbi,
+		Client:              client,
+		Name:                "rocketMinipool",
+		GasLimitMultipliers: multipliers,
+	}
+
+	// Check the limits
+	gasInfo, err := contract.GetTransactionGasInfo(&bind.TransactOpts{}, "distributeBalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasInfo.EstGasLimit != 100000 || gasInfo.SafeGasLimit != 200000 {
+		t.Errorf("Incorrect gas limits %d / %d", gasInfo.EstGasLimit, gasInfo.SafeGasLimit)
+	}
+	gasInfo, err = contract.GetTransactionGasInfo(&bind.TransactOpts{}, "stake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasInfo.SafeGasLimit != 150000 {
+		t.Errorf("Incorrect default safe gas limit %d", gasInfo.SafeGasLimit)
+	}
+
+	// Check the costs, using the next block's base fee and the median reward of non-empty blocks
+	costs := gasInfo.Costs
+	if costs == nil {
+		t.Fatal("Gas costs were not estimated")
+	}
+	if costs.BaseFee.Cmp(big.NewInt(10e9)) != 0 {
+		t.Errorf("Incorrect base fee %s", costs.BaseFee)
+	}
+	if costs.Low.MaxPriorityFeePerGas.Cmp(big.NewInt(1e9)) != 0 || costs.Medium.MaxPriorityFeePerGas.Cmp(big.NewInt(3e9)) != 0 || costs.High.MaxPriorityFeePerGas.Cmp(big.NewInt(6e9)) != 0 {
+		t.Errorf("Incorrect priority fees %s / %s / %s", costs.Low.MaxPriorityFeePerGas, costs.Medium.MaxPriorityFeePerGas, costs.High.MaxPriorityFeePerGas)
+	}
+	if costs.Medium.MaxFeePerGas.Cmp(big.NewInt(23e9)) != 0 {
+		t.Errorf("Incorrect max fee %s", costs.Medium.MaxFeePerGas)
+	}
+	if costs.Medium.EstCost.Cmp(big.NewInt(13e14)) != 0 || costs.Medium.MaxCost.Cmp(big.NewInt(345e13)) != 0 {
+		t.Errorf("Incorrect costs %s / %s", costs.Medium.EstCost, costs.Medium.MaxCost)
+	}
+
+}
+
+func TestGasInfoWithoutFeeHistory(t *testing.T) {
+
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &noFeeClient{feeClient{gas: 100000}}
+	address := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	contract := &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsedAbi, client, client, client),
+		Address:  &address,
+		ABI:      &parsedAbi,
+		Client:   client,
+		Name:     "rocketMinipool",
+	}
+
+	// The limits are still returned, without costs
+	gasInfo, err := contract.GetTransactionGasInfo(&bind.TransactOpts{}, "stake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasInfo.EstGasLimit != 100000 || gasInfo.SafeGasLimit != 150000 {
+		t.Errorf("Incorrect gas limits %d / %d", gasInfo.EstGasLimit, gasInfo.SafeGasLimit)
+	}
+	if gasInfo.Costs != nil {
+		t.Errorf("Unexpected gas costs %+v", gasInfo.Costs)
+	}
+	gasInfo, err = contract.GetTransferGasInfo(&bind.TransactOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasInfo.SafeGasLimit != 150000 || gasInfo.Costs != nil {
+		t.Errorf("Incorrect transfer gas info %+v", gasInfo)
+	}
+
+}
+
+func TestGasCostsFailure(t *testing.T) {
+
+	// Unsupported fee history means no costs
+	costs, err := rocketpool.GetOptionalGasCosts(&noFeeClient{feeClient{gas: 100000}}, 100000, 150000)
+	if err != nil || costs != nil {
+		t.Errorf("Incorrect costs for a client without fee history: %+v, %v", costs, err)
+	}
+
+	// Other failures are errors
+	client := &failingFeeClient{feeClient{gas: 100000}}
+	if _, err := rocketpool.GetOptionalGasCosts(client, 100000, 150000); err == nil {
+		t.Error("Fee history failure was ignored")
+	}
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	contract := &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsedAbi, client, client, client),
+		Address:  &address,
+		ABI:      &parsedAbi,
+		Client:   client,
+		Name:     "rocketMinipool",
+	}
+	if _, err := contract.GetTransactionGasInfo(&bind.TransactOpts{}, "stake"); err == nil {
+		t.Error("Gas info was returned without costs after a fee history failure")
+	}
+
+}
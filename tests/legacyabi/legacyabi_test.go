@@ -0,0 +1,97 @@
+package legacyabi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+func TestEmbeddedVersions(t *testing.T) {
+
+	versions, err := rocketpool.GetLegacyABIVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"1.0.0", "1.1.0-rc1", "1.1.0", "1.2.0"}
+	if len(versions) != len(expected) {
+		t.Fatalf("Incorrect legacy ABI versions: expected %v, got %v", expected, versions)
+	}
+	for i := range expected {
+		if versions[i] != expected[i] {
+			t.Errorf("Incorrect legacy ABI version %d: expected %s, got %s", i, expected[i], versions[i])
+		}
+	}
+
+	// Every embedded ABI must parse
+	for _, rpVersion := range versions {
+		contractNames, err := rocketpool.GetLegacyABIContracts(rpVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, contractName := range contractNames {
+			if _, err := rocketpool.GetLegacyABI(rpVersion, contractName); err != nil {
+				t.Errorf("Error loading v%s contract %s ABI: %s", rpVersion, contractName, err.Error())
+			}
+		}
+	}
+
+}
+
+func TestWrappersLoadFromRegistry(t *testing.T) {
+
+	rp, err := rocketpool.NewRocketPool(nil, common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wrapper := range rp.VersionManager.GetLegacyVersions() {
+		contractNames, err := rocketpool.GetLegacyABIContracts(wrapper.GetVersion().Original())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contractNames) == 0 {
+			t.Errorf("No ABIs embedded for v%s", wrapper.GetVersion().Original())
+		}
+		for _, contractName := range contractNames {
+			if _, exists := wrapper.GetVersionedContractName(contractName); !exists {
+				t.Errorf("v%s ABI for contract %s has no versioned name", wrapper.GetVersion().Original(), contractName)
+			}
+			if _, err := wrapper.GetABI(contractName); err != nil {
+				t.Errorf("Error loading v%s contract %s ABI from its wrapper: %s", wrapper.GetVersion().Original(), contractName, err.Error())
+			}
+		}
+	}
+
+	// The v1.2.0 network prices contract still had the old price submission method
+	pricesAbi, err := rp.VersionManager.V1_2_0.GetABI("rocketNetworkPrices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := pricesAbi.Methods["getLatestReportableBlock"]; !exists {
+		t.Error("v1.2.0 network prices ABI is missing getLatestReportableBlock")
+	}
+
+}
+
+func TestRegisterLegacyABI(t *testing.T) {
+
+	if err := rocketpool.RegisterLegacyABI("1.2.1", "rocketTest", []byte("not json")); err == nil {
+		t.Error("Registered an invalid ABI")
+	}
+	abiJson := []byte(`[{"type":"function","name":"version","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}]`)
+	if err := rocketpool.RegisterLegacyABI("1.2.1", "rocketTest", abiJson); err != nil {
+		t.Fatal(err)
+	}
+	testAbi, err := rocketpool.GetLegacyABI("1.2.1", "rocketTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := testAbi.Methods["version"]; !exists {
+		t.Error("Registered ABI is missing its method")
+	}
+	if _, err := rocketpool.GetLegacyABI("1.2.1", "rocketMissing"); err == nil {
+		t.Error("Loaded an ABI that was never registered")
+	}
+
+}
@@ -0,0 +1,92 @@
+package networks
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/networks"
+)
+
+func TestBuiltinPresets(t *testing.T) {
+	for _, name := range []string{networks.Mainnet, networks.Holesky} {
+		preset, err := networks.GetPreset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := preset.Validate(); err != nil {
+			t.Error(err)
+		}
+		if !preset.IsHoustonDeployed {
+			t.Errorf("Preset %s does not have Houston deployed", name)
+		}
+	}
+	if _, err := networks.GetPreset("nonexistent"); err == nil {
+		t.Error("Unknown preset was found")
+	}
+
+	// Mainnet has a deploy block hint
+	mainnet, _ := networks.GetPreset(networks.Mainnet)
+	if mainnet.DeployBlock != 13325233 {
+		t.Errorf("Incorrect mainnet deploy block %d", mainnet.DeployBlock)
+	}
+}
+
+func TestLoadPresets(t *testing.T) {
+
+	// Write a devnet config
+	path := filepath.Join(t.TempDir(), "networks.json")
+	config := `[{
+		"name": "devnet",
+		"chainId": 1337,
+		"storageAddress": "0x1111111111111111111111111111111111111111",
+		"multicallAddress": "0x2222222222222222222222222222222222222222",
+		"balanceBatcherAddress": "0x3333333333333333333333333333333333333333",
+		"depositContractAddress": "0x4444444444444444444444444444444444444444",
+		"deployBlock": 10,
+		"isHoustonDeployed": true,
+		"houstonDeployBlock": 100
+	}]`
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Load it
+	if _, err := networks.LoadPresets(path); err != nil {
+		t.Fatal(err)
+	}
+	preset, err := networks.GetPreset("devnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preset.ChainID != 1337 || preset.StorageAddress != common.HexToAddress("0x1111111111111111111111111111111111111111") || preset.DeployBlock != 10 {
+		t.Errorf("Incorrect devnet preset %+v", preset)
+	}
+
+	// Houston activation follows the deploy block
+	if preset.IsHoustonDeployedAt(&bind.CallOpts{BlockNumber: big.NewInt(99)}) {
+		t.Error("Houston was active before its deploy block")
+	}
+	if !preset.IsHoustonDeployedAt(&bind.CallOpts{BlockNumber: big.NewInt(100)}) || !preset.IsHoustonDeployedAt(nil) {
+		t.Error("Houston was not active after its deploy block")
+	}
+
+	// Houston can't be deployed before the network
+	preset.HoustonDeployBlock = 5
+	if err := preset.Validate(); err == nil {
+		t.Error("Preset with Houston deployed before the network was accepted")
+	}
+
+	// Incomplete presets are rejected
+	if err := os.WriteFile(path, []byte(`[{"name": "broken", "chainId": 1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := networks.LoadPresets(path); err == nil {
+		t.Error("Incomplete preset was loaded")
+	}
+
+}
@@ -0,0 +1,173 @@
+package networkstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+var (
+	nodeAddress     = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	minipoolAddress = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	memberAddress   = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	pubkey          = types.BytesToValidatorPubkey(bytes.Repeat([]byte{0xab}, types.ValidatorPubkeyLength))
+)
+
+// Create a small network state with every section filled in
+// Times are in UTC, since that's how they are decoded
+func getTestState() *state.NetworkState {
+	wei, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	networkState := &state.NetworkState{
+		ElBlockNumber:  18000000,
+		NetworkVersion: "1.3.0",
+		NetworkDetails: &state.NetworkDetails{
+			RplPrice:            big.NewInt(5e15),
+			IntervalDuration:    28 * 24 * time.Hour,
+			IntervalStart:       time.Unix(1690000000, 0).UTC(),
+			RewardIndex:         12,
+			QueueCapacity:       minipool.QueueCapacity{Total: big.NewInt(0), Effective: big.NewInt(0)},
+			RETHExchangeRate:    1.0834512345678901,
+			TotalETHBalance:     wei,
+			SubmitPricesEnabled: true,
+		},
+		NodeDetails: []state.NativeNodeDetails{
+			{
+				Exists:           true,
+				NodeAddress:      nodeAddress,
+				RegistrationTime: big.NewInt(1650000000),
+				TimezoneLocation: "Etc/UTC",
+				RplStake:         wei,
+				AverageNodeFee:   big.NewInt(0),
+			},
+		},
+		MinipoolDetails: []state.NativeMinipoolDetails{
+			{
+				Exists:          true,
+				MinipoolAddress: minipoolAddress,
+				NodeAddress:     nodeAddress,
+				Pubkey:          pubkey,
+				Status:          types.Staking,
+				DepositType:     types.Variable,
+				NodeFee:         big.NewInt(14e16),
+				Version:         3,
+			},
+		},
+		OracleDaoMemberDetails: []state.OracleDaoMemberDetails{
+			{
+				Address:       memberAddress,
+				Exists:        true,
+				ID:            "member",
+				JoinedTime:    time.Unix(1640000000, 0).UTC(),
+				RPLBondAmount: big.NewInt(1750),
+			},
+		},
+		ProtocolDaoProposalDetails: []protocol.ProtocolDaoProposalDetails{
+			{
+				ID:              1,
+				ProposerAddress: nodeAddress,
+				Message:         "set a setting",
+				CreatedTime:     time.Unix(1700000000, 0).UTC(),
+				VotingPowerFor:  big.NewInt(1000),
+				Payload:         []byte{0x01, 0x02},
+				State:           types.ProtocolDaoProposalState_Executed,
+			},
+		},
+	}
+	networkState.UpdateIndexes()
+	return networkState
+}
+
+// Check that a loaded state matches the original
+func checkRoundTrip(t *testing.T, original *state.NetworkState, loaded *state.NetworkState) {
+	if !reflect.DeepEqual(original, loaded) {
+		t.Errorf("Loaded state doesn't match:\nexpected %+v\ngot      %+v", original, loaded)
+	}
+
+	// The indexes must be rebuilt
+	if node, exists := loaded.GetNode(nodeAddress); !exists || node.TimezoneLocation != "Etc/UTC" {
+		t.Error("Node index wasn't rebuilt")
+	}
+	if mp, exists := loaded.GetMinipoolByPubkey(pubkey); !exists || mp.MinipoolAddress != minipoolAddress {
+		t.Error("Minipool pubkey index wasn't rebuilt")
+	}
+	if minipools := loaded.GetNodeMinipools(nodeAddress); len(minipools) != 1 || minipools[0] != loaded.MinipoolDetailsByAddress[minipoolAddress] {
+		t.Error("Node minipool index wasn't rebuilt")
+	}
+	if _, exists := loaded.GetOracleDaoMember(memberAddress); !exists {
+		t.Error("Oracle DAO member index wasn't rebuilt")
+	}
+}
+
+func TestJsonRoundTrip(t *testing.T) {
+
+	original := getTestState()
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["formatVersion"] != float64(state.NetworkStateFormatVersion) {
+		t.Errorf("Incorrect format version %v", raw["formatVersion"])
+	}
+
+	var loaded state.NetworkState
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, original, &loaded)
+	if loaded.NetworkDetails.TotalETHBalance.Cmp(original.NetworkDetails.TotalETHBalance) != 0 {
+		t.Error("Large integers weren't preserved")
+	}
+
+	// Snapshots from newer formats must be rejected
+	if err := json.Unmarshal([]byte(`{"formatVersion":99}`), &loaded); err == nil {
+		t.Error("Loaded a state with an unsupported format version")
+	}
+
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+
+	original := getTestState()
+	data, err := original.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) >= len(jsonData) {
+		t.Errorf("Binary state (%d bytes) isn't smaller than the JSON state (%d bytes)", len(data), len(jsonData))
+	}
+
+	var loaded state.NetworkState
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, original, &loaded)
+
+	// Corrupt data must be rejected
+	if err := loaded.UnmarshalBinary(jsonData); err == nil {
+		t.Error("Loaded a binary state from JSON data")
+	}
+	future := append([]byte{}, data...)
+	future[7] = 99
+	if err := loaded.UnmarshalBinary(future); err == nil {
+		t.Error("Loaded a binary state with an unsupported format version")
+	}
+
+}
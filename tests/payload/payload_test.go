@@ -0,0 +1,143 @@
+package payload
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol/payload"
+	"github.com/rocket-pool/rocketpool-go/settings"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The proposal methods of rocketDAOProtocolProposals
+const proposalsAbi = `[
+	{"type":"function","name":"proposalSettingUint","inputs":[{"name":"_settingNameSpace","type":"string"},{"name":"_settingPath","type":"string"},{"name":"_value","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"proposalSettingBool","inputs":[{"name":"_settingNameSpace","type":"string"},{"name":"_settingPath","type":"string"},{"name":"_value","type":"bool"}],"outputs":[]},
+	{"type":"function","name":"proposalSettingMulti","inputs":[{"name":"_settingContractNames","type":"string[]"},{"name":"_settingPaths","type":"string[]"},{"name":"_types","type":"uint8[]"},{"name":"_data","type":"bytes[]"}],"outputs":[]},
+	{"type":"function","name":"proposalSettingRewardsClaimers","inputs":[{"name":"_trustedNodePercent","type":"uint256"},{"name":"_protocolPercent","type":"uint256"},{"name":"_nodePercent","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"proposalTreasuryNewContract","inputs":[{"name":"_contractName","type":"string"},{"name":"_recipientAddress","type":"address"},{"name":"_amountPerPeriod","type":"uint256"},{"name":"_periodLength","type":"uint256"},{"name":"_startTime","type":"uint256"},{"name":"_numPeriods","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"proposalSecurityReplace","inputs":[{"name":"_existingMemberAddress","type":"address"},{"name":"_newMemberId","type":"string"},{"name":"_newMemberAddress","type":"address"}],"outputs":[]}
+]`
+
+func pack(t *testing.T, contractAbi *abi.ABI, method string, args ...interface{}) []byte {
+	data, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecodeSettings(t *testing.T) {
+
+	contractAbi, err := abi.JSON(strings.NewReader(proposalsAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Single setting
+	action, err := payload.DecodePayload(&contractAbi, pack(t, &contractAbi, "proposalSettingUint", psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, eth.EthToWei(0.1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Type != payload.ActionSetUint || len(action.SettingChanges) != 1 {
+		t.Fatalf("Incorrect action %+v", action)
+	}
+	change := action.SettingChanges[0]
+	if change.Path != psettings.TargetNodeFeeSettingPath || change.Unit != settings.UnitFraction || change.Setting == nil {
+		t.Errorf("Incorrect setting change %+v", change)
+	}
+	change.OldValue = eth.EthToWei(0.14)
+	if change.String() != "Set rocketDAOProtocolSettingsNetwork/network.node.fee.target from 14% to 10%" {
+		t.Errorf("Incorrect description: %s", change.String())
+	}
+
+	// Multiple settings, including one the registry doesn't know
+	values := [][]byte{
+		math.U256Bytes(big.NewInt(604800)),
+		common.LeftPadBytes([]byte{1}, 32),
+		math.U256Bytes(big.NewInt(7)),
+	}
+	settingTypes := []uint8{uint8(types.ProposalSettingType_Uint256), uint8(types.ProposalSettingType_Bool), uint8(types.ProposalSettingType_Uint256)}
+	data := pack(t, &contractAbi, "proposalSettingMulti",
+		[]string{psettings.ProposalsSettingsContractName, psettings.DepositSettingsContractName, "rocketDAOProtocolSettingsUnknown"},
+		[]string{psettings.VotePhase1TimeSettingPath, psettings.DepositEnabledSettingPath, "unknown.setting"},
+		settingTypes, values)
+	action, err = payload.DecodePayload(&contractAbi, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Type != payload.ActionSetMulti || len(action.SettingChanges) != 3 {
+		t.Fatalf("Incorrect action %+v", action)
+	}
+	if action.SettingChanges[0].NewValue.(*big.Int).Uint64() != 604800 || action.SettingChanges[0].Unit != settings.UnitSeconds {
+		t.Errorf("Incorrect duration change %+v", action.SettingChanges[0])
+	}
+	if action.SettingChanges[1].NewValue != true {
+		t.Errorf("Incorrect bool change %+v", action.SettingChanges[1])
+	}
+	if action.SettingChanges[2].Setting != nil {
+		t.Error("Unknown setting was found in the registry")
+	}
+	expected := []string{
+		"Set rocketDAOProtocolSettingsProposals/proposal.vote.phase1.time to 168h0m0s",
+		"Set rocketDAOProtocolSettingsDeposit/deposit.enabled to true",
+		"Set rocketDAOProtocolSettingsUnknown/unknown.setting to 7",
+	}
+	if action.String() != strings.Join(expected, "\n") {
+		t.Errorf("Incorrect description:\n%s", action.String())
+	}
+
+	// Unknown methods and truncated payloads
+	if _, err := payload.DecodePayload(&contractAbi, []byte{1, 2, 3, 4}); err == nil {
+		t.Error("Decoded an unknown method")
+	}
+	if _, err := payload.DecodePayload(&contractAbi, data[:40]); err == nil {
+		t.Error("Decoded a truncated payload")
+	}
+
+}
+
+func TestDecodeOtherActions(t *testing.T) {
+
+	contractAbi, err := abi.JSON(strings.NewReader(proposalsAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action, err := payload.DecodePayload(&contractAbi, pack(t, &contractAbi, "proposalSettingRewardsClaimers", eth.EthToWei(0.1), eth.EthToWei(0.2), eth.EthToWei(0.7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Type != payload.ActionSetRewardsPercentages || action.RewardsPercentages.NewPercentages.NodePercentage.Cmp(eth.EthToWei(0.7)) != 0 {
+		t.Errorf("Incorrect rewards percentages action %+v", action)
+	}
+
+	recipient := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	action, err = payload.DecodePayload(&contractAbi, pack(t, &contractAbi, "proposalTreasuryNewContract", "grants", recipient, eth.EthToWei(100), big.NewInt(2419200), big.NewInt(1700000000), big.NewInt(12)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend := action.RecurringSpend
+	if action.Type != payload.ActionTreasuryNewContract || spend.Recipient != recipient || spend.PeriodLength != 28*24*time.Hour || spend.StartTime.Unix() != 1700000000 || spend.NumberOfPeriods != 12 {
+		t.Errorf("Incorrect recurring spend %+v", spend)
+	}
+
+	existing := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	action, err = payload.DecodePayload(&contractAbi, pack(t, &contractAbi, "proposalSecurityReplace", existing, "member", recipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	council := action.SecurityCouncil
+	if action.Type != payload.ActionSecurityReplace || council.ID != "member" || council.Address != recipient || len(council.KickedAddresses) != 1 || council.KickedAddresses[0] != existing {
+		t.Errorf("Incorrect security council change %+v", council)
+	}
+
+}
@@ -0,0 +1,237 @@
+package registry
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/contracts"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+const testAbi string = `[{"type":"function","name":"version","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}]`
+
+var (
+	mainnetStorage = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testnetStorage = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	multicaller    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+// Execution client stand-in that serves RocketStorage and multicall reads, counting the requests it gets
+type storageClient struct {
+	rocketpool.ExecutionClient
+	t            *testing.T
+	storageAbi   abi.ABI
+	multicallAbi abi.ABI
+	encodedAbi   string
+	delay        time.Duration
+	storageCalls int32
+	multicalls   int32
+}
+
+func newStorageClient(t *testing.T, delay time.Duration) *storageClient {
+	storageAbi, err := abi.JSON(strings.NewReader(contracts.RocketStorageABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall.MulticallABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedAbi, err := rocketpool.EncodeAbiStr(testAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &storageClient{
+		t:            t,
+		storageAbi:   storageAbi,
+		multicallAbi: multicallAbi,
+		encodedAbi:   encodedAbi,
+		delay:        delay,
+	}
+}
+
+// Get the address a fake storage contract holds for a contract name
+func contractAddress(storage common.Address, contractName string) common.Address {
+	return common.BytesToAddress(crypto.Keccak256(storage.Bytes(), []byte(contractName)))
+}
+
+func (c *storageClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if *call.To == multicaller {
+		atomic.AddInt32(&c.multicalls, 1)
+		return c.multicall(call.Data)
+	}
+	atomic.AddInt32(&c.storageCalls, 1)
+	time.Sleep(c.delay)
+	return c.storageCall(*call.To, call.Data)
+}
+
+// Serve a RocketStorage call; contract names are matched by their storage keys
+func (c *storageClient) storageCall(storage common.Address, data []byte) ([]byte, error) {
+	method, err := c.storageAbi.MethodById(data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	key := common.Hash(args[0].([32]byte))
+	for _, contractName := range []string{"rocketNetworkPrices", "rocketNetworkBalances", "rocketNodeManager"} {
+		switch {
+		case method.Name == "getAddress" && key == crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)):
+			return method.Outputs.Pack(contractAddress(storage, contractName))
+		case method.Name == "getString" && key == crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)):
+			return method.Outputs.Pack(c.encodedAbi)
+		}
+	}
+	c.t.Fatalf("unexpected storage call %s(%s)", method.Name, key.Hex())
+	return nil, nil
+}
+
+// Serve a Multicall2 tryAggregate call by running each of its storage calls
+func (c *storageClient) multicall(data []byte) ([]byte, error) {
+	method, err := c.multicallAbi.MethodById(data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	calls := *abi.ConvertType(args[1], new([]multicall.MultiCall)).(*[]multicall.MultiCall)
+	type result struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	}
+	results := make([]result, len(calls))
+	for i, call := range calls {
+		returnData, err := c.storageCall(call.Target, call.CallData)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		results[i] = result{Success: true, ReturnData: returnData}
+	}
+	return method.Outputs.Pack(results)
+}
+
+func TestConcurrentLookupsShareRequests(t *testing.T) {
+
+	client := newStorageClient(t, 50*time.Millisecond)
+	rp, err := rocketpool.NewRocketPool(client, mainnetStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Look the same contract up from many goroutines at once
+	var wg sync.WaitGroup
+	contracts := make([]*rocketpool.Contract, 20)
+	errs := make([]error, len(contracts))
+	for i := range contracts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			contracts[i], errs[i] = rp.GetContract("rocketNetworkPrices", nil)
+		}(i)
+	}
+	wg.Wait()
+	for i := range contracts {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if contracts[i] != contracts[0] {
+			t.Errorf("Lookup %d returned a different contract instance", i)
+		}
+	}
+
+	// One address and one ABI request should have been made
+	if calls := atomic.LoadInt32(&client.storageCalls); calls != 2 {
+		t.Errorf("Incorrect storage call count: expected 2, got %d", calls)
+	}
+
+}
+
+func TestInstancesAreIndependent(t *testing.T) {
+
+	client := newStorageClient(t, 0)
+	mainnet, err := rocketpool.NewRocketPool(client, mainnetStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testnet, err := rocketpool.NewRocketPool(client, testnetStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainnetContract, err := mainnet.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testnetContract, err := testnet.GetContract("rocketNodeManager", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *mainnetContract.Address != contractAddress(mainnetStorage, "rocketNodeManager") {
+		t.Errorf("Incorrect mainnet address %s", mainnetContract.Address.Hex())
+	}
+	if *testnetContract.Address != contractAddress(testnetStorage, "rocketNodeManager") {
+		t.Errorf("Incorrect testnet address %s", testnetContract.Address.Hex())
+	}
+
+}
+
+func TestPreload(t *testing.T) {
+
+	client := newStorageClient(t, 0)
+	rp, err := rocketpool.NewRocketPool(client, mainnetStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multicallAddress := multicaller
+	rp.MulticallAddress = &multicallAddress
+
+	// Preload the contracts in a single multicall
+	names := []string{"rocketNetworkPrices", "rocketNetworkBalances", "rocketNodeManager"}
+	if err := rp.Preload(names...); err != nil {
+		t.Fatal(err)
+	}
+	if calls := atomic.LoadInt32(&client.multicalls); calls != 1 {
+		t.Errorf("Incorrect multicall count: expected 1, got %d", calls)
+	}
+
+	// Loading them afterwards must hit the cache
+	for _, contractName := range names {
+		contract, err := rp.GetContract(contractName, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *contract.Address != contractAddress(mainnetStorage, contractName) {
+			t.Errorf("Incorrect %s address %s", contractName, contract.Address.Hex())
+		}
+		if _, exists := contract.ABI.Methods["version"]; !exists {
+			t.Errorf("%s ABI is missing its method", contractName)
+		}
+	}
+	if calls := atomic.LoadInt32(&client.storageCalls); calls != 0 {
+		t.Errorf("Incorrect storage call count after preloading: expected 0, got %d", calls)
+	}
+
+	// Preloading cached contracts is a no-op
+	if err := rp.Preload(names...); err != nil {
+		t.Fatal(err)
+	}
+	if calls := atomic.LoadInt32(&client.multicalls); calls != 1 {
+		t.Errorf("Cached contracts were preloaded again")
+	}
+
+}
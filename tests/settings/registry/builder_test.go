@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestBuilderArgs(t *testing.T) {
+
+	builder := settings.NewProposalBuilder(nil).
+		SetUint(psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, eth.EthToWei(0.1)).
+		SetBool(psettings.NodeSettingsContractName, psettings.VacantMinipoolsEnabledSettingPath, false).
+		SetString(psettings.ProposalsSettingsContractName, psettings.VotePhase1TimeSettingPath, "336h")
+	if err := builder.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	contractNames, settingPaths, settingTypes, values, err := builder.GetArgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contractNames) != 3 || contractNames[1] != psettings.NodeSettingsContractName || settingPaths[2] != psettings.VotePhase1TimeSettingPath {
+		t.Errorf("Incorrect contracts and paths: %v %v", contractNames, settingPaths)
+	}
+	if settingTypes[0] != types.ProposalSettingType_Uint256 || settingTypes[1] != types.ProposalSettingType_Bool {
+		t.Errorf("Incorrect setting types: %v", settingTypes)
+	}
+	if values[2].(*big.Int).Int64() != 1209600 {
+		t.Errorf("Incorrect parsed duration %v", values[2])
+	}
+
+	summary := builder.Summary()
+	expected := []string{
+		"Set rocketDAOProtocolSettingsNetwork/network.node.fee.target to 10%",
+		"Set rocketDAOProtocolSettingsNode/node.vacant.minipools.enabled to false (the security council can also change this setting)",
+		"Set rocketDAOProtocolSettingsProposals/proposal.vote.phase1.time to 336h0m0s",
+	}
+	if summary != strings.Join(expected, "\n") {
+		t.Errorf("Incorrect summary:\n%s", summary)
+	}
+
+}
+
+func TestBuilderErrors(t *testing.T) {
+
+	if err := settings.NewProposalBuilder(nil).Validate(); err == nil {
+		t.Error("Empty proposal was accepted")
+	}
+
+	atlas := rocketpool.NewCapabilities(version.Must(version.NewVersion("1.2.0")))
+	builder := settings.NewProposalBuilder(atlas).
+		SetUint(psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, eth.EthToWei(0.5)).         // Out of bounds
+		SetBool(psettings.NetworkSettingsContractName, psettings.NodeConsensusThresholdSettingPath, true).             // Wrong type
+		SetUint(tnsettings.MembersSettingsContractName, tnsettings.QuorumSettingPath, eth.EthToWei(0.5)).              // Oracle DAO setting
+		SetUint(psettings.ProposalsSettingsContractName, psettings.ProposalQuorumSettingPath, eth.EthToWei(0.2)).      // Not in Atlas
+		SetBool(psettings.DepositSettingsContractName, psettings.DepositEnabledSettingPath, true).                     // Valid
+		SetBool(psettings.DepositSettingsContractName, psettings.DepositEnabledSettingPath, false).                    // Duplicate
+		SetString(psettings.NetworkSettingsContractName, psettings.SubmitBalancesFrequencySettingPath, "not a number") // Unparseable
+	err := builder.Validate()
+	if err == nil {
+		t.Fatal("Invalid proposal was accepted")
+	}
+	if !strings.Contains(err.Error(), "has 6 errors") {
+		t.Errorf("Not every error was reported: %s", err)
+	}
+	if len(builder.GetChanges()) != 1 {
+		t.Errorf("Incorrect number of valid changes: %d", len(builder.GetChanges()))
+	}
+	if _, _, _, _, err := builder.GetArgs(); err == nil {
+		t.Error("Got arguments for an invalid proposal")
+	}
+
+}
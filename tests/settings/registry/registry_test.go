@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+func TestRegistryIsConsistent(t *testing.T) {
+
+	seen := map[string]bool{}
+	for _, setting := range settings.GetSettings() {
+		if seen[setting.String()] {
+			t.Errorf("Setting %s is registered more than once", setting.String())
+		}
+		seen[setting.String()] = true
+		if setting.Getter == "" || setting.Description == "" || len(setting.Daos) == 0 {
+			t.Errorf("Setting %s is missing metadata", setting.String())
+		}
+		if setting.ChangeableBy(settings.DaoSecurityCouncil) != (setting.SecurityNamespace != "") {
+			t.Errorf("Setting %s has an inconsistent security council namespace", setting.String())
+		}
+		if setting.Type == types.ProposalSettingType_Bool && setting.Unit != settings.UnitNone {
+			t.Errorf("Boolean setting %s has unit %s", setting.String(), setting.Unit)
+		}
+	}
+
+	if len(settings.GetSettingsForDao(settings.DaoSecurityCouncil)) != 13 {
+		t.Errorf("Incorrect number of security council settings: %d", len(settings.GetSettingsForDao(settings.DaoSecurityCouncil)))
+	}
+	if len(settings.GetContractSettings(tnsettings.MinipoolSettingsContractName)) != 5 {
+		t.Error("Incorrect number of Oracle DAO minipool settings")
+	}
+
+}
+
+func TestLookup(t *testing.T) {
+
+	setting, err := settings.FindSetting(psettings.NodeConsensusThresholdSettingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setting.ContractName != psettings.NetworkSettingsContractName || setting.Getter != "getNodeConsensusThreshold" || setting.Unit != settings.UnitFraction {
+		t.Errorf("Incorrect setting %+v", setting)
+	}
+
+	// Paths shared by several contracts need the contract name
+	if _, err := settings.FindSetting(psettings.ExecuteTimeSettingPath); err == nil {
+		t.Error("Found an ambiguous setting by path")
+	}
+	setting, err = settings.GetSetting(tnsettings.ProposalsSettingsContractName, tnsettings.ExecuteTimeSettingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !setting.ChangeableBy(settings.DaoOracle) || setting.ChangeableBy(settings.DaoProtocol) {
+		t.Errorf("Incorrect DAOs for %s: %v", setting.String(), setting.Daos)
+	}
+	setting, err = settings.FindSetting(psettings.SubmitPricesEnabledSettingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !setting.ChangeableBy(settings.DaoSecurityCouncil) {
+		t.Errorf("Incorrect DAOs for %s: %v", setting.String(), setting.Daos)
+	}
+	if _, err := settings.GetSetting(psettings.NetworkSettingsContractName, "network.unknown"); err == nil {
+		t.Error("Found an unknown setting")
+	}
+
+	// Settings are only available in the releases that have them
+	setting, _ = settings.GetSetting(psettings.ProposalsSettingsContractName, psettings.ProposalQuorumSettingPath)
+	if setting.IsAvailable(rocketpool.NewCapabilities(version.Must(version.NewVersion("1.2.0")))) {
+		t.Error("Houston setting is available on Atlas")
+	}
+	if !setting.IsAvailable(rocketpool.NewCapabilities(version.Must(version.NewVersion("1.3.0")))) {
+		t.Error("Houston setting isn't available on Houston")
+	}
+
+}
+
+func TestValidate(t *testing.T) {
+
+	fee, _ := settings.GetSetting(psettings.NetworkSettingsContractName, psettings.MinimumNodeFeeSettingPath)
+	if err := fee.Validate(eth.EthToWei(0.1)); err != nil {
+		t.Errorf("Valid node fee was rejected: %s", err)
+	}
+	if err := fee.Validate(eth.EthToWei(0.04)); err == nil {
+		t.Error("Node fee below the contract's minimum was accepted")
+	}
+	if err := fee.Validate(eth.EthToWei(0.25)); err == nil {
+		t.Error("Node fee above the contract's maximum was accepted")
+	}
+	if err := fee.Validate(true); err == nil {
+		t.Error("Boolean value was accepted for a uint setting")
+	}
+	if err := fee.Validate(big.NewInt(-1)); err == nil {
+		t.Error("Negative value was accepted")
+	}
+
+	// The proposal block age must be strictly above 128 blocks
+	blockAge, _ := settings.FindSetting(psettings.ProposalMaxBlockAgeSettingPath)
+	if err := blockAge.Validate(big.NewInt(128)); err == nil {
+		t.Error("Proposal block age of 128 was accepted")
+	}
+	if err := blockAge.Validate(big.NewInt(129)); err != nil {
+		t.Errorf("Valid proposal block age was rejected: %s", err)
+	}
+
+	enabled, _ := settings.FindSetting(psettings.DepositEnabledSettingPath)
+	if err := enabled.Validate(false); err != nil {
+		t.Errorf("Valid boolean was rejected: %s", err)
+	}
+	if err := enabled.Validate(common.Address{}); err == nil {
+		t.Error("Address value was accepted for a bool setting")
+	}
+
+}
+
+func TestFormatAndParse(t *testing.T) {
+
+	tests := []struct {
+		contractName string
+		path         string
+		input        string
+		raw          string
+		formatted    string
+	}{
+		{psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, "14%", "140000000000000000", "14%"},
+		{psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath, "0.051", "51000000000000000", "5.1%"},
+		{psettings.ProposalsSettingsContractName, psettings.VotePhase1TimeSettingPath, "168h", "604800", "168h0m0s"},
+		{psettings.ProposalsSettingsContractName, psettings.VotePhase1TimeSettingPath, "604800", "604800", "168h0m0s"},
+		{psettings.ProposalsSettingsContractName, psettings.ProposalBondSettingPath, "100.5", "100500000000000000000", "100.5 RPL"},
+		{psettings.DepositSettingsContractName, psettings.MinimumDepositSettingPath, "0.01 ETH", "10000000000000000", "0.01 ETH"},
+		{psettings.ProposalsSettingsContractName, psettings.ProposalMaxBlockAgeSettingPath, "1024", "1024", "1024 blocks"},
+	}
+	for _, test := range tests {
+		setting, err := settings.GetSetting(test.contractName, test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := setting.Parse(test.input)
+		if err != nil {
+			t.Errorf("Error parsing %s for %s: %s", test.input, setting.String(), err)
+			continue
+		}
+		if value.(*big.Int).String() != test.raw {
+			t.Errorf("Incorrect value for %s: expected %s, got %s", test.input, test.raw, value.(*big.Int).String())
+		}
+		if formatted := setting.Format(value); formatted != test.formatted {
+			t.Errorf("Incorrect formatting for %s: expected %s, got %s", test.input, test.formatted, formatted)
+		}
+	}
+
+	fee, _ := settings.GetSetting(psettings.NetworkSettingsContractName, psettings.TargetNodeFeeSettingPath)
+	if _, err := fee.Parse("0.0000000000000000001"); err == nil {
+		t.Error("Parsed a fraction with too many decimal places")
+	}
+	enabled, _ := settings.FindSetting(psettings.SubmitPricesEnabledSettingPath)
+	if value, err := enabled.Parse("true"); err != nil || value != true {
+		t.Errorf("Incorrect boolean parse: %v, %v", value, err)
+	}
+
+}
+
+func TestRegistryCoversSnapshot(t *testing.T) {
+
+	// The rewards claimer percentages are set together by a dedicated proposal rather than by path
+	unregistered := map[string]bool{
+		"getRewardsClaimersPercTotal":       true,
+		"getRewardsClaimersNodePerc":        true,
+		"getRewardsClaimersTrustedNodePerc": true,
+		"getRewardsClaimersProtocolPerc":    true,
+		"getRewardsClaimersTimeUpdated":     true,
+	}
+
+	// Every setting in a snapshot has a registry entry introduced with the same feature
+	for _, method := range state.GetSettingMethods() {
+		if method.ContractName == psettings.RewardsSettingsContractName && unregistered[method.Method] {
+			continue
+		}
+		var setting *settings.Setting
+		for _, contractSetting := range settings.GetContractSettings(method.ContractName) {
+			if contractSetting.Getter == method.Method {
+				setting = contractSetting
+			}
+		}
+		if setting == nil {
+			t.Errorf("Setting %s.%s isn't registered", method.ContractName, method.Method)
+			continue
+		}
+		if setting.Feature != method.Feature {
+			t.Errorf("Setting %s.%s is registered with feature %q but read with feature %q", method.ContractName, method.Method, setting.Feature, method.Feature)
+		}
+	}
+
+}
@@ -0,0 +1,33 @@
+package settingsdetails
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+func TestDiffSettings(t *testing.T) {
+
+	before, _ := loadSettings(t, "1.3.0")
+	after, _ := loadSettings(t, "1.3.0")
+	after.ElBlockNumber++
+	if changes := state.DiffSettingsDetails(before, after); len(changes) != 0 {
+		t.Errorf("Identical settings have changes: %v", changes)
+	}
+
+	// Each changed setting is one change, compared by its raw value
+	after.Network.TargetNodeFee = state.FractionSetting{Raw: big.NewInt(100000), Value: 100000e-18}
+	after.Node.VacantMinipoolsEnabled = false
+	changes := state.DiffSettingsDetails(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("Incorrect number of changes: %v", changes)
+	}
+	if changes[0].Entity != state.EntitySettings || changes[0].Field != "Network.TargetNodeFee" || changes[0].NewValue.(state.FractionSetting).Raw.Int64() != 100000 {
+		t.Errorf("Incorrect setting change %+v", changes[0])
+	}
+	if changes[1].Field != "Node.VacantMinipoolsEnabled" || changes[1].NewValue != false {
+		t.Errorf("Incorrect setting change %+v", changes[1])
+	}
+
+}
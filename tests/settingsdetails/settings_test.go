@@ -0,0 +1,313 @@
+package settingsdetails
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+var (
+	storageAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	multicaller    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+// Settings that fail when read, as if they were missing from the deployed contract
+var missingSettings = map[string]bool{
+	"getMaximumCount": true,
+}
+
+// Execution client stand-in that serves multicall and settings reads
+// Every settings contract shares one ABI; numeric settings return one day in seconds and boolean settings return true
+type settingsClient struct {
+	rocketpool.ExecutionClient
+	t             *testing.T
+	multicallAbi  abi.ABI
+	settingsAbi   abi.ABI
+	multicalls    int
+	contractCalls map[common.Address]int
+}
+
+func newSettingsClient(t *testing.T) *settingsClient {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall.MulticallABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build an ABI with every getter the loader reads
+	boolMethods := []string{"getCreateLotEnabled", "getBidOnLotEnabled", "getDepositEnabled", "getAssignDepositsEnabled", "getSubmitWithdrawableEnabled",
+		"getBondReductionEnabled", "getSubmitBalancesEnabled", "getSubmitPricesEnabled", "getSubmitRewardsEnabled", "getRegistrationEnabled",
+		"getSmoothingPoolRegistrationEnabled", "getVacantMinipoolsEnabled", "getScrubPenaltyEnabled"}
+	uintMethods := []string{"getLotMinimumEthValue", "getLotMaximumEthValue", "getLotDuration", "getStartingPriceRatio", "getReservePriceRatio",
+		"getMinimumDeposit", "getMaximumDepositPoolSize", "getMaximumDepositAssignments", "getMaximumDepositSocialisedAssignments", "getDepositFee",
+		"getInflationIntervalRate", "getInflationIntervalStartTime", "getLaunchTimeout", "getMaximumCount", "getUserDistributeWindowStart",
+		"getUserDistributeWindowLength", "getNodeConsensusThreshold", "getSubmitBalancesFrequency", "getSubmitPricesFrequency", "getMinimumNodeFee",
+		"getTargetNodeFee", "getMaximumNodeFee", "getNodeFeeDemandRange", "getTargetRethCollateralRate", "getNodePenaltyThreshold", "getPerPenaltyRate",
+		"getMinimumPerMinipoolStake", "getMaximumPerMinipoolStake", "getVotePhase1Time", "getVotePhase2Time", "getVoteDelayTime", "getExecuteTime",
+		"getProposalBond", "getChallengeBond", "getChallengePeriod", "getProposalQuorum", "getProposalVetoQuorum", "getProposalMaxBlockAge",
+		"getRewardsClaimersNodePerc", "getRewardsClaimersTrustedNodePerc", "getRewardsClaimersProtocolPerc", "getRewardsClaimersTimeUpdated",
+		"getRewardsClaimersPercTotal", "getRewardsClaimIntervalTime", "getQuorum", "getLeaveTime", "getVoteTime", "getActionTime", "getRPLBond",
+		"getMinipoolUnbondedMax", "getMinipoolUnbondedMinFee", "getChallengeCooldown", "getChallengeWindow", "getChallengeCost", "getScrubPeriod",
+		"getPromotionScrubPeriod", "getBondReductionWindowStart", "getBondReductionWindowLength", "getCooldownTime"}
+	entries := []string{}
+	for _, method := range boolMethods {
+		entries = append(entries, fmt.Sprintf(`{"type":"function","name":"%s","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bool"}]}`, method))
+	}
+	for _, method := range uintMethods {
+		entries = append(entries, fmt.Sprintf(`{"type":"function","name":"%s","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}`, method))
+	}
+	settingsAbi, err := abi.JSON(strings.NewReader("[" + strings.Join(entries, ",") + "]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &settingsClient{
+		t:             t,
+		multicallAbi:  multicallAbi,
+		settingsAbi:   settingsAbi,
+		contractCalls: map[common.Address]int{},
+	}
+}
+
+// Get the address of a fake settings contract
+func contractAddress(contractName string) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(contractName)))
+}
+
+func (c *settingsClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if *call.To != multicaller {
+		c.t.Fatalf("unexpected direct call to %s", call.To.Hex())
+	}
+	c.multicalls++
+	method, err := c.multicallAbi.MethodById(call.Data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	calls := *abi.ConvertType(args[1], new([]multicall.MultiCall)).(*[]multicall.MultiCall)
+	type result struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	}
+	results := make([]result, len(calls))
+	for i, call := range calls {
+		c.contractCalls[call.Target]++
+		returnData, success := c.settingCall(call.CallData)
+		results[i] = result{Success: success, ReturnData: returnData}
+	}
+	return method.Outputs.Pack(results)
+}
+
+// Serve a settings getter
+func (c *settingsClient) settingCall(data []byte) ([]byte, bool) {
+	method, err := c.settingsAbi.MethodById(data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if missingSettings[method.Name] {
+		return nil, false
+	}
+	var returnData []byte
+	if method.Outputs[0].Type.T == abi.BoolTy {
+		returnData, err = method.Outputs.Pack(true)
+	} else {
+		returnData, err = method.Outputs.Pack(big.NewInt(86400))
+	}
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return returnData, true
+}
+
+// Bind a fake settings contract with the given ABI
+func (c *settingsClient) makeContract(contractName string, contractAbi abi.ABI) *rocketpool.Contract {
+	address := contractAddress(contractName)
+	return &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, contractAbi, c, c, c),
+		Address:  &address,
+		ABI:      &contractAbi,
+		Client:   c,
+		Name:     contractName,
+	}
+}
+
+// Load the settings as they'd be at a protocol version
+func loadSettings(t *testing.T, protocolVersion string) (*state.SettingsDetails, *settingsClient) {
+	details, client, err := tryLoadSettings(t, protocolVersion, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return details, client
+}
+
+// Load the settings as they'd be at a protocol version, returning any loader error
+// The network contracts can be modified before the settings are loaded
+func tryLoadSettings(t *testing.T, protocolVersion string, modify func(*settingsClient, *state.NetworkContracts)) (*state.SettingsDetails, *settingsClient, error) {
+	client := newSettingsClient(t)
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc, err := multicall.NewMultiCaller(client, multicaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	networkVersion := version.Must(version.NewVersion(protocolVersion))
+	networkContracts := &state.NetworkContracts{
+		Multicaller:   mc,
+		ElBlockNumber: big.NewInt(18000000),
+		Version:       networkVersion,
+		Capabilities:  rocketpool.NewCapabilities(networkVersion),
+		RocketStorage: rp.RocketStorageContract,
+
+		RocketDAOProtocolSettingsAuction:      client.makeContract("rocketDAOProtocolSettingsAuction", client.settingsAbi),
+		RocketDAOProtocolSettingsDeposit:      client.makeContract("rocketDAOProtocolSettingsDeposit", client.settingsAbi),
+		RocketDAOProtocolSettingsInflation:    client.makeContract("rocketDAOProtocolSettingsInflation", client.settingsAbi),
+		RocketDAOProtocolSettingsMinipool:     client.makeContract("rocketDAOProtocolSettingsMinipool", client.settingsAbi),
+		RocketDAOProtocolSettingsNetwork:      client.makeContract("rocketDAOProtocolSettingsNetwork", client.settingsAbi),
+		RocketDAOProtocolSettingsNode:         client.makeContract("rocketDAOProtocolSettingsNode", client.settingsAbi),
+		RocketDAOProtocolSettingsRewards:      client.makeContract("rocketDAOProtocolSettingsRewards", client.settingsAbi),
+		RocketDAOProtocolSettingsProposals:    client.makeContract("rocketDAOProtocolSettingsProposals", client.settingsAbi),
+		RocketDAOProtocolSettingsSecurity:     client.makeContract("rocketDAOProtocolSettingsSecurity", client.settingsAbi),
+		RocketDAONodeTrustedSettingsMembers:   client.makeContract("rocketDAONodeTrustedSettingsMembers", client.settingsAbi),
+		RocketDAONodeTrustedSettingsMinipool:  client.makeContract("rocketDAONodeTrustedSettingsMinipool", client.settingsAbi),
+		RocketDAONodeTrustedSettingsProposals: client.makeContract("rocketDAONodeTrustedSettingsProposals", client.settingsAbi),
+	}
+	if modify != nil {
+		modify(client, networkContracts)
+	}
+	details, err := state.NewSettingsDetails(rp, networkContracts)
+	return details, client, err
+}
+
+func TestSettingsConversion(t *testing.T) {
+
+	details, client := loadSettings(t, "1.3.0")
+	if client.multicalls != 1 {
+		t.Errorf("Settings took %d multicall round trips instead of 1", client.multicalls)
+	}
+	if details.ElBlockNumber != 18000000 || details.NetworkVersion != "1.3.0" {
+		t.Errorf("Incorrect snapshot header: block %d, version %s", details.ElBlockNumber, details.NetworkVersion)
+	}
+	if !details.Auction.CreateLotEnabled || !details.Node.VacantMinipoolsEnabled {
+		t.Error("Boolean settings weren't loaded")
+	}
+	if details.OracleDaoMinipool.ScrubPeriod.Raw.Int64() != 86400 || details.OracleDaoMinipool.ScrubPeriod.Value != 24*time.Hour {
+		t.Errorf("Incorrect duration setting %+v", details.OracleDaoMinipool.ScrubPeriod)
+	}
+	if details.Deposit.DepositFee.Value != 86400e-18 {
+		t.Errorf("Incorrect fraction setting %+v", details.Deposit.DepositFee)
+	}
+	if details.Deposit.MaximumDepositAssignments.Value != 86400 {
+		t.Errorf("Incorrect count setting %+v", details.Deposit.MaximumDepositAssignments)
+	}
+	if !details.Inflation.StartTime.Value.Equal(time.Unix(86400, 0)) {
+		t.Errorf("Incorrect time setting %+v", details.Inflation.StartTime)
+	}
+	if details.Proposals.VotePhase1Time.Value != 24*time.Hour || details.Security.ActionTime.Value != 24*time.Hour {
+		t.Error("Houston settings weren't loaded")
+	}
+
+	// Settings that can't be read are left empty
+	if details.Minipool.MaximumCount.Raw != nil || details.Minipool.MaximumCount.Value != 0 {
+		t.Errorf("Missing setting was filled in: %+v", details.Minipool.MaximumCount)
+	}
+	if len(details.FailedSettings) != 1 || details.FailedSettings[0] != "rocketDAOProtocolSettingsMinipool.getMaximumCount" {
+		t.Errorf("Incorrect failed settings %v", details.FailedSettings)
+	}
+	if _, err := json.Marshal(details); err != nil {
+		t.Fatal(err)
+	}
+
+}
+
+func TestSettingsVersioning(t *testing.T) {
+
+	details, client := loadSettings(t, "1.2.0")
+
+	// Settings introduced in Houston aren't read on Atlas
+	if client.contractCalls[contractAddress("rocketDAOProtocolSettingsProposals")] != 0 || client.contractCalls[contractAddress("rocketDAOProtocolSettingsSecurity")] != 0 {
+		t.Error("Houston settings contracts were read on Atlas")
+	}
+	if details.Proposals.VotePhase1Time.Raw != nil || details.Network.SubmitPricesFrequency.Raw != nil || details.Rewards.NodeOperatorPercent.Raw != nil {
+		t.Error("Houston settings were loaded on Atlas")
+	}
+
+	// Atlas and earlier settings are still read
+	if !details.Minipool.BondReductionEnabled || details.OracleDaoMinipool.PromotionScrubPeriod.Value != 24*time.Hour || !details.Network.SubmitRewardsEnabled {
+		t.Error("Atlas settings weren't loaded")
+	}
+	if details.Rewards.ClaimIntervalTime.Value != 24*time.Hour {
+		t.Error("Rewards settings weren't loaded")
+	}
+
+}
+
+func TestSettingsFailure(t *testing.T) {
+
+	// A Houston setting that can't be read on Houston is an error rather than an empty value
+	missingSettings["getVotePhase1Time"] = true
+	defer delete(missingSettings, "getVotePhase1Time")
+	if _, _, err := tryLoadSettings(t, "1.3.0", nil); err == nil {
+		t.Error("Settings loaded without a deployed Houston setting")
+	}
+
+	// It isn't read at all before Houston
+	if _, _, err := tryLoadSettings(t, "1.2.0", nil); err != nil {
+		t.Error(err)
+	}
+
+}
+
+func TestSettingsMissingMethod(t *testing.T) {
+
+	// Remove a getter from one contract's ABI, as if it predates the setting
+	removeMethod := func(method string) func(*settingsClient, *state.NetworkContracts) {
+		return func(client *settingsClient, contracts *state.NetworkContracts) {
+			contractAbi := client.settingsAbi
+			contractAbi.Methods = map[string]abi.Method{}
+			for name, abiMethod := range client.settingsAbi.Methods {
+				if name != method {
+					contractAbi.Methods[name] = abiMethod
+				}
+			}
+			contracts.RocketDAOProtocolSettingsAuction = client.makeContract("rocketDAOProtocolSettingsAuction", contractAbi)
+			contracts.RocketDAOProtocolSettingsProposals = client.makeContract("rocketDAOProtocolSettingsProposals", contractAbi)
+		}
+	}
+
+	// A setting without a feature is recorded as failed without being called
+	details, client, err := tryLoadSettings(t, "1.3.0", removeMethod("getLotDuration"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details.FailedSettings) != 2 || details.FailedSettings[0] != "rocketDAOProtocolSettingsAuction.getLotDuration" {
+		t.Errorf("Incorrect failed settings %v", details.FailedSettings)
+	}
+	if client.multicalls != 1 {
+		t.Errorf("Settings took %d multicall round trips instead of 1", client.multicalls)
+	}
+
+	// A setting the protocol version should have is an error
+	if _, _, err := tryLoadSettings(t, "1.3.0", removeMethod("getVotePhase1Time")); err == nil {
+		t.Error("Settings loaded without a deployed Houston setting")
+	}
+
+}
@@ -0,0 +1,180 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/signer"
+
+	"github.com/rocket-pool/rocketpool-go/tests"
+)
+
+func TestUnsignedTxCollector(t *testing.T) {
+
+	// Build a transaction through the collector's transactor
+	chainID := big.NewInt(1337)
+	keySigner, err := signer.NewPrivateKeySignerFromHex(tests.AccountPrivateKeys[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	collector := signer.NewUnsignedTxCollector(keySigner.Address(), chainID)
+	opts, err := collector.GetTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.NoSend {
+		t.Error("Collector transactor would send transactions")
+	}
+	tx := newTestTransaction(chainID)
+	returnedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if returnedTx.Hash() != tx.Hash() {
+		t.Error("Collector modified the transaction")
+	}
+
+	// Check the collected transaction
+	txs := collector.Transactions()
+	if len(txs) != 1 {
+		t.Fatalf("Incorrect collected transaction count %d", len(txs))
+	}
+	unsignedTx := txs[0]
+	if *unsignedTx.To != *tx.To() || unsignedTx.GasLimit != tx.Gas() || unsignedTx.Value.Cmp(tx.Value()) != 0 || !bytes.Equal(unsignedTx.Data, tx.Data()) {
+		t.Error("Collected transaction does not match the built transaction")
+	}
+
+	// The RLP payload must hash to the transaction's signing hash
+	payload, err := unsignedTx.RLP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.Keccak256Hash(payload) != types.LatestSignerForChainID(chainID).Hash(tx) {
+		t.Error("RLP payload does not match the transaction's signing hash")
+	}
+
+	// Sign it "offline" and ingest the result
+	signedTx, err := keySigner.SignTx(context.Background(), tx, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ingestedTx, err := signer.DecodeSignedTransaction(rawTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unsignedTx.VerifySigned(ingestedTx); err != nil {
+		t.Error(err)
+	}
+
+	// Unsigned transactions can't be ingested
+	rawUnsignedTx, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.DecodeSignedTransaction(rawUnsignedTx); err == nil {
+		t.Error("Unsigned transaction was accepted as signed")
+	}
+
+	// Reset
+	collector.Reset()
+	if len(collector.Transactions()) != 0 {
+		t.Error("Collector was not reset")
+	}
+
+}
+
+func TestUnsignedTxCollectorNonces(t *testing.T) {
+
+	// Collect two transactions built with the same pending nonce
+	chainID := big.NewInt(1337)
+	collector := signer.NewUnsignedTxCollector(common.HexToAddress("0x3333333333333333333333333333333333333333"), chainID)
+	for i := 0; i < 2; i++ {
+		if _, err := collector.SignTx(context.Background(), newTestTransaction(chainID), chainID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txs := collector.Transactions()
+	if len(txs) != 2 {
+		t.Fatalf("Incorrect collected transaction count %d", len(txs))
+	}
+	if txs[0].Nonce != 7 || txs[1].Nonce != 8 || txs[1].Transaction().Nonce() != 8 {
+		t.Errorf("Incorrect collected transaction nonces %d, %d", txs[0].Nonce, txs[1].Nonce)
+	}
+
+	// The access list survives serialization
+	txBytes, err := json.Marshal(txs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decodedTx signer.UnsignedTransaction
+	if err := json.Unmarshal(txBytes, &decodedTx); err != nil {
+		t.Fatal(err)
+	}
+	if decodedTx.Transaction().Hash() != txs[1].Transaction().Hash() {
+		t.Error("Decoded transaction does not match the collected transaction")
+	}
+
+	// Nonces start from the built transaction again after a reset
+	collector.Reset()
+	if _, err := collector.SignTx(context.Background(), newTestTransaction(chainID), chainID); err != nil {
+		t.Fatal(err)
+	}
+	if nonce := collector.Transactions()[0].Nonce; nonce != 7 {
+		t.Errorf("Incorrect nonce %d after reset", nonce)
+	}
+
+}
+
+func TestSafeBatch(t *testing.T) {
+
+	chainID := big.NewInt(1)
+	safeAddress := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	collector := signer.NewUnsignedTxCollector(safeAddress, chainID)
+	if _, err := collector.SignTx(context.Background(), newTestTransaction(chainID), chainID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the batch
+	batch, err := signer.CreateSafeBatch("Confirm RPL withdrawal address", "", safeAddress, chainID, collector.Transactions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchBytes, err := json.Marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the serialized form
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(batchBytes, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["chainId"] != "1" {
+		t.Errorf("Incorrect batch chain ID %v", decoded["chainId"])
+	}
+	transactions := decoded["transactions"].([]interface{})
+	if len(transactions) != 1 {
+		t.Fatalf("Incorrect batch transaction count %d", len(transactions))
+	}
+	batchTx := transactions[0].(map[string]interface{})
+	if batchTx["value"] != "1000000000000000000" || batchTx["data"] != "0x0102" || batchTx["to"] != "0x1111111111111111111111111111111111111111" {
+		t.Errorf("Incorrect batch transaction %v", batchTx)
+	}
+
+	// Transactions built for another address can't be added to the Safe's batch
+	if _, err := signer.CreateSafeBatch("", "", common.Address{}, chainID, collector.Transactions()); err == nil {
+		t.Error("Batch accepted a transaction for another sender")
+	}
+
+}
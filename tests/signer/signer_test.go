@@ -0,0 +1,215 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/rocketpool-go/signer"
+
+	"github.com/rocket-pool/rocketpool-go/tests"
+	"github.com/rocket-pool/rocketpool-go/tests/testutils/accounts"
+)
+
+// Stand-in for a Web3Signer instance holding a single key
+type remoteSignerService struct {
+	signer    *signer.PrivateKeySigner
+	chainID   *big.Int
+	tipChange *big.Int // Added to the priority fee, to simulate a misbehaving signer
+}
+
+type remoteSignerArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big      `json:"value"`
+	Data                 hexutil.Bytes     `json:"data"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	AccessList           *types.AccessList `json:"accessList"`
+}
+
+func (s *remoteSignerService) SignTransaction(args remoteSignerArgs) (hexutil.Bytes, error) {
+	if args.From != s.signer.Address() {
+		return nil, errors.New("unknown account")
+	}
+	tip := args.MaxPriorityFeePerGas.ToInt()
+	if s.tipChange != nil {
+		tip = new(big.Int).Add(tip, s.tipChange)
+	}
+	var accessList types.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    s.chainID,
+		Nonce:      uint64(args.Nonce),
+		GasTipCap:  tip,
+		GasFeeCap:  args.MaxFeePerGas.ToInt(),
+		Gas:        uint64(args.Gas),
+		To:         args.To,
+		Value:      args.Value.ToInt(),
+		Data:       args.Data,
+		AccessList: accessList,
+	})
+	signedTx, err := s.signer.SignTx(context.Background(), tx, s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	return signedTx.MarshalBinary()
+}
+
+func newTestTransaction(chainID *big.Int) *types.Transaction {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		Data:      []byte{0x01, 0x02},
+		AccessList: types.AccessList{{
+			Address:     to,
+			StorageKeys: []common.Hash{common.BigToHash(big.NewInt(1))},
+		}},
+	})
+}
+
+func TestPrivateKeySigner(t *testing.T) {
+
+	chainID := big.NewInt(1337)
+	s, err := signer.NewPrivateKeySignerFromHex(tests.AccountPrivateKeys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := accounts.GetAccount(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Address() != account.Address {
+		t.Errorf("Incorrect signer address %s", s.Address().Hex())
+	}
+
+	// Sign through a transactor
+	opts, err := signer.NewTransactor(s, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTx, err := opts.Signer(opts.From, newTestTransaction(chainID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx); err != nil {
+		t.Error(err)
+	} else if sender != account.Address {
+		t.Errorf("Incorrect transaction sender %s", sender.Hex())
+	}
+
+	// Signing for another address must be refused
+	if _, err := opts.Signer(common.Address{}, newTestTransaction(chainID)); err == nil {
+		t.Error("Signer signed a transaction for another address")
+	}
+
+}
+
+func TestRemoteSigner(t *testing.T) {
+
+	// Start the stand-in signer
+	chainID := big.NewInt(1337)
+	keySigner, err := signer.NewPrivateKeySignerFromHex(tests.AccountPrivateKeys[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &remoteSignerService{signer: keySigner, chainID: chainID}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	// Sign a transaction remotely
+	remoteSigner, err := signer.NewRemoteSigner(httpServer.URL, keySigner.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(remoteSigner.Close)
+	tx := newTestTransaction(chainID)
+	signedTx, err := remoteSigner.SignTx(context.Background(), tx, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Nonce() != tx.Nonce() || signedTx.Gas() != tx.Gas() || signedTx.Value().Cmp(tx.Value()) != 0 {
+		t.Error("Remotely signed transaction does not match the request")
+	}
+	if len(signedTx.AccessList()) != 1 || signedTx.AccessList()[0].Address != *tx.To() {
+		t.Errorf("Access list was not forwarded: %v", signedTx.AccessList())
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx); err != nil {
+		t.Error(err)
+	} else if sender != keySigner.Address() {
+		t.Errorf("Incorrect transaction sender %s", sender.Hex())
+	}
+
+	// A remote key that doesn't match the configured address must be rejected
+	otherAccount, err := accounts.GetAccount(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongSigner, err := signer.NewRemoteSigner(httpServer.URL, otherAccount.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(wrongSigner.Close)
+	if _, err := wrongSigner.SignTx(context.Background(), tx, chainID); err == nil {
+		t.Error("Remote signer signed a transaction for an unknown account")
+	}
+
+	// Changed fees must be rejected
+	service.tipChange = big.NewInt(1)
+	if _, err := remoteSigner.SignTx(context.Background(), tx, chainID); err == nil {
+		t.Error("Remote signer changed the priority fee")
+	}
+	service.tipChange = nil
+
+	// Signing uses the transactor's context
+	opts, err := signer.NewTransactor(remoteSigner, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts.Context = ctx
+	if _, err := opts.Signer(opts.From, tx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Signing ignored the transactor's context: %v", err)
+	}
+
+}
+
+func TestReadOnlySigner(t *testing.T) {
+
+	chainID := big.NewInt(1337)
+	address := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	opts, err := signer.NewTransactor(signer.NewReadOnlySigner(address), chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.From != address {
+		t.Errorf("Incorrect transactor address %s", opts.From.Hex())
+	}
+	if _, err := opts.Signer(address, newTestTransaction(chainID)); !errors.Is(err, signer.ErrReadOnly) {
+		t.Errorf("Expected read-only error, got %v", err)
+	}
+
+}
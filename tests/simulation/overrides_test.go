@@ -0,0 +1,109 @@
+package simulation
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+func TestTraceStateChangesWithRpc(t *testing.T) {
+
+	client, service := newDebugClient(t)
+	to := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	changes, err := rocketpool.TraceStateChangesWithRpc(context.Background(), client, ethereum.CallMsg{To: &to}, big.NewInt(100), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.config["tracer"] != "prestateTracer" {
+		t.Errorf("Incorrect tracer %v", service.config["tracer"])
+	}
+
+	// Changed and cleared slots are both overridden
+	change := changes[to]
+	if change.Balance == nil || change.Balance.Int64() != 3 {
+		t.Errorf("Incorrect balance %v", change.Balance)
+	}
+	if change.StateDiff[common.BigToHash(big.NewInt(1))] != common.BigToHash(big.NewInt(7)) {
+		t.Error("Changed slot was not overridden")
+	}
+	if value, exists := change.StateDiff[common.BigToHash(big.NewInt(2))]; !exists || value != (common.Hash{}) {
+		t.Error("Cleared slot was not overridden")
+	}
+
+	// Deleted accounts are emptied
+	deleted := changes[common.HexToAddress("0x7777777777777777777777777777777777777777")]
+	if deleted.Balance == nil || deleted.Balance.Sign() != 0 || deleted.Nonce == nil || *deleted.Nonce != 0 {
+		t.Errorf("Deleted account was not emptied: %+v", deleted)
+	}
+
+}
+
+func TestMergeOverrides(t *testing.T) {
+
+	account := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	slot1 := common.BigToHash(big.NewInt(1))
+	slot2 := common.BigToHash(big.NewInt(2))
+	base := rocketpool.StateOverrides{
+		account: {Balance: big.NewInt(1), StateDiff: map[common.Hash]common.Hash{slot1: slot1}},
+	}
+	merged := base.Merge(rocketpool.StateOverrides{
+		account: {StateDiff: map[common.Hash]common.Hash{slot1: slot2, slot2: slot2}},
+	})
+	if merged[account].Balance.Int64() != 1 || merged[account].StateDiff[slot1] != slot2 || merged[account].StateDiff[slot2] != slot2 {
+		t.Errorf("Incorrect merged overrides %+v", merged[account])
+	}
+	if base[account].StateDiff[slot1] != slot1 || len(base[account].StateDiff) != 1 {
+		t.Error("Merging modified the original overrides")
+	}
+
+	// Full state replacements take the later slots
+	merged = base.Merge(rocketpool.StateOverrides{
+		account: {State: map[common.Hash]common.Hash{slot2: slot1}},
+	})
+	if merged[account].StateDiff != nil || merged[account].State[slot2] != slot1 {
+		t.Errorf("Incorrect replaced state %+v", merged[account])
+	}
+
+}
+
+func TestOverrideClient(t *testing.T) {
+
+	// Calls are traced with the client's overrides
+	inner := &traceClient{trace: &rocketpool.CallTrace{Output: []byte{1, 2, 3}}}
+	account := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	client := rocketpool.NewOverrideClient(inner, rocketpool.StateOverrides{account: {Balance: big.NewInt(1)}})
+	output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &account}, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output) != 3 || len(inner.calls) != 1 {
+		t.Errorf("Call was not traced: %v", output)
+	}
+
+	// Reverts become errors
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("Invalid setting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner.trace = &rocketpool.CallTrace{
+		Output: append(hexutil.MustDecode("0x08c379a0"), reason...),
+		Error:  "execution reverted",
+	}
+	_, err = client.CallContract(context.Background(), ethereum.CallMsg{To: &account}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Invalid setting") {
+		t.Errorf("Incorrect revert error %v", err)
+	}
+
+}
@@ -0,0 +1,351 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+const testAbi string = `[
+	{"type":"function","name":"stakeRPL","stateMutability":"nonpayable","inputs":[{"name":"_amount","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"RPLStaked","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]}
+]`
+
+// Execution client stand-in that only supports call tracing
+type traceClient struct {
+	rocketpool.ExecutionClient
+	trace    *rocketpool.CallTrace
+	calls    []ethereum.CallMsg
+	contexts []context.Context
+}
+
+func (c *traceClient) TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides rocketpool.StateOverrides) (*rocketpool.CallTrace, error) {
+	c.calls = append(c.calls, call)
+	c.contexts = append(c.contexts, ctx)
+	return c.trace, nil
+}
+
+func (c *traceClient) TraceStateChanges(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides rocketpool.StateOverrides) (rocketpool.StateOverrides, error) {
+	return nil, fmt.Errorf("state change tracing is not supported")
+}
+
+func newTestContract(t *testing.T, client rocketpool.ExecutionClient) (*rocketpool.Contract, abi.ABI) {
+	parsedAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	return &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsedAbi, client, client, client),
+		Address:  &address,
+		ABI:      &parsedAbi,
+		Client:   client,
+	}, parsedAbi
+}
+
+func TestSimulateEvents(t *testing.T) {
+
+	// Build a trace with an RPLStaked event
+	client := &traceClient{}
+	contract, parsedAbi := newTestContract(t, client)
+	staker := common.HexToAddress("0x5555555555555555555555555555555555555555")
+	data, err := parsedAbi.Events["RPLStaked"].Inputs.NonIndexed().Pack(big.NewInt(100), big.NewInt(1700000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.trace = &rocketpool.CallTrace{
+		GasUsed: 50000,
+		Logs: []types.Log{{
+			Address: *contract.Address,
+			Topics:  []common.Hash{parsedAbi.Events["RPLStaked"].ID, common.BytesToHash(staker.Bytes())},
+			Data:    data,
+		}},
+	}
+
+	// Simulate through the regular transaction path
+	opts, simulation := rocketpool.NewSimulationTransactor(&bind.TransactOpts{From: staker}, nil)
+	if tx, err := contract.Transact(opts, "stakeRPL", big.NewInt(100)); tx != nil || !errors.Is(err, rocketpool.ErrSimulated) {
+		t.Fatalf("Simulated transaction returned %v, %v", tx, err)
+	}
+	results := simulation.Results()
+	if len(results) != 1 {
+		t.Fatalf("Incorrect simulation result count %d", len(results))
+	}
+	result := results[0]
+	if !result.Success || !result.Traced || result.Method != "stakeRPL" || result.GasUsed != 50000 {
+		t.Errorf("Incorrect simulation result %+v", result)
+	}
+	if len(result.Events) != 1 || result.Events[0].Name != "RPLStaked" {
+		t.Fatalf("Incorrect simulated events %+v", result.Events)
+	}
+	if result.Events[0].Values["from"] != staker || result.Events[0].Values["amount"].(*big.Int).Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Incorrect simulated event values %v", result.Events[0].Values)
+	}
+	if len(client.calls) != 1 || client.calls[0].From != staker {
+		t.Error("Simulation did not call the client as the sender")
+	}
+
+}
+
+func TestSimulateRevert(t *testing.T) {
+
+	// Build a trace with an Error(string) revert
+	client := &traceClient{}
+	contract, _ := newTestContract(t, client)
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("Invalid amount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	revertData := append(hexutil.MustDecode("0x08c379a0"), reason...)
+	client.trace = &rocketpool.CallTrace{
+		Output: revertData,
+		Error:  "execution reverted",
+	}
+
+	result, err := contract.Simulate(&bind.TransactOpts{}, nil, "stakeRPL", big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success {
+		t.Error("Reverted simulation reported success")
+	}
+	if result.RevertReason != "Invalid amount" {
+		t.Errorf("Incorrect revert reason %q", result.RevertReason)
+	}
+
+}
+
+func TestSimulateContext(t *testing.T) {
+
+	// Simulations are traced with the caller's context
+	type contextKey struct{}
+	client := &traceClient{trace: &rocketpool.CallTrace{}}
+	contract, _ := newTestContract(t, client)
+	ctx := context.WithValue(context.Background(), contextKey{}, "caller")
+	if _, err := contract.Simulate(&bind.TransactOpts{Context: ctx}, nil, "stakeRPL", big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.contexts) != 1 || client.contexts[0].Value(contextKey{}) != "caller" {
+		t.Error("Simulation did not use the caller's context")
+	}
+
+}
+
+// Stand-in for a node's debug namespace
+type debugService struct {
+	config map[string]interface{}
+}
+
+func (s *debugService) TraceCall(args map[string]interface{}, block string, config map[string]interface{}) (map[string]interface{}, error) {
+	s.config = config
+	if config["tracer"] == "prestateTracer" {
+		return map[string]interface{}{
+			"pre": map[string]interface{}{
+				"0x6666666666666666666666666666666666666666": map[string]interface{}{
+					"balance": "0x1",
+					"storage": map[string]interface{}{
+						"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000005",
+						"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000006",
+					},
+				},
+				"0x7777777777777777777777777777777777777777": map[string]interface{}{
+					"balance": "0x2",
+					"nonce":   1,
+				},
+			},
+			"post": map[string]interface{}{
+				"0x6666666666666666666666666666666666666666": map[string]interface{}{
+					"balance": "0x3",
+					"storage": map[string]interface{}{
+						"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000007",
+					},
+				},
+			},
+		}, nil
+	}
+	return map[string]interface{}{
+		"gasUsed": "0x5208",
+		"output":  "0x",
+		"logs": []map[string]interface{}{
+			{"address": "0x0000000000000000000000000000000000000001", "topics": []string{}, "data": "0x01", "position": "0x1"},
+		},
+		"calls": []map[string]interface{}{
+			{
+				"gasUsed": "0x1",
+				"output":  "0x",
+				"logs":    []map[string]interface{}{{"address": "0x0000000000000000000000000000000000000002", "topics": []string{}, "data": "0x02", "position": "0x0"}},
+			},
+			{
+				"gasUsed": "0x1",
+				"output":  "0x",
+				"error":   "execution reverted",
+				"logs":    []map[string]interface{}{{"address": "0x0000000000000000000000000000000000000003", "topics": []string{}, "data": "0x03", "position": "0x0"}},
+			},
+		},
+	}, nil
+}
+
+// Stand-in for a node's eth namespace, which only runs calls
+type ethService struct {
+	output    hexutil.Bytes
+	revert    hexutil.Bytes
+	block     string
+	overrides *map[string]interface{}
+}
+
+// A revert error with data, as nodes return it
+type revertError struct {
+	data hexutil.Bytes
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data.String() }
+
+func (s *ethService) Call(args map[string]interface{}, block string, overrides *map[string]interface{}) (hexutil.Bytes, error) {
+	s.block = block
+	s.overrides = overrides
+	if s.revert != nil {
+		return nil, &revertError{data: s.revert}
+	}
+	return s.output, nil
+}
+
+// Start a stand-in node with debug and eth namespaces
+func newDebugClient(t *testing.T) (*rpc.Client, *debugService) {
+	client, service, _ := newRpcClient(t)
+	return client, service
+}
+
+// Start a stand-in node with debug and eth namespaces, returning both services
+func newRpcClient(t *testing.T) (*rpc.Client, *debugService, *ethService) {
+	service := &debugService{}
+	eth := &ethService{}
+	server := rpc.NewServer()
+	if err := server.RegisterName("debug", service); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName("eth", eth); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+	client, err := rpc.DialHTTP(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client, service, eth
+}
+
+func TestTraceCallWithRpc(t *testing.T) {
+
+	client, service := newDebugClient(t)
+
+	// Trace with a balance override
+	to := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	trace, err := rocketpool.TraceCallWithRpc(context.Background(), client, ethereum.CallMsg{To: &to}, nil, rocketpool.StateOverrides{
+		to: {Balance: big.NewInt(1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.GasUsed != 21000 {
+		t.Errorf("Incorrect gas used %d", trace.GasUsed)
+	}
+	if _, ok := service.config["stateOverrides"]; !ok {
+		t.Error("State overrides were not sent")
+	}
+
+	// Logs must be in execution order, without the reverted subcall's logs
+	if len(trace.Logs) != 2 {
+		t.Fatalf("Incorrect log count %d", len(trace.Logs))
+	}
+	if trace.Logs[0].Data[0] != 0x02 || trace.Logs[1].Data[0] != 0x01 {
+		t.Errorf("Logs are out of order: %v", trace.Logs)
+	}
+
+}
+
+// Execution client that runs eth_call with state overrides but can't trace
+type overrideCallClient struct {
+	*ethclient.Client
+	rpcClient *rpc.Client
+}
+
+func (c *overrideCallClient) CallContractWithOverrides(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, overrides rocketpool.StateOverrides) ([]byte, error) {
+	return rocketpool.CallContractWithRpc(ctx, c.rpcClient, call, blockNumber, overrides)
+}
+
+func TestSimulateWithCall(t *testing.T) {
+
+	// A plain ethclient simulates with eth_call at the pending block
+	rpcClient, _, eth := newRpcClient(t)
+	contract, _ := newTestContract(t, ethclient.NewClient(rpcClient))
+	eth.output = hexutil.Bytes{0x01, 0x02}
+	result, err := contract.Simulate(&bind.TransactOpts{}, nil, "stakeRPL", big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.Traced || len(result.ReturnData) != 2 || len(result.Events) != 0 {
+		t.Errorf("Incorrect simulation result %+v", result)
+	}
+	if eth.block != "pending" {
+		t.Errorf("Simulation ran at block %s instead of the pending block", eth.block)
+	}
+
+	// Reverts are decoded from the error data
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("Invalid amount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eth.revert = append(hexutil.MustDecode("0x08c379a0"), reason...)
+	result, err = contract.Simulate(&bind.TransactOpts{}, nil, "stakeRPL", big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success || result.RevertReason != "Invalid amount" {
+		t.Errorf("Incorrect reverted simulation result %+v", result)
+	}
+
+	// Overrides need a client that can apply them
+	account := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	overrides := rocketpool.StateOverrides{account: {Balance: big.NewInt(1)}}
+	if _, err := contract.Simulate(&bind.TransactOpts{}, overrides, "stakeRPL", big.NewInt(100)); err == nil || !strings.Contains(err.Error(), "does not support state overrides") {
+		t.Errorf("Incorrect error for overrides on a plain client: %v", err)
+	}
+	eth.revert = nil
+	contract, _ = newTestContract(t, &overrideCallClient{Client: ethclient.NewClient(rpcClient), rpcClient: rpcClient})
+	result, err = contract.Simulate(&bind.TransactOpts{}, overrides, "stakeRPL", big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || eth.overrides == nil || (*eth.overrides)[strings.ToLower(account.Hex())] == nil {
+		t.Errorf("Overrides were not sent with the call: %v", eth.overrides)
+	}
+
+}
@@ -0,0 +1,137 @@
+package statediff
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+var (
+	firstNode      = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	secondNode     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	firstMinipool  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	secondMinipool = common.HexToAddress("0x4444444444444444444444444444444444444444")
+)
+
+// Create a network state at the given block
+func getState(block uint64, rplStake int64, status types.MinipoolStatus, queueTotal int64, withSecondNode bool) *state.NetworkState {
+	networkState := &state.NetworkState{
+		ElBlockNumber: block,
+		NetworkDetails: &state.NetworkDetails{
+			RplPrice:      big.NewInt(5e15),
+			IntervalStart: time.Unix(1690000000, 0),
+			QueueCapacity: minipool.QueueCapacity{Total: big.NewInt(queueTotal), Effective: big.NewInt(0)},
+		},
+		NodeDetails: []state.NativeNodeDetails{
+			{NodeAddress: firstNode, RplStake: big.NewInt(rplStake), TimezoneLocation: "Etc/UTC"},
+		},
+		MinipoolDetails: []state.NativeMinipoolDetails{
+			{MinipoolAddress: firstMinipool, NodeAddress: firstNode, Status: status, NodeFee: big.NewInt(14e16)},
+		},
+	}
+	if withSecondNode {
+		networkState.NodeDetails = append(networkState.NodeDetails, state.NativeNodeDetails{NodeAddress: secondNode, RplStake: big.NewInt(0)})
+		networkState.MinipoolDetails = append(networkState.MinipoolDetails, state.NativeMinipoolDetails{MinipoolAddress: secondMinipool, NodeAddress: secondNode})
+	}
+	networkState.UpdateIndexes()
+	return networkState
+}
+
+func TestDiff(t *testing.T) {
+
+	oldState := getState(100, 1000, types.Prelaunch, 32, false)
+	newState := getState(200, 1500, types.Staking, 64, true)
+	diff := state.DiffNetworkStates(oldState, newState)
+	if diff.FromBlock != 100 || diff.ToBlock != 200 {
+		t.Errorf("Incorrect block range %d-%d", diff.FromBlock, diff.ToBlock)
+	}
+
+	// Equal values in different instances aren't changes
+	expected := []state.Change{
+		{Entity: state.EntityNetwork, Type: state.ChangeModified, Field: "QueueCapacity.Total"},
+		{Entity: state.EntityNode, Address: firstNode, NodeAddress: firstNode, Type: state.ChangeModified, Field: "RplStake"},
+		{Entity: state.EntityNode, Address: secondNode, NodeAddress: secondNode, Type: state.ChangeAdded},
+		{Entity: state.EntityMinipool, Address: firstMinipool, NodeAddress: firstNode, Type: state.ChangeModified, Field: "Status"},
+		{Entity: state.EntityMinipool, Address: secondMinipool, NodeAddress: secondNode, Type: state.ChangeAdded},
+	}
+	if len(diff.Changes) != len(expected) {
+		t.Fatalf("Incorrect change count: expected %d, got %d: %+v", len(expected), len(diff.Changes), diff.Changes)
+	}
+	for i, change := range diff.Changes {
+		want := expected[i]
+		if change.Entity != want.Entity || change.Address != want.Address || change.NodeAddress != want.NodeAddress || change.Type != want.Type || change.Field != want.Field {
+			t.Errorf("Incorrect change %d: expected %+v, got %+v", i, want, change)
+		}
+	}
+	if status := diff.Changes[3].NewValue.(types.MinipoolStatus); status != types.Staking {
+		t.Errorf("Incorrect new status %s", status)
+	}
+
+	// Removals are the reverse of additions
+	reverse := state.DiffNetworkStates(newState, oldState).ForEntity(state.EntityMinipool)
+	if len(reverse.Changes) != 2 || reverse.Changes[1].Type != state.ChangeRemoved {
+		t.Errorf("Incorrect reverse minipool changes: %+v", reverse.Changes)
+	}
+
+}
+
+func TestDiffFilters(t *testing.T) {
+
+	diff := state.DiffNetworkStates(getState(100, 1000, types.Prelaunch, 32, false), getState(200, 1500, types.Staking, 64, true))
+
+	nodeDiff := diff.ForNode(firstNode)
+	if len(nodeDiff.Changes) != 2 {
+		t.Errorf("Incorrect change count for the first node: expected 2, got %d", len(nodeDiff.Changes))
+	}
+	for _, change := range nodeDiff.Changes {
+		if change.NodeAddress != firstNode {
+			t.Errorf("Change for %s included in the first node's changes", change.NodeAddress.Hex())
+		}
+	}
+
+	stakeDiff := diff.ForFields("RplStake").ForNode(firstNode)
+	if len(stakeDiff.Changes) != 1 || stakeDiff.Changes[0].OldValue.(*big.Int).Int64() != 1000 || stakeDiff.Changes[0].NewValue.(*big.Int).Int64() != 1500 {
+		t.Errorf("Incorrect RPL stake changes: %+v", stakeDiff.Changes)
+	}
+
+	if !diff.ForNode(common.Address{0x99}).IsEmpty() {
+		t.Error("Found changes for a node that doesn't exist")
+	}
+
+}
+
+func TestDiffJson(t *testing.T) {
+
+	diff := state.DiffNetworkStates(getState(100, 1000, types.Prelaunch, 32, false), getState(200, 1000, types.Staking, 32, false))
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		FromBlock uint64 `json:"fromBlock"`
+		Changes   []struct {
+			Entity   string `json:"entity"`
+			Field    string `json:"field"`
+			OldValue string `json:"oldValue"`
+			NewValue string `json:"newValue"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.FromBlock != 100 || len(decoded.Changes) != 1 {
+		t.Fatalf("Incorrect JSON diff: %s", data)
+	}
+	change := decoded.Changes[0]
+	if change.Entity != "minipool" || change.Field != "Status" || change.OldValue != "Prelaunch" || change.NewValue != "Staking" {
+		t.Errorf("Incorrect JSON change: %s", data)
+	}
+
+}
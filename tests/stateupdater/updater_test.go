@@ -0,0 +1,218 @@
+package stateupdater
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+const managerAbi string = `[
+	{"type":"event","name":"NodeRegistered","anonymous":false,"inputs":[{"name":"node","type":"address","indexed":true},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"MinipoolCreated","anonymous":false,"inputs":[{"name":"minipool","type":"address","indexed":true},{"name":"node","type":"address","indexed":true},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"MinipoolDestroyed","anonymous":false,"inputs":[{"name":"minipool","type":"address","indexed":true},{"name":"node","type":"address","indexed":true},{"name":"time","type":"uint256","indexed":false}]}
+]`
+
+var (
+	managerAddress     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	tokenAddress       = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	oracleDaoAddress   = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	firstNode          = common.HexToAddress("0x2000000000000000000000000000000000000001")
+	secondNode         = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	newNode            = common.HexToAddress("0x2000000000000000000000000000000000000003")
+	secondDistributor  = common.HexToAddress("0x3000000000000000000000000000000000000002")
+	firstMinipool      = common.HexToAddress("0x4000000000000000000000000000000000000001")
+	secondMinipool     = common.HexToAddress("0x4000000000000000000000000000000000000002")
+	thirdMinipool      = common.HexToAddress("0x4000000000000000000000000000000000000003")
+	newMinipool        = common.HexToAddress("0x4000000000000000000000000000000000000004")
+	shortLivedMinipool = common.HexToAddress("0x4000000000000000000000000000000000000005")
+)
+
+// Create network contracts with a single manager contract for node and minipool events
+func getTestContracts(t *testing.T, networkVersion string) *state.NetworkContracts {
+	parsedAbi, err := abi.JSON(strings.NewReader(managerAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := managerAddress
+	manager := &rocketpool.Contract{Address: &address, ABI: &parsedAbi, Name: "rocketNodeManager"}
+	return &state.NetworkContracts{
+		Version:               version.Must(version.NewVersion(networkVersion)),
+		RocketNodeManager:     manager,
+		RocketMinipoolManager: manager,
+	}
+}
+
+// Create a state with two nodes; the first node has the first and third minipools, the second node has the second
+func getTestState() *state.NetworkState {
+	networkState := &state.NetworkState{
+		NodeDetails: []state.NativeNodeDetails{
+			{NodeAddress: firstNode},
+			{NodeAddress: secondNode, FeeDistributorAddress: secondDistributor},
+		},
+		MinipoolDetails: []state.NativeMinipoolDetails{
+			{MinipoolAddress: firstMinipool, NodeAddress: firstNode},
+			{MinipoolAddress: secondMinipool, NodeAddress: secondNode},
+			{MinipoolAddress: thirdMinipool, NodeAddress: firstNode},
+		},
+	}
+	networkState.UpdateIndexes()
+	return networkState
+}
+
+// Create a log for a manager event
+func getManagerLog(contracts *state.NetworkContracts, event string, addresses ...common.Address) types.Log {
+	topics := []common.Hash{contracts.RocketNodeManager.ABI.Events[event].ID}
+	for _, address := range addresses {
+		topics = append(topics, common.BytesToHash(address.Bytes()))
+	}
+	return types.Log{Address: managerAddress, Topics: topics}
+}
+
+func TestLogAddresses(t *testing.T) {
+
+	// The event ID is skipped even if it looks like an address
+	log := types.Log{
+		Topics: []common.Hash{
+			common.BytesToHash(secondNode.Bytes()),
+			common.BytesToHash(firstNode.Bytes()),
+			common.HexToHash("0xff00000000000000000000000000000000000000000000000000000000000001"),
+		},
+		Data: append(append(
+			common.BytesToHash(secondDistributor.Bytes()).Bytes(),
+			common.BigToHash(big.NewInt(5)).Bytes()...),
+			common.HexToHash("0x0100000000000000000000000000000000000000000000000000000000000000").Bytes()...,
+		),
+	}
+
+	// Topics come first, and small numbers count as addresses
+	addresses := state.GetLogAddresses(log)
+	expected := []common.Address{firstNode, secondDistributor, common.BigToAddress(big.NewInt(5))}
+	if len(addresses) != len(expected) {
+		t.Fatalf("Incorrect addresses: %v", addresses)
+	}
+	for i, address := range expected {
+		if addresses[i] != address {
+			t.Errorf("Incorrect address %d: expected %s, got %s", i, address.Hex(), addresses[i].Hex())
+		}
+	}
+
+	// Partial words are ignored
+	if addresses := state.GetLogAddresses(types.Log{Data: firstNode.Bytes()}); len(addresses) != 0 {
+		t.Errorf("Partial data word was decoded: %v", addresses)
+	}
+
+}
+
+func TestEventChanges(t *testing.T) {
+
+	contracts := getTestContracts(t, "1.2.0")
+	networkState := getTestState()
+	logs := []types.Log{
+		// A token transfer from the first node
+		{Address: tokenAddress, Topics: []common.Hash{{}, common.BytesToHash(firstNode.Bytes())}},
+		// A payment to the second node's distributor
+		{Address: tokenAddress, Data: common.BytesToHash(secondDistributor.Bytes()).Bytes()},
+		getManagerLog(contracts, "NodeRegistered", newNode),
+		getManagerLog(contracts, "MinipoolCreated", newMinipool, newNode),
+		getManagerLog(contracts, "MinipoolCreated", shortLivedMinipool, newNode),
+		getManagerLog(contracts, "MinipoolDestroyed", shortLivedMinipool, newNode),
+		getManagerLog(contracts, "MinipoolDestroyed", thirdMinipool, firstNode),
+	}
+	minipoolLogs := []types.Log{
+		{Address: secondMinipool},
+		{Address: thirdMinipool},
+		{Address: newMinipool},
+	}
+	changes := state.GetEventChanges(networkState, contracts, map[common.Address]bool{oracleDaoAddress: true}, logs, minipoolLogs)
+
+	// Nodes are marked directly, through their distributors, and through their minipools
+	if len(changes.DirtyNodes) != 2 || !changes.DirtyNodes[firstNode] || !changes.DirtyNodes[secondNode] {
+		t.Errorf("Incorrect dirty nodes: %v", changes.DirtyNodes)
+	}
+	if len(changes.NewNodes) != 1 || changes.NewNodes[0] != newNode {
+		t.Errorf("Incorrect new nodes: %v", changes.NewNodes)
+	}
+
+	// Destroyed minipools are dropped, including ones created in the same range
+	if len(changes.DirtyMinipools) != 1 || !changes.DirtyMinipools[secondMinipool] {
+		t.Errorf("Incorrect dirty minipools: %v", changes.DirtyMinipools)
+	}
+	if len(changes.NewMinipools) != 1 || changes.NewMinipools[0] != newMinipool {
+		t.Errorf("Incorrect new minipools: %v", changes.NewMinipools)
+	}
+	if !changes.DestroyedMinipools[thirdMinipool] || !changes.DestroyedMinipools[shortLivedMinipool] {
+		t.Errorf("Incorrect destroyed minipools: %v", changes.DestroyedMinipools)
+	}
+	if changes.UpdateOracleDao {
+		t.Error("Oracle DAO was updated without any of its events")
+	}
+
+	// Balance changes mark the minipool and its node, unless it was destroyed
+	changes.MarkMinipool(networkState, firstMinipool)
+	changes.MarkMinipool(networkState, thirdMinipool)
+	changes.MarkMinipool(networkState, newMinipool)
+	if len(changes.DirtyMinipools) != 2 || !changes.DirtyMinipools[firstMinipool] {
+		t.Errorf("Incorrect dirty minipools after balance changes: %v", changes.DirtyMinipools)
+	}
+
+	// Oracle DAO events refresh the members
+	logs = append(logs, types.Log{Address: oracleDaoAddress})
+	if changes := state.GetEventChanges(networkState, contracts, map[common.Address]bool{oracleDaoAddress: true}, logs, nil); !changes.UpdateOracleDao {
+		t.Error("Oracle DAO wasn't updated")
+	}
+
+}
+
+func TestUpgradeReason(t *testing.T) {
+
+	oldContracts := getTestContracts(t, "1.2.0")
+	if reason := state.GetUpgradeReason(oldContracts, getTestContracts(t, "1.2.0")); reason != "" {
+		t.Errorf("Unexpected upgrade: %s", reason)
+	}
+
+	// Version changes
+	if reason := state.GetUpgradeReason(oldContracts, getTestContracts(t, "1.3.0")); reason != "the network was upgraded from v1.2.0 to v1.3.0" {
+		t.Errorf("Incorrect version upgrade reason: %s", reason)
+	}
+
+	// Address changes
+	newContracts := getTestContracts(t, "1.2.0")
+	upgradedAddress := common.HexToAddress("0x1000000000000000000000000000000000000009")
+	newContracts.RocketNodeManager = &rocketpool.Contract{Address: &upgradedAddress, Name: "rocketNodeManager"}
+	if reason := state.GetUpgradeReason(oldContracts, newContracts); reason != "contract rocketNodeManager was upgraded" {
+		t.Errorf("Incorrect contract upgrade reason: %s", reason)
+	}
+
+	// Contracts added without a version change
+	newContracts = getTestContracts(t, "1.2.0")
+	newContracts.RocketMinipoolBondReducer = &rocketpool.Contract{Address: &upgradedAddress, Name: "rocketMinipoolBondReducer"}
+	if reason := state.GetUpgradeReason(oldContracts, newContracts); reason != "contract RocketMinipoolBondReducer was upgraded" {
+		t.Errorf("Incorrect added contract reason: %s", reason)
+	}
+
+}
+
+func TestCountMismatchReason(t *testing.T) {
+
+	networkState := getTestState()
+	if reason := state.GetCountMismatchReason(networkState, 2, 3); reason != "" {
+		t.Errorf("Unexpected count mismatch: %s", reason)
+	}
+
+	// A missed registration or minipool forces a full reload
+	if reason := state.GetCountMismatchReason(networkState, 3, 3); reason == "" || !strings.Contains(reason, "node count 3") {
+		t.Errorf("Incorrect node count mismatch: %s", reason)
+	}
+	if reason := state.GetCountMismatchReason(networkState, 2, 4); reason == "" || !strings.Contains(reason, "minipool count 4") {
+		t.Errorf("Incorrect minipool count mismatch: %s", reason)
+	}
+
+}
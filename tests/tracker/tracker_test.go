@@ -0,0 +1,128 @@
+package tracker
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+var start = time.Unix(1700000000, 0)
+
+func newProposal(id uint64) protocol.ProtocolDaoProposalDetails {
+	return protocol.ProtocolDaoProposalDetails{
+		ID:                   id,
+		VotingStartTime:      start,
+		Phase1EndTime:        start.Add(7 * 24 * time.Hour),
+		Phase2EndTime:        start.Add(14 * 24 * time.Hour),
+		ExpiryTime:           start.Add(42 * 24 * time.Hour),
+		VotingPowerRequired:  eth.EthToWei(100),
+		VotingPowerFor:       big.NewInt(0),
+		VotingPowerAgainst:   big.NewInt(0),
+		VotingPowerAbstained: big.NewInt(0),
+		VotingPowerToVeto:    big.NewInt(0),
+		VetoQuorum:           eth.EthToWei(50),
+	}
+}
+
+func TestProposalStatus(t *testing.T) {
+
+	proposal := newProposal(1)
+	proposal.VotingPowerFor = eth.EthToWei(60)
+	proposal.VotingPowerAgainst = eth.EthToWei(20)
+
+	status := protocol.GetProposalStatus(proposal, start.Add(-time.Hour), time.Hour)
+	if status.State != types.ProtocolDaoProposalState_Pending || status.TimeRemaining != time.Hour {
+		t.Errorf("Incorrect pending status: %v, %s", status.State, status.TimeRemaining)
+	}
+
+	status = protocol.GetProposalStatus(proposal, start.Add(10*24*time.Hour), time.Hour)
+	if status.State != types.ProtocolDaoProposalState_ActivePhase2 || status.TimeRemaining != 4*24*time.Hour {
+		t.Errorf("Incorrect phase 2 status: %v, %s", status.State, status.TimeRemaining)
+	}
+	if status.QuorumMet || status.VotingPowerToQuorum.Cmp(eth.EthToWei(20)) != 0 {
+		t.Errorf("Incorrect quorum: %t, %s short", status.QuorumMet, status.VotingPowerToQuorum)
+	}
+	if status.ProjectedState != types.ProtocolDaoProposalState_QuorumNotMet {
+		t.Errorf("Incorrect projected state: %v", status.ProjectedState)
+	}
+
+	proposal.VotingPowerAbstained = eth.EthToWei(20)
+	status = protocol.GetProposalStatus(proposal, start.Add(10*24*time.Hour), time.Hour)
+	if !status.QuorumMet || status.ProjectedState != types.ProtocolDaoProposalState_Succeeded {
+		t.Errorf("Incorrect projection with quorum: %t, %v", status.QuorumMet, status.ProjectedState)
+	}
+
+	status = protocol.GetProposalStatus(proposal, proposal.ExpiryTime.Add(-30*time.Minute), time.Hour)
+	if !status.CanExecute || !status.IsExpiringSoon || status.TimeRemaining != 30*time.Minute {
+		t.Errorf("Incorrect executable status: %t, %t, %s", status.CanExecute, status.IsExpiringSoon, status.TimeRemaining)
+	}
+	if state := protocol.ComputeProposalState(proposal, proposal.ExpiryTime); state != types.ProtocolDaoProposalState_Expired {
+		t.Errorf("Incorrect state after expiry: %v", state)
+	}
+
+	proposal.VotingPowerToVeto = eth.EthToWei(50)
+	status = protocol.GetProposalStatus(proposal, proposal.Phase2EndTime, time.Hour)
+	if status.State != types.ProtocolDaoProposalState_Vetoed || !status.CanFinalize || status.CanExecute {
+		t.Errorf("Incorrect vetoed status: %v, %t, %t", status.State, status.CanFinalize, status.CanExecute)
+	}
+	proposal.IsFinalized = true
+	if status := protocol.GetProposalStatus(proposal, proposal.Phase2EndTime, time.Hour); status.CanFinalize {
+		t.Error("Finalized proposal can be finalized again")
+	}
+
+}
+
+func TestProposalTracker(t *testing.T) {
+
+	tracker := protocol.NewProposalTracker(24 * time.Hour)
+	proposal := newProposal(1)
+	update := func(now time.Time, proposals ...protocol.ProtocolDaoProposalDetails) []protocol.ProposalEvent {
+		statuses := []protocol.ProtocolDaoProposalStatus{}
+		for _, proposal := range proposals {
+			statuses = append(statuses, protocol.GetProposalStatus(proposal, now, 24*time.Hour))
+		}
+		return tracker.Update(statuses)
+	}
+	checkEvents := func(events []protocol.ProposalEvent, expected ...protocol.ProposalEventType) {
+		t.Helper()
+		if len(events) != len(expected) {
+			t.Fatalf("Expected events %v but got %v", expected, events)
+		}
+		for i, event := range events {
+			if event.Type != expected[i] {
+				t.Errorf("Expected event %d to be %s but it was %s", i, expected[i], event.Type)
+			}
+		}
+	}
+
+	// The first update only seeds the tracker
+	checkEvents(update(start.Add(time.Hour), proposal))
+
+	// Voting reaches quorum and moves to phase 2
+	proposal.VotingPowerFor = eth.EthToWei(120)
+	checkEvents(update(start.Add(8*24*time.Hour), proposal), protocol.ProposalEventQuorumReached, protocol.ProposalEventEnteredPhase2)
+
+	// A new proposal is created
+	second := newProposal(2)
+	second.VotingStartTime = second.VotingStartTime.Add(9 * 24 * time.Hour)
+	second.Phase1EndTime = second.Phase1EndTime.Add(9 * 24 * time.Hour)
+	second.Phase2EndTime = second.Phase2EndTime.Add(9 * 24 * time.Hour)
+	second.ExpiryTime = second.ExpiryTime.Add(9 * 24 * time.Hour)
+	events := update(start.Add(8*24*time.Hour+time.Hour), proposal, second)
+	checkEvents(events, protocol.ProposalEventCreated)
+	if events[0].ProposalID != 2 {
+		t.Errorf("Incorrect proposal ID: %d", events[0].ProposalID)
+	}
+
+	// Voting ends and the first proposal can be executed, then nears its expiry
+	checkEvents(update(start.Add(15*24*time.Hour), proposal, second), protocol.ProposalEventExecutable, protocol.ProposalEventEnteredPhase1)
+	checkEvents(update(proposal.ExpiryTime.Add(-time.Hour), proposal, second), protocol.ProposalEventExpiringSoon, protocol.ProposalEventDefeated)
+
+	// Nothing changes
+	checkEvents(update(proposal.ExpiryTime.Add(-time.Minute), proposal, second))
+
+}
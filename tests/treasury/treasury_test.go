@@ -0,0 +1,125 @@
+package treasury
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol/treasury"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The treasury events of rocketClaimDAO
+const claimDaoAbi = `[
+	{"type":"event","name":"RPLTokensSentByDAOProtocol","inputs":[{"name":"invoiceID","type":"string","indexed":false},{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"RPLTreasuryContractPayment","inputs":[{"name":"contractName","type":"string","indexed":true},{"name":"recipient","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"RPLTreasuryContractClaimed","inputs":[{"name":"recipient","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"RPLTreasuryContractCreated","inputs":[{"name":"contractName","type":"string","indexed":true},{"name":"recipient","type":"address","indexed":true},{"name":"amountPerPeriod","type":"uint256","indexed":false},{"name":"startTime","type":"uint256","indexed":false},{"name":"periodLength","type":"uint256","indexed":false},{"name":"numPeriods","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Unrelated","inputs":[{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func makeLog(t *testing.T, contractAbi *abi.ABI, name string, blockNumber uint64, topics []common.Hash, args ...interface{}) types.Log {
+	event := contractAbi.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Topics:      append([]common.Hash{event.ID}, topics...),
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
+
+func TestDecodeEvents(t *testing.T) {
+
+	contractAbi, err := abi.JSON(strings.NewReader(claimDaoAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vault := common.HexToAddress("0x01")
+	recipient := common.HexToAddress("0x02")
+	grants := crypto.Keccak256Hash([]byte("grants"))
+	unknown := crypto.Keccak256Hash([]byte("unknown"))
+
+	logs := []types.Log{
+		makeLog(t, &contractAbi, "RPLTokensSentByDAOProtocol", 1, []common.Hash{vault.Hash(), recipient.Hash()}, "invoice-1", eth.EthToWei(100), big.NewInt(1000)),
+		makeLog(t, &contractAbi, "RPLTreasuryContractCreated", 2, []common.Hash{grants, recipient.Hash()}, eth.EthToWei(10), big.NewInt(2000), big.NewInt(86400), big.NewInt(12)),
+		makeLog(t, &contractAbi, "RPLTreasuryContractPayment", 3, []common.Hash{grants, recipient.Hash()}, eth.EthToWei(20), big.NewInt(3000)),
+		makeLog(t, &contractAbi, "RPLTreasuryContractPayment", 4, []common.Hash{unknown, recipient.Hash()}, eth.EthToWei(5), big.NewInt(4000)),
+		makeLog(t, &contractAbi, "RPLTreasuryContractClaimed", 5, []common.Hash{recipient.Hash()}, eth.EthToWei(15), big.NewInt(5000)),
+		makeLog(t, &contractAbi, "Unrelated", 6, nil, big.NewInt(1)),
+	}
+	events, err := treasury.DecodeEvents(&contractAbi, logs, []string{"grants"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("Incorrect number of events: %d", len(events))
+	}
+
+	spend := events[0]
+	if spend.Type != treasury.EventSpend || spend.InvoiceID != "invoice-1" || spend.Recipient != recipient || spend.Amount.Cmp(eth.EthToWei(100)) != 0 || spend.Time.Unix() != 1000 {
+		t.Errorf("Incorrect spend event: %+v", spend)
+	}
+	created := events[1]
+	if created.Type != treasury.EventContractCreated || created.ContractName != "grants" || created.PeriodLength != 24*time.Hour || created.NumberOfPeriods != 12 || created.StartTime.Unix() != 2000 {
+		t.Errorf("Incorrect contract created event: %+v", created)
+	}
+	if events[2].ContractName != "grants" || events[2].BlockNumber != 3 {
+		t.Errorf("Incorrect payment event: %+v", events[2])
+	}
+	if events[3].ContractName != "" || events[3].ContractNameHash != unknown {
+		t.Errorf("Unknown contract name was not left unresolved: %+v", events[3])
+	}
+	if events[4].Type != treasury.EventClaim || events[4].Recipient != recipient {
+		t.Errorf("Incorrect claim event: %+v", events[4])
+	}
+
+	spent, paidOut, claimed := treasury.GetHistoryTotals(events)
+	if spent.Cmp(eth.EthToWei(100)) != 0 || paidOut.Cmp(eth.EthToWei(25)) != 0 || claimed.Cmp(eth.EthToWei(15)) != 0 {
+		t.Errorf("Incorrect totals: spent %s, paid out %s, claimed %s", spent, paidOut, claimed)
+	}
+
+}
+
+func TestPaymentContractAmounts(t *testing.T) {
+
+	lastPayment := time.Unix(1000000, 0)
+	contract := treasury.PaymentContract{
+		AmountPerPeriod: eth.EthToWei(10),
+		PeriodLength:    24 * time.Hour,
+		StartTime:       lastPayment.Add(-48 * time.Hour),
+		LastPaymentTime: lastPayment,
+		NumberOfPeriods: 5,
+		PeriodsPaid:     2,
+	}
+
+	if periods := contract.GetPendingPeriods(lastPayment.Add(-time.Hour)); periods != 0 {
+		t.Errorf("Periods were pending before the last payment: %d", periods)
+	}
+	if amount := contract.GetPendingAmount(lastPayment.Add(50 * time.Hour)); amount.Cmp(eth.EthToWei(20)) != 0 {
+		t.Errorf("Incorrect pending amount: %s", amount)
+	}
+	if periods := contract.GetPendingPeriods(lastPayment.Add(30 * 24 * time.Hour)); periods != 3 {
+		t.Errorf("Pending periods were not capped: %d", periods)
+	}
+	if amount := contract.GetRemainingAmount(); amount.Cmp(eth.EthToWei(30)) != 0 {
+		t.Errorf("Incorrect remaining amount: %s", amount)
+	}
+	if end := contract.GetEndTime(); !end.Equal(lastPayment.Add(72 * time.Hour)) {
+		t.Errorf("Incorrect end time: %s", end)
+	}
+
+	contract.PeriodsPaid = 5
+	if amount := contract.GetRemainingAmount(); amount.Sign() != 0 {
+		t.Errorf("Finished contract has a remaining amount: %s", amount)
+	}
+
+}
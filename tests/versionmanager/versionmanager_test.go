@@ -0,0 +1,242 @@
+package versionmanager
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/contracts"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The current network prices ABI, with a method the legacy versions don't have
+const currentPricesAbi string = `[
+	{"type":"function","name":"getPricesBlock","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getRPLPrice","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getCurrentOnly","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var (
+	storageAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	v1Address      = common.HexToAddress("0x2222222222222222222222222222222222222222") // Replaced by v1.2.0
+	v2Address      = common.HexToAddress("0x3333333333333333333333333333333333333333") // Replaced by v1.3.0
+	currentAddress = common.HexToAddress("0x4444444444444444444444444444444444444444")
+)
+
+// Execution client stand-in with a network prices contract that was upgraded at blocks 500 and 800
+// Each deployment returns its own RPL price, decoded with the ABI it was deployed with
+type upgradeClient struct {
+	rocketpool.ExecutionClient
+	t          *testing.T
+	storageAbi abi.ABI
+	encodedAbi string
+	abis       map[common.Address]*abi.ABI
+	prices     map[common.Address]int64
+	calls      map[common.Address][]string
+}
+
+func newUpgradeClient(t *testing.T) *upgradeClient {
+	storageAbi, err := abi.JSON(strings.NewReader(contracts.RocketStorageABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedAbi, err := rocketpool.EncodeAbiStr(currentPricesAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentAbi, err := abi.JSON(strings.NewReader(currentPricesAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v1Abi, err := rocketpool.GetLegacyABI("1.1.0", "rocketNetworkPrices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2Abi, err := rocketpool.GetLegacyABI("1.2.0", "rocketNetworkPrices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &upgradeClient{
+		t:          t,
+		storageAbi: storageAbi,
+		encodedAbi: encodedAbi,
+		abis:       map[common.Address]*abi.ABI{v1Address: v1Abi, v2Address: v2Abi, currentAddress: &currentAbi},
+		prices:     map[common.Address]int64{v1Address: 1, v2Address: 2, currentAddress: 3},
+		calls:      map[common.Address][]string{},
+	}
+}
+
+// Get the storage key of a contract address
+func addressKey(contractName string) [32]byte {
+	return crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName))
+}
+
+func (c *upgradeClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *upgradeClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	block := uint64(1000)
+	if blockNumber != nil {
+		block = blockNumber.Uint64()
+	}
+
+	// Serve the network prices deployments
+	if contractAbi, exists := c.abis[*call.To]; exists {
+		method, err := contractAbi.MethodById(call.Data[:4])
+		if err != nil {
+			c.t.Fatalf("contract %s has no method %x", call.To.Hex(), call.Data[:4])
+		}
+		c.calls[*call.To] = append(c.calls[*call.To], method.Name)
+		return method.Outputs.Pack(big.NewInt(c.prices[*call.To]))
+	}
+
+	// Serve RocketStorage
+	method, err := c.storageAbi.MethodById(call.Data[:4])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	key := args[0].([32]byte)
+	switch method.Name {
+	case "getAddress":
+		address := common.Address{}
+		switch key {
+		case addressKey("rocketNetworkPrices"):
+			switch {
+			case block >= 800:
+				address = currentAddress
+			case block >= 500:
+				address = v2Address
+			default:
+				address = v1Address
+			}
+		case addressKey("rocketNetworkPrices.v1"):
+			address = v1Address
+		case addressKey("rocketNetworkPrices.v2"):
+			address = v2Address
+		}
+		return method.Outputs.Pack(address)
+	case "getString":
+		return method.Outputs.Pack(c.encodedAbi)
+	}
+	c.t.Fatalf("unexpected storage call %s", method.Name)
+	return nil, nil
+}
+
+// Get call options at a block
+func atBlock(block int64) *bind.CallOpts {
+	return &bind.CallOpts{BlockNumber: big.NewInt(block)}
+}
+
+func TestContractAtBlock(t *testing.T) {
+
+	rp, err := rocketpool.NewRocketPool(newUpgradeClient(t), storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each block gets the deployment that was live at it, with the ABI of the version it belongs to
+	for _, test := range []struct {
+		block      int64
+		address    common.Address
+		legacy     bool
+		hasCurrent bool
+	}{
+		{block: 100, address: v1Address, legacy: true},
+		{block: 600, address: v2Address, legacy: true},
+		{block: 900, address: currentAddress, hasCurrent: true},
+	} {
+		contract, err := rp.VersionManager.GetContractAtBlock("rocketNetworkPrices", atBlock(test.block))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *contract.Address != test.address {
+			t.Errorf("Incorrect address at block %d: expected %s, got %s", test.block, test.address.Hex(), contract.Address.Hex())
+		}
+		if _, exists := contract.ABI.Methods["getLatestReportableBlock"]; exists != test.legacy {
+			t.Errorf("Incorrect ABI at block %d: legacy method exists = %t", test.block, exists)
+		}
+		if _, exists := contract.ABI.Methods["getCurrentOnly"]; exists != test.hasCurrent {
+			t.Errorf("Incorrect ABI at block %d: current method exists = %t", test.block, exists)
+		}
+	}
+
+	// The v1.1.0 ABI is the only one with the effective RPL stake methods
+	contract, err := rp.VersionManager.GetContractAtBlock("rocketNetworkPrices", atBlock(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := contract.ABI.Methods["getEffectiveRPLStake"]; !exists {
+		t.Error("Block 100 wasn't bound with the v1.1.0 ABI")
+	}
+
+	// Legacy bindings are cached
+	cached, err := rp.VersionManager.GetContractAtBlock("rocketNetworkPrices", atBlock(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != contract {
+		t.Error("Legacy binding wasn't cached")
+	}
+
+	// Without a block, the current contract is used
+	contract, err = rp.VersionManager.GetContractAtBlock("rocketNetworkPrices", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *contract.Address != currentAddress {
+		t.Errorf("Incorrect current address %s", contract.Address.Hex())
+	}
+
+}
+
+func TestLegacyCalls(t *testing.T) {
+
+	client := newUpgradeClient(t)
+	rp, err := rocketpool.NewRocketPool(client, storageAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Historical getters use the current method names, which resolve through the legacy ABIs
+	for _, test := range []struct {
+		block   int64
+		address common.Address
+		price   int64
+	}{
+		{block: 100, address: v1Address, price: 1},
+		{block: 600, address: v2Address, price: 2},
+		{block: 900, address: currentAddress, price: 3},
+	} {
+		price, err := network.GetRPLPrice(rp, atBlock(test.block))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if price.Int64() != test.price {
+			t.Errorf("Incorrect RPL price at block %d: expected %d, got %s", test.block, test.price, price)
+		}
+		pricesBlock, err := network.GetPricesBlock(rp, atBlock(test.block))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pricesBlock != uint64(test.price) {
+			t.Errorf("Incorrect prices block at block %d: %d", test.block, pricesBlock)
+		}
+		if calls := client.calls[test.address]; len(calls) != 2 || calls[0] != "getRPLPrice" || calls[1] != "getPricesBlock" {
+			t.Errorf("Incorrect calls to %s: %v", test.address.Hex(), calls)
+		}
+	}
+
+}
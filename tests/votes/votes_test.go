@@ -0,0 +1,104 @@
+package votes
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The vote events of rocketDAOProtocolProposal
+const proposalAbi = `[
+	{"type":"event","name":"ProposalVoted","inputs":[{"name":"proposalID","type":"uint256","indexed":true},{"name":"direction","type":"uint8","indexed":false},{"name":"votingPower","type":"uint256","indexed":false},{"name":"voter","type":"address","indexed":true},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"ProposalVoteOverridden","inputs":[{"name":"proposalID","type":"uint256","indexed":true},{"name":"delegate","type":"address","indexed":true},{"name":"voter","type":"address","indexed":true},{"name":"votingPower","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]}
+]`
+
+var (
+	delegate  = common.HexToAddress("0x0a")
+	delegator = common.HexToAddress("0x0b")
+	vetoer    = common.HexToAddress("0x0c")
+)
+
+func proposalTopic(id int64) common.Hash {
+	return common.BigToHash(big.NewInt(id))
+}
+
+func makeLog(t *testing.T, contractAbi *abi.ABI, name string, blockNumber uint64, index uint, topics []common.Hash, args ...interface{}) ethtypes.Log {
+	event := contractAbi.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ethtypes.Log{
+		Topics:      append([]common.Hash{event.ID}, topics...),
+		Data:        data,
+		BlockNumber: blockNumber,
+		Index:       index,
+	}
+}
+
+func TestVoteHistory(t *testing.T) {
+
+	contractAbi, err := abi.JSON(strings.NewReader(proposalAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Logs are deliberately out of order, as they would be across deployments
+	logs := []ethtypes.Log{
+		makeLog(t, &contractAbi, "ProposalVoteOverridden", 12, 0, []common.Hash{proposalTopic(1), delegate.Hash(), delegator.Hash()}, eth.EthToWei(40), big.NewInt(1200)),
+		makeLog(t, &contractAbi, "ProposalVoted", 10, 3, []common.Hash{proposalTopic(1), delegate.Hash()}, uint8(types.VoteDirection_For), eth.EthToWei(100), big.NewInt(1000)),
+		makeLog(t, &contractAbi, "ProposalVoted", 11, 0, []common.Hash{proposalTopic(1), vetoer.Hash()}, uint8(types.VoteDirection_AgainstWithVeto), eth.EthToWei(30), big.NewInt(1100)),
+		makeLog(t, &contractAbi, "ProposalVoted", 11, 1, []common.Hash{proposalTopic(2), vetoer.Hash()}, uint8(types.VoteDirection_Abstain), eth.EthToWei(30), big.NewInt(1100)),
+	}
+	events, err := protocol.DecodeProposalVoteEvents(&contractAbi, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 || events[0].Voter != delegate || events[3].Voter != delegator {
+		t.Fatalf("Events were not decoded in order: %+v", events)
+	}
+	if !events[3].IsOverride || events[3].Delegate != delegate || events[3].Direction != types.VoteDirection_NoVote {
+		t.Errorf("Incorrect override event: %+v", events[3])
+	}
+
+	// The override's direction comes from its vote receipt
+	events[3].Direction = types.VoteDirection_Against
+	history := protocol.BuildProposalVoteHistory(1, events)
+	if len(history.Voters) != 3 {
+		t.Fatalf("Incorrect voters: %+v", history.Voters)
+	}
+	if voter := history.Voters[0]; voter.EffectiveVotingPower.Cmp(eth.EthToWei(60)) != 0 || voter.OverriddenVotingPower.Cmp(eth.EthToWei(40)) != 0 {
+		t.Errorf("Override was not removed from the delegate's vote: %+v", voter)
+	}
+	if voter := history.Voters[2]; !voter.IsOverride || voter.OverriddenDelegate != delegate || voter.BlockNumber != 12 {
+		t.Errorf("Incorrect overriding voter: %+v", voter)
+	}
+
+	expected := protocol.ProposalVoteTotals{
+		For:       eth.EthToWei(60),
+		Against:   eth.EthToWei(70),
+		Abstained: big.NewInt(0),
+		Veto:      eth.EthToWei(30),
+	}
+	if !history.Totals.Equals(expected) {
+		t.Errorf("Incorrect totals: %+v", history.Totals)
+	}
+	if len(history.UnmatchedOverrides) != 0 {
+		t.Errorf("Incorrect unmatched overrides: %v", history.UnmatchedOverrides)
+	}
+
+	// Overrides of votes cast before the events start are flagged
+	history = protocol.BuildProposalVoteHistory(1, events[3:])
+	if len(history.UnmatchedOverrides) != 1 || history.UnmatchedOverrides[0] != delegator {
+		t.Errorf("Incorrect unmatched overrides: %v", history.UnmatchedOverrides)
+	}
+
+}
@@ -3,7 +3,6 @@ package tokens
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -202,10 +201,6 @@ func BurnRETH(rp *rocketpool.RocketPool, amount *big.Int, opts *bind.TransactOpt
 //
 
 // Get contracts
-var rocketTokenRETHLock sync.Mutex
-
 func getRocketTokenRETH(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketTokenRETHLock.Lock()
-	defer rocketTokenRETHLock.Unlock()
 	return rp.GetContract("rocketTokenRETH", opts)
 }
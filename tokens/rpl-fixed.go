@@ -2,7 +2,6 @@ package tokens
 
 import (
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -100,10 +99,6 @@ func TransferFromFixedSupplyRPL(rp *rocketpool.RocketPool, from, to common.Addre
 //
 
 // Get contracts
-var rocketTokenFixedSupplyRPLLock sync.Mutex
-
 func getRocketTokenRPLFixedSupply(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketTokenFixedSupplyRPLLock.Lock()
-	defer rocketTokenFixedSupplyRPLLock.Unlock()
 	return rp.GetContract("rocketTokenRPLFixedSupply", opts)
 }
@@ -3,7 +3,6 @@ package tokens
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -176,10 +175,6 @@ func GetRPLInflationIntervalStartTime(rp *rocketpool.RocketPool, opts *bind.Call
 //
 
 // Get contracts
-var rocketTokenRPLLock sync.Mutex
-
 func getRocketTokenRPL(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	rocketTokenRPLLock.Lock()
-	defer rocketTokenRPLLock.Unlock()
 	return rp.GetContract("rocketTokenRPL", opts)
 }
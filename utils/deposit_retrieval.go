@@ -5,7 +5,6 @@ import (
 	"encoding/binary"
 	"math/big"
 	"sort"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -114,10 +113,6 @@ func sortDepositData(data []DepositData) {
 }
 
 // Get contracts
-var casperDepositLock sync.Mutex
-
 func getCasperDeposit(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
-	casperDepositLock.Lock()
-	defer casperDepositLock.Unlock()
 	return rp.GetContract("casperDeposit", opts)
 }
@@ -0,0 +1,211 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The kind of mismatch between the library and a deployed contract
+type IssueType string
+
+const (
+	IssueMissingContract IssueType = "missingContract"
+	IssueMissingMethod   IssueType = "missingMethod"
+	IssueArgumentCount   IssueType = "argumentCount"
+	IssueInputsChanged   IssueType = "inputsChanged"
+	IssueOutputsChanged  IssueType = "outputsChanged"
+	IssueMissingEvent    IssueType = "missingEvent"
+	IssueEventChanged    IssueType = "eventChanged"
+)
+
+// A mismatch between the library and a deployed contract
+type Issue struct {
+	Type     IssueType `json:"type"`
+	Contract string    `json:"contract"`
+	Member   string    `json:"member"`
+	Details  string    `json:"details"`
+	Usages   []Usage   `json:"usages"`
+}
+
+// The result of checking the library's usages against a set of deployed ABIs
+type Report struct {
+	Contracts     []string `json:"contracts"`
+	CheckedUsages int      `json:"checkedUsages"`
+	Issues        []Issue  `json:"issues"`
+}
+
+// Check the library's usages against the ABIs deployed on a network at the block in the call options
+// Changed signatures are reported against the baseline (e.g. the ABIs the current release was tested against); if it's nil, only missing members and argument counts are checked
+func CheckNetwork(rp *rocketpool.RocketPool, usages []Usage, baseline map[string]*abi.ABI, opts *bind.CallOpts) (Report, error) {
+	deployed := map[string]*abi.ABI{}
+	for _, contractName := range getContractNames(usages) {
+		abiEncoded, err := rp.RocketStorage.GetString(opts, crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)))
+		if err != nil {
+			return Report{}, fmt.Errorf("error getting contract %s ABI: %w", contractName, err)
+		}
+		if abiEncoded == "" {
+			// The contract isn't deployed on this network
+			continue
+		}
+		contractAbi, err := rp.GetABI(contractName, opts)
+		if err != nil {
+			return Report{}, err
+		}
+		deployed[contractName] = contractAbi
+	}
+	return CheckAbis(usages, deployed, baseline), nil
+}
+
+// Check the library's usages against a set of deployed ABIs, keyed by contract name
+func CheckAbis(usages []Usage, deployed map[string]*abi.ABI, baseline map[string]*abi.ABI) Report {
+
+	report := Report{
+		Contracts: getContractNames(usages),
+		Issues:    []Issue{},
+	}
+	issues := map[string]*Issue{}
+	addIssue := func(issueType IssueType, usage Usage, details string) {
+		key := fmt.Sprintf("%s/%s/%s/%s", issueType, usage.Contract, usage.Member, details)
+		issue, exists := issues[key]
+		if !exists {
+			issue = &Issue{
+				Type:     issueType,
+				Contract: usage.Contract,
+				Member:   usage.Member,
+				Details:  details,
+				Usages:   []Usage{},
+			}
+			issues[key] = issue
+		}
+		issue.Usages = append(issue.Usages, usage)
+	}
+
+	for _, usage := range usages {
+		report.CheckedUsages++
+		contractAbi, exists := deployed[usage.Contract]
+		if !exists {
+			addIssue(IssueMissingContract, usage, "no ABI is deployed for the contract")
+			continue
+		}
+		var baselineAbi *abi.ABI
+		if baseline != nil {
+			baselineAbi = baseline[usage.Contract]
+		}
+
+		// Check events
+		if usage.Kind == UsageEvent {
+			event, exists := contractAbi.Events[usage.Member]
+			if !exists {
+				addIssue(IssueMissingEvent, usage, "the event was removed")
+				continue
+			}
+			if baselineAbi != nil {
+				if baselineEvent, exists := baselineAbi.Events[usage.Member]; exists {
+					if expected, actual := formatEvent(baselineEvent), formatEvent(event); expected != actual {
+						addIssue(IssueEventChanged, usage, fmt.Sprintf("expected %s, deployed %s", expected, actual))
+					}
+				}
+			}
+			continue
+		}
+
+		// Check methods
+		method, exists := contractAbi.Methods[usage.Member]
+		if !exists {
+			addIssue(IssueMissingMethod, usage, "the method was removed")
+			continue
+		}
+		if usage.ArgCount >= 0 && usage.ArgCount != len(method.Inputs) {
+			addIssue(IssueArgumentCount, usage, fmt.Sprintf("the library passes %d arguments, deployed %s takes %d", usage.ArgCount, method.Sig, len(method.Inputs)))
+		}
+		if baselineAbi == nil {
+			continue
+		}
+		baselineMethod, exists := baselineAbi.Methods[usage.Member]
+		if !exists {
+			continue
+		}
+		if baselineMethod.Sig != method.Sig {
+			addIssue(IssueInputsChanged, usage, fmt.Sprintf("expected %s, deployed %s", baselineMethod.Sig, method.Sig))
+		}
+		if usage.Kind != UsageTransact {
+			if expected, actual := formatArguments(baselineMethod.Outputs, false), formatArguments(method.Outputs, false); expected != actual {
+				addIssue(IssueOutputsChanged, usage, fmt.Sprintf("expected (%s), deployed (%s)", expected, actual))
+			}
+		}
+	}
+
+	// Sort the issues by contract and member
+	for _, issue := range issues {
+		report.Issues = append(report.Issues, *issue)
+	}
+	sort.Slice(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if a.Contract != b.Contract {
+			return a.Contract < b.Contract
+		}
+		if a.Member != b.Member {
+			return a.Member < b.Member
+		}
+		return a.Type < b.Type
+	})
+	return report
+
+}
+
+// Check if the report found any mismatches
+func (r Report) HasIssues() bool {
+	return len(r.Issues) > 0
+}
+
+// Get a readable summary of the report, one issue per line
+func (r Report) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Checked %d usages of %d contracts: %d issues\n", r.CheckedUsages, len(r.Contracts), len(r.Issues))
+	for _, issue := range r.Issues {
+		positions := make([]string, len(issue.Usages))
+		for i, usage := range issue.Usages {
+			positions[i] = usage.Package + "/" + usage.Position
+		}
+		fmt.Fprintf(&builder, "[%s] %s.%s: %s (used in %s)\n", issue.Type, issue.Contract, issue.Member, issue.Details, strings.Join(positions, ", "))
+	}
+	return builder.String()
+}
+
+// Get the names of the contracts used, sorted by name
+func getContractNames(usages []Usage) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, usage := range usages {
+		if !seen[usage.Contract] {
+			seen[usage.Contract] = true
+			names = append(names, usage.Contract)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format an event's signature, including which inputs are indexed
+func formatEvent(event abi.Event) string {
+	return fmt.Sprintf("%s(%s)", event.RawName, formatArguments(event.Inputs, true))
+}
+
+// Format a list of argument types
+func formatArguments(arguments abi.Arguments, withIndexed bool) string {
+	types := make([]string, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type.String()
+		if withIndexed && argument.Indexed {
+			types[i] += " indexed"
+		}
+	}
+	return strings.Join(types, ",")
+}
@@ -0,0 +1,468 @@
+package drift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// How a contract member is used
+type UsageKind string
+
+const (
+	UsageCall      UsageKind = "call"
+	UsageTransact  UsageKind = "transact"
+	UsageMulticall UsageKind = "multicall"
+	UsageMethod    UsageKind = "method"
+	UsageEvent     UsageKind = "event"
+)
+
+// Contracts that aren't stored in RocketStorage, so they can't be checked
+var ignoredContracts = map[string]bool{
+	"rocketStorage":         true,
+	"rocketStorageContract": true,
+}
+
+// Directories that don't hold library code
+var ignoredDirs = map[string]bool{
+	"docs":     true,
+	"tests":    true,
+	"testdata": true,
+	"vendor":   true,
+}
+
+// Functions that bind a contract from its name, with the index of the name argument
+var contractGetters = map[string]int{
+	"GetContract":        0,
+	"GetContractAtBlock": 0,
+	"MakeContract":       0,
+}
+
+// Contract methods that take a method name, with the index of the name argument
+var methodArgs = map[string]struct {
+	index int
+	kind  UsageKind
+}{
+	"Call":                            {index: 2, kind: UsageCall},
+	"Transact":                        {index: 1, kind: UsageTransact},
+	"GetTransactionGasInfo":           {index: 1, kind: UsageTransact},
+	"GetTransactionAccessListGasInfo": {index: 1, kind: UsageTransact},
+	"Simulate":                        {index: 2, kind: UsageTransact},
+}
+
+// A use of a contract method or event by name in the library's source
+type Usage struct {
+	Contract string    `json:"contract"`
+	Member   string    `json:"member"`
+	Kind     UsageKind `json:"kind"`
+	ArgCount int       `json:"argCount"` // The number of arguments passed to a method, or -1 if unknown
+	Package  string    `json:"package"`
+	Position string    `json:"position"`
+}
+
+// The names that resolve to contracts within a package
+type packageScope struct {
+	constants map[string]string
+	getters   map[string]string
+	types     map[string]string
+}
+
+// Scan the Go source under a directory for contract method and event usages
+// Contracts are resolved from literal or constant names passed to the contract getters, so methods called on
+// contracts that are bound in other ways (or with names built at runtime) aren't included
+func ScanUsages(rootDir string) ([]Usage, error) {
+	usages := []Usage{}
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != rootDir && (ignoredDirs[entry.Name()] || strings.HasPrefix(entry.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		packageUsages, err := scanPackage(rootDir, path)
+		if err != nil {
+			return err
+		}
+		usages = append(usages, packageUsages...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s for contract usages: %w", rootDir, err)
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].Contract != usages[j].Contract {
+			return usages[i].Contract < usages[j].Contract
+		}
+		return usages[i].Member < usages[j].Member
+	})
+	return usages, nil
+}
+
+// Scan the Go files in a single directory
+func scanPackage(rootDir string, dir string) ([]Usage, error) {
+
+	// Parse the package's files
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	packageName, err := filepath.Rel(rootDir, dir)
+	if err != nil {
+		return nil, err
+	}
+	packageName = filepath.ToSlash(packageName)
+
+	usages := []Usage{}
+	for _, pkg := range packages {
+		if pkg.Name == "main" {
+			continue
+		}
+		files := make([]*ast.File, 0, len(pkg.Files))
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+		sort.Slice(files, func(i, j int) bool {
+			return fset.Position(files[i].Pos()).Filename < fset.Position(files[j].Pos()).Filename
+		})
+
+		// Resolve the package's contract names, getters and contract-holding types
+		scope := &packageScope{
+			constants: map[string]string{},
+			getters:   map[string]string{},
+			types:     map[string]string{},
+		}
+		for _, file := range files {
+			scope.addConstants(file)
+		}
+		for _, file := range files {
+			scope.addGetters(file)
+		}
+		for _, file := range files {
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+					scope.addTypes(fn)
+				}
+			}
+		}
+
+		// Find the usages in each function
+		for _, file := range files {
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+					usages = append(usages, scope.scanFunction(fset, packageName, fn)...)
+				}
+			}
+		}
+	}
+	return usages, nil
+
+}
+
+// Record the package's string constants
+func (s *packageScope) addConstants(file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if i < len(valueSpec.Values) {
+					if value, ok := getStringLiteral(valueSpec.Values[i]); ok {
+						s.constants[name.Name] = value
+					}
+				}
+			}
+		}
+	}
+}
+
+// Record the package's functions that return a contract with a fixed name
+func (s *packageScope) addGetters(file *ast.File) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || fn.Recv != nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			ret, ok := node.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) == 0 {
+				return true
+			}
+			if contractName, ok := s.getBoundContract(ret.Results[0]); ok {
+				s.getters[fn.Name.Name] = contractName
+			}
+			return true
+		})
+	}
+}
+
+// Record the types that hold a contract with a fixed name in their Contract field
+func (s *packageScope) addTypes(fn *ast.FuncDecl) {
+	vars := s.getContractVars(fn)
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		literal, ok := node.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		typeName, ok := literal.Type.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, element := range literal.Elts {
+			keyValue, ok := element.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := keyValue.Key.(*ast.Ident); !ok || key.Name != "Contract" {
+				continue
+			}
+			if value, ok := keyValue.Value.(*ast.Ident); ok {
+				if contractName, ok := vars[value.Name]; ok {
+					s.types[typeName.Name] = contractName
+				}
+			}
+		}
+		return true
+	})
+}
+
+// Get the variables in a function that hold a contract with a fixed name
+func (s *packageScope) getContractVars(fn *ast.FuncDecl) map[string]string {
+	vars := map[string]string{}
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return true
+		}
+		name, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if contractName, ok := s.getBoundContract(assign.Rhs[0]); ok {
+			vars[name.Name] = contractName
+		}
+		return true
+	})
+	return vars
+}
+
+// Get the contract name an expression binds, if it's a call to a contract getter
+func (s *packageScope) getBoundContract(expr ast.Expr) (string, bool) {
+	// Contracts built directly with a name
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if literal, ok := expr.(*ast.CompositeLit); ok && isContractType(literal.Type) {
+		for _, element := range literal.Elts {
+			keyValue, ok := element.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := keyValue.Key.(*ast.Ident); ok && key.Name == "Name" {
+				return s.getString(keyValue.Value)
+			}
+		}
+		return "", false
+	}
+
+	// Contract getters
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		contractName, ok := s.getters[fun.Name]
+		return contractName, ok
+	case *ast.SelectorExpr:
+		index, ok := contractGetters[fun.Sel.Name]
+		if !ok || index >= len(call.Args) {
+			return "", false
+		}
+		return s.getString(call.Args[index])
+	}
+	return "", false
+}
+
+// Find the contract usages in a function
+func (s *packageScope) scanFunction(fset *token.FileSet, packageName string, fn *ast.FuncDecl) []Usage {
+
+	// Get the contracts available in the function
+	vars := s.getContractVars(fn)
+	receiver := ""
+	if fn.Recv != nil && len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 {
+		receiverType := fn.Recv.List[0].Type
+		if star, ok := receiverType.(*ast.StarExpr); ok {
+			receiverType = star.X
+		}
+		if typeName, ok := receiverType.(*ast.Ident); ok {
+			if contractName, ok := s.types[typeName.Name]; ok {
+				receiver = fn.Recv.List[0].Names[0].Name
+				vars[receiver+".Contract"] = contractName
+			}
+		}
+	}
+	resolve := func(expr ast.Expr) (string, bool) {
+		switch expr := expr.(type) {
+		case *ast.Ident:
+			contractName, ok := vars[expr.Name]
+			return contractName, ok
+		case *ast.SelectorExpr:
+			if ident, ok := expr.X.(*ast.Ident); ok && ident.Name == receiver && expr.Sel.Name == "Contract" {
+				return vars[receiver+".Contract"], true
+			}
+			// Network contract fields, such as the ones in state.NetworkContracts
+			if strings.HasPrefix(expr.Sel.Name, "Rocket") {
+				return lowerFirst(expr.Sel.Name), true
+			}
+		}
+		return "", false
+	}
+
+	usages := []Usage{}
+	addUsage := func(node ast.Node, contractName string, member string, kind UsageKind, argCount int) {
+		if ignoredContracts[contractName] {
+			return
+		}
+		usages = append(usages, Usage{
+			Contract: contractName,
+			Member:   member,
+			Kind:     kind,
+			ArgCount: argCount,
+			Package:  packageName,
+			Position: fmt.Sprintf("%s:%d", filepath.Base(fset.Position(node.Pos()).Filename), fset.Position(node.Pos()).Line),
+		})
+	}
+
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+
+		// Method calls and multicalls
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if selector.Sel.Name == "AddCall" && len(node.Args) >= 3 {
+				contractName, ok := resolve(node.Args[0])
+				member, memberOk := s.getString(node.Args[2])
+				if ok && memberOk {
+					addUsage(node, contractName, member, UsageMulticall, getArgCount(node, 3))
+				}
+				return true
+			}
+			if selector.Sel.Name == "GetTransactionEvents" && len(node.Args) >= 2 {
+				contractName, ok := resolve(selector.X)
+				member, memberOk := s.getString(node.Args[1])
+				if ok && memberOk {
+					addUsage(node, contractName, member, UsageEvent, -1)
+				}
+				return true
+			}
+			methodArg, ok := methodArgs[selector.Sel.Name]
+			if !ok || len(node.Args) <= methodArg.index {
+				return true
+			}
+			contractName, ok := resolve(selector.X)
+			member, memberOk := s.getString(node.Args[methodArg.index])
+			if ok && memberOk {
+				addUsage(node, contractName, member, methodArg.kind, getArgCount(node, methodArg.index+1))
+			}
+
+		// Method and event lookups in the ABI
+		case *ast.IndexExpr:
+			members, ok := node.X.(*ast.SelectorExpr)
+			if !ok || (members.Sel.Name != "Methods" && members.Sel.Name != "Events") {
+				return true
+			}
+			abiSelector, ok := members.X.(*ast.SelectorExpr)
+			if !ok || abiSelector.Sel.Name != "ABI" {
+				return true
+			}
+			contractName, ok := resolve(abiSelector.X)
+			member, memberOk := s.getString(node.Index)
+			if !ok || !memberOk {
+				return true
+			}
+			if members.Sel.Name == "Events" {
+				addUsage(node, contractName, member, UsageEvent, -1)
+			} else {
+				addUsage(node, contractName, member, UsageMethod, -1)
+			}
+		}
+		return true
+	})
+	return usages
+
+}
+
+// Check if a type expression is rocketpool.Contract
+func isContractType(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name == "Contract"
+	case *ast.SelectorExpr:
+		return expr.Sel.Name == "Contract"
+	}
+	return false
+}
+
+// Get the value of a string literal or constant
+func (s *packageScope) getString(expr ast.Expr) (string, bool) {
+	if value, ok := getStringLiteral(expr); ok {
+		return value, true
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		value, ok := s.constants[ident.Name]
+		return value, ok
+	}
+	return "", false
+}
+
+// Get the value of a string literal
+func getStringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// Get the number of method arguments passed after the given index, or -1 if they're spread from a slice
+func getArgCount(call *ast.CallExpr, firstArg int) int {
+	if call.Ellipsis.IsValid() {
+		return -1
+	}
+	return len(call.Args) - firstArg
+}
+
+// Lowercase the first letter of a name
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return name
+	}
+	return string(unicode.ToLower(r)) + name[size:]
+}
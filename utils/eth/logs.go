@@ -2,6 +2,7 @@ package eth
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -20,7 +21,30 @@ type FilterQuery struct {
 	Topics    [][]common.Hash
 }
 
+// Gets the logs emitted by every address a contract has been deployed at
+// The addresses come from the ContractUpgraded logs and the current address, or from the contract's address history
+// (up to the block in the call options) if the address history resolver has been enabled
 func FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q FilterQuery, intervalSize *big.Int, opts *bind.CallOpts) ([]types.Log, error) {
+	var addresses []common.Address
+	var err error
+	if rp.AddressHistory.IsEnabled() {
+		addresses, err = getHistoryAddresses(rp, contractName, q, opts)
+	} else {
+		addresses, err = getUpgradedAddresses(rp, contractName, intervalSize, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return []types.Log{}, nil
+	}
+
+	// Perform the desired getLogs call and return results
+	return GetLogs(rp, addresses, q.Topics, intervalSize, q.FromBlock, q.ToBlock, q.BlockHash)
+}
+
+// Get all the addresses a contract has ever been deployed at from the ContractUpgraded logs
+func getUpgradedAddresses(rp *rocketpool.RocketPool, contractName string, intervalSize *big.Int, opts *bind.CallOpts) ([]common.Address, error) {
 	rocketDaoNodeTrustedUpgrade, err := rp.GetContract("rocketDAONodeTrustedUpgrade", opts)
 	if err != nil {
 		return nil, err
@@ -44,8 +68,40 @@ func FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q Filter
 		return nil, err
 	}
 	addresses = append(addresses, *currentAddress)
-	// Perform the desired getLogs call and return results
-	return GetLogs(rp, addresses, q.Topics, intervalSize, q.FromBlock, q.ToBlock, q.BlockHash)
+	return addresses, nil
+}
+
+// Get the addresses a contract was deployed at during a query's block range from its address history
+func getHistoryAddresses(rp *rocketpool.RocketPool, contractName string, q FilterQuery, opts *bind.CallOpts) ([]common.Address, error) {
+	// Get the contract's address history
+	var history *rocketpool.ContractHistory
+	var err error
+	if opts != nil && opts.BlockNumber != nil {
+		history, err = rp.AddressHistory.GetHistoryAtBlock(contractName, opts.BlockNumber.Uint64())
+	} else {
+		history, err = rp.AddressHistory.GetHistory(contractName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract %s address history: %w", contractName, err)
+	}
+
+	// Only query the addresses that were live during the requested range
+	if q.BlockHash != nil || (q.FromBlock == nil && q.ToBlock == nil) {
+		return history.GetAddresses(), nil
+	}
+	fromBlock := uint64(0)
+	if q.FromBlock != nil {
+		fromBlock = q.FromBlock.Uint64()
+	}
+	toBlock := history.ResolvedBlock
+	if q.ToBlock != nil {
+		toBlock = q.ToBlock.Uint64()
+	}
+	addresses := []common.Address{}
+	for _, deployment := range history.GetDeploymentsInRange(fromBlock, toBlock) {
+		addresses = append(addresses, deployment.Address)
+	}
+	return addresses, nil
 }
 
 // Gets the logs for a particular log request, breaking the calls into batches if necessary
@@ -47,7 +47,13 @@ func EstimateSendTransactionGas(client rocketpool.ExecutionClient, toAddress com
 		response.SafeGasLimit = gasLimit
 	}
 
-	return response, err
+	// Estimate costs if the client provides fee history
+	response.Costs, err = rocketpool.GetOptionalGasCosts(client, response.EstGasLimit, response.SafeGasLimit)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+
+	return response, nil
 }
 
 // Send a transaction to an address
@@ -115,7 +121,10 @@ func SendTransaction(client rocketpool.ExecutionClient, toAddress common.Address
 		return common.Hash{}, err
 	}
 
-	// Send transaction
+	// Send transaction, unless it was only being built
+	if opts.NoSend {
+		return signedTx.Hash(), nil
+	}
 	if err = client.SendTransaction(context.Background(), signedTx); err != nil {
 		return common.Hash{}, err
 	}
@@ -20,38 +20,47 @@ type NetworkContracts struct {
 	Multicaller    *multicall.MultiCaller
 	ElBlockNumber  *big.Int
 
-	// Network version
-	Version *version.Version
+	// Network version and the features it supports
+	Version      *version.Version
+	Capabilities *rocketpool.Capabilities
 
 	// Redstone
-	RocketDAONodeTrusted                 *rocketpool.Contract
-	RocketDAONodeTrustedSettingsMinipool *rocketpool.Contract
-	RocketDAOProtocolSettingsMinipool    *rocketpool.Contract
-	RocketDAOProtocolSettingsNetwork     *rocketpool.Contract
-	RocketDAOProtocolSettingsNode        *rocketpool.Contract
-	RocketDepositPool                    *rocketpool.Contract
-	RocketMinipoolManager                *rocketpool.Contract
-	RocketMinipoolQueue                  *rocketpool.Contract
-	RocketNetworkBalances                *rocketpool.Contract
-	RocketNetworkFees                    *rocketpool.Contract
-	RocketNetworkPrices                  *rocketpool.Contract
-	RocketNodeDeposit                    *rocketpool.Contract
-	RocketNodeDistributorFactory         *rocketpool.Contract
-	RocketNodeManager                    *rocketpool.Contract
-	RocketNodeStaking                    *rocketpool.Contract
-	RocketRewardsPool                    *rocketpool.Contract
-	RocketSmoothingPool                  *rocketpool.Contract
-	RocketStorage                        *rocketpool.Contract
-	RocketTokenRETH                      *rocketpool.Contract
-	RocketTokenRPL                       *rocketpool.Contract
-	RocketTokenRPLFixedSupply            *rocketpool.Contract
+	RocketDAONodeTrusted                  *rocketpool.Contract
+	RocketDAONodeTrustedSettingsMembers   *rocketpool.Contract
+	RocketDAONodeTrustedSettingsMinipool  *rocketpool.Contract
+	RocketDAONodeTrustedSettingsProposals *rocketpool.Contract
+	RocketDAOProtocolSettingsAuction      *rocketpool.Contract
+	RocketDAOProtocolSettingsDeposit      *rocketpool.Contract
+	RocketDAOProtocolSettingsInflation    *rocketpool.Contract
+	RocketDAOProtocolSettingsMinipool     *rocketpool.Contract
+	RocketDAOProtocolSettingsNetwork      *rocketpool.Contract
+	RocketDAOProtocolSettingsNode         *rocketpool.Contract
+	RocketDAOProtocolSettingsRewards      *rocketpool.Contract
+	RocketDepositPool                     *rocketpool.Contract
+	RocketMinipoolManager                 *rocketpool.Contract
+	RocketMinipoolQueue                   *rocketpool.Contract
+	RocketNetworkBalances                 *rocketpool.Contract
+	RocketNetworkFees                     *rocketpool.Contract
+	RocketNetworkPrices                   *rocketpool.Contract
+	RocketNodeDeposit                     *rocketpool.Contract
+	RocketNodeDistributorFactory          *rocketpool.Contract
+	RocketNodeManager                     *rocketpool.Contract
+	RocketNodeStaking                     *rocketpool.Contract
+	RocketRewardsPool                     *rocketpool.Contract
+	RocketSmoothingPool                   *rocketpool.Contract
+	RocketStorage                         *rocketpool.Contract
+	RocketTokenRETH                       *rocketpool.Contract
+	RocketTokenRPL                        *rocketpool.Contract
+	RocketTokenRPLFixedSupply             *rocketpool.Contract
 
 	// Atlas
 	RocketMinipoolBondReducer *rocketpool.Contract
 
 	// Houston
-	RocketDAOProtocolProposal *rocketpool.Contract
-	RocketDAOProtocolVerifier *rocketpool.Contract
+	RocketDAOProtocolProposal          *rocketpool.Contract
+	RocketDAOProtocolSettingsProposals *rocketpool.Contract
+	RocketDAOProtocolSettingsSecurity  *rocketpool.Contract
+	RocketDAOProtocolVerifier          *rocketpool.Contract
 }
 
 type contractArtifacts struct {
@@ -59,10 +68,11 @@ type contractArtifacts struct {
 	address    common.Address
 	abiEncoded string
 	contract   **rocketpool.Contract
+	feature    rocketpool.Feature // The feature that introduced the contract, if any
 }
 
 // Get a new network contracts container
-func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, isHoustonDeployed bool, opts *bind.CallOpts) (*NetworkContracts, error) {
+func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, opts *bind.CallOpts) (*NetworkContracts, error) {
 	// Get the latest block number if it's not provided
 	if opts == nil {
 		latestElBlock, err := rp.Client.BlockNumber(context.Background())
@@ -74,14 +84,21 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 		}
 	}
 
+	// Get the features deployed at the block
+	capabilities, err := rp.GetCapabilities(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network capabilities: %w", err)
+	}
+
 	// Create the contract binding
 	contracts := &NetworkContracts{
 		RocketStorage: rp.RocketStorageContract,
 		ElBlockNumber: opts.BlockNumber,
+		Version:       capabilities.Version,
+		Capabilities:  capabilities,
 	}
 
 	// Create the multicaller
-	var err error
 	contracts.Multicaller, err = multicall.NewMultiCaller(rp.Client, multicallerAddress)
 	if err != nil {
 		return nil, err
@@ -98,9 +115,24 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 		{
 			name:     "rocketDAONodeTrusted",
 			contract: &contracts.RocketDAONodeTrusted,
+		}, {
+			name:     "rocketDAONodeTrustedSettingsMembers",
+			contract: &contracts.RocketDAONodeTrustedSettingsMembers,
 		}, {
 			name:     "rocketDAONodeTrustedSettingsMinipool",
 			contract: &contracts.RocketDAONodeTrustedSettingsMinipool,
+		}, {
+			name:     "rocketDAONodeTrustedSettingsProposals",
+			contract: &contracts.RocketDAONodeTrustedSettingsProposals,
+		}, {
+			name:     "rocketDAOProtocolSettingsAuction",
+			contract: &contracts.RocketDAOProtocolSettingsAuction,
+		}, {
+			name:     "rocketDAOProtocolSettingsDeposit",
+			contract: &contracts.RocketDAOProtocolSettingsDeposit,
+		}, {
+			name:     "rocketDAOProtocolSettingsInflation",
+			contract: &contracts.RocketDAOProtocolSettingsInflation,
 		}, {
 			name:     "rocketDAOProtocolSettingsMinipool",
 			contract: &contracts.RocketDAOProtocolSettingsMinipool,
@@ -110,6 +142,9 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 		}, {
 			name:     "rocketDAOProtocolSettingsNode",
 			contract: &contracts.RocketDAOProtocolSettingsNode,
+		}, {
+			name:     "rocketDAOProtocolSettingsRewards",
+			contract: &contracts.RocketDAOProtocolSettingsRewards,
 		}, {
 			name:     "rocketDepositPool",
 			contract: &contracts.RocketDepositPool,
@@ -162,18 +197,36 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 	wrappers = append(wrappers, contractArtifacts{
 		name:     "rocketMinipoolBondReducer",
 		contract: &contracts.RocketMinipoolBondReducer,
+		feature:  rocketpool.FeatureBondReduction,
 	})
 
 	// Houston wrappers
-	if isHoustonDeployed {
-		wrappers = append(wrappers, contractArtifacts{
-			name:     "rocketDAOProtocolProposal",
-			contract: &contracts.RocketDAOProtocolProposal,
-		}, contractArtifacts{
-			name:     "rocketDAOProtocolVerifier",
-			contract: &contracts.RocketDAOProtocolVerifier,
-		})
+	wrappers = append(wrappers, contractArtifacts{
+		name:     "rocketDAOProtocolProposal",
+		contract: &contracts.RocketDAOProtocolProposal,
+		feature:  rocketpool.FeatureOnChainPDaoVoting,
+	}, contractArtifacts{
+		name:     "rocketDAOProtocolSettingsProposals",
+		contract: &contracts.RocketDAOProtocolSettingsProposals,
+		feature:  rocketpool.FeatureOnChainPDaoVoting,
+	}, contractArtifacts{
+		name:     "rocketDAOProtocolSettingsSecurity",
+		contract: &contracts.RocketDAOProtocolSettingsSecurity,
+		feature:  rocketpool.FeatureOnChainPDaoVoting,
+	}, contractArtifacts{
+		name:     "rocketDAOProtocolVerifier",
+		contract: &contracts.RocketDAOProtocolVerifier,
+		feature:  rocketpool.FeatureOnChainPDaoVoting,
+	})
+
+	// Skip contracts for features that aren't deployed yet
+	deployedWrappers := make([]contractArtifacts, 0, len(wrappers))
+	for _, wrapper := range wrappers {
+		if wrapper.feature == "" || capabilities.Has(wrapper.feature) {
+			deployedWrappers = append(deployedWrappers, wrapper)
+		}
 	}
+	wrappers = deployedWrappers
 
 	// Add the address and ABI getters to multicall
 	for i, wrapper := range wrappers {
@@ -204,47 +257,12 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 			Address:  &wrappers[i].address,
 			ABI:      abi,
 			Client:   rp.Client,
+			Name:     wrapper.name,
 		}
 
 		// Set the contract in the main wrapper object
 		*wrappers[i].contract = contract
 	}
 
-	err = contracts.getCurrentVersion(rp)
-	if err != nil {
-		return nil, fmt.Errorf("error getting network contract version: %w", err)
-	}
-
 	return contracts, nil
 }
-
-// Get the current version of the network
-func (c *NetworkContracts) getCurrentVersion(rp *rocketpool.RocketPool) error {
-	opts := &bind.CallOpts{
-		BlockNumber: c.ElBlockNumber,
-	}
-
-	// Check for v1.2
-	nodeStakingVersion, err := rocketpool.GetContractVersion(rp, *c.RocketNodeStaking.Address, opts)
-	if err != nil {
-		return fmt.Errorf("error checking node staking version: %w", err)
-	}
-	if nodeStakingVersion > 3 {
-		c.Version, err = version.NewSemver("1.2.0")
-		return err
-	}
-
-	// Check for v1.1
-	nodeMgrVersion, err := rocketpool.GetContractVersion(rp, *c.RocketNodeManager.Address, opts)
-	if err != nil {
-		return fmt.Errorf("error checking node manager version: %w", err)
-	}
-	if nodeMgrVersion > 1 {
-		c.Version, err = version.NewSemver("1.1.0")
-		return err
-	}
-
-	// v1.0
-	c.Version, err = version.NewSemver("1.0.0")
-	return err
-}
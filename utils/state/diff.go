@@ -0,0 +1,280 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The kind of entity a change belongs to
+type EntityType string
+
+const (
+	EntityNetwork         EntityType = "network"
+	EntityNode            EntityType = "node"
+	EntityMinipool        EntityType = "minipool"
+	EntityOracleDaoMember EntityType = "oracleDaoMember"
+	EntitySettings        EntityType = "settings"
+)
+
+// The kind of change to an entity
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// A single change between two snapshots
+// Added and removed entities have one change with no field; modified entities have one change per field
+type Change struct {
+	Entity      EntityType     `json:"entity"`
+	Address     common.Address `json:"address"`     // The entity's address; empty for network changes
+	NodeAddress common.Address `json:"nodeAddress"` // The node the entity belongs to; empty for network changes
+	Type        ChangeType     `json:"type"`
+	Field       string         `json:"field,omitempty"` // Nested fields are separated by dots, e.g. QueueCapacity.Total
+	OldValue    interface{}    `json:"oldValue,omitempty"`
+	NewValue    interface{}    `json:"newValue,omitempty"`
+}
+
+// The changes between two snapshots of the network
+type StateDiff struct {
+	FromBlock uint64   `json:"fromBlock"`
+	ToBlock   uint64   `json:"toBlock"`
+	Changes   []Change `json:"changes"`
+}
+
+// Get the changes between two snapshots of the network
+func DiffNetworkStates(oldState *NetworkState, newState *NetworkState) *StateDiff {
+	diff := &StateDiff{
+		FromBlock: oldState.ElBlockNumber,
+		ToBlock:   newState.ElBlockNumber,
+		Changes:   []Change{},
+	}
+	diff.Changes = append(diff.Changes, DiffNetworkDetails(oldState.NetworkDetails, newState.NetworkDetails)...)
+	diff.Changes = append(diff.Changes, DiffNodeDetails(oldState.NodeDetails, newState.NodeDetails)...)
+	diff.Changes = append(diff.Changes, DiffMinipoolDetails(oldState.MinipoolDetails, newState.MinipoolDetails)...)
+	diff.Changes = append(diff.Changes, DiffOracleDaoMemberDetails(oldState.OracleDaoMemberDetails, newState.OracleDaoMemberDetails)...)
+	return diff
+}
+
+// Get the changes to the network details, including its settings
+func DiffNetworkDetails(oldDetails *NetworkDetails, newDetails *NetworkDetails) []Change {
+	if oldDetails == nil || newDetails == nil {
+		return []Change{}
+	}
+	template := Change{Entity: EntityNetwork}
+	return diffFields(template, "", reflect.ValueOf(*oldDetails), reflect.ValueOf(*newDetails), []Change{})
+}
+
+// Get the changes between two settings snapshots, comparing each setting by its raw value
+// The snapshots' block numbers aren't treated as a change
+func DiffSettingsDetails(oldDetails *SettingsDetails, newDetails *SettingsDetails) []Change {
+	if oldDetails == nil || newDetails == nil {
+		return []Change{}
+	}
+	newCopy := *newDetails
+	newCopy.ElBlockNumber = oldDetails.ElBlockNumber
+	template := Change{Entity: EntitySettings}
+	return diffFields(template, "", reflect.ValueOf(*oldDetails), reflect.ValueOf(newCopy), []Change{})
+}
+
+// Get the changes to a set of nodes, matched by node address
+func DiffNodeDetails(oldDetails []NativeNodeDetails, newDetails []NativeNodeDetails) []Change {
+	oldEntities := make([]diffEntity, len(oldDetails))
+	for i, details := range oldDetails {
+		oldEntities[i] = diffEntity{address: details.NodeAddress, nodeAddress: details.NodeAddress, value: reflect.ValueOf(details)}
+	}
+	newEntities := make([]diffEntity, len(newDetails))
+	for i, details := range newDetails {
+		newEntities[i] = diffEntity{address: details.NodeAddress, nodeAddress: details.NodeAddress, value: reflect.ValueOf(details)}
+	}
+	return diffEntities(EntityNode, oldEntities, newEntities)
+}
+
+// Get the changes to a set of minipools, matched by minipool address
+func DiffMinipoolDetails(oldDetails []NativeMinipoolDetails, newDetails []NativeMinipoolDetails) []Change {
+	oldEntities := make([]diffEntity, len(oldDetails))
+	for i, details := range oldDetails {
+		oldEntities[i] = diffEntity{address: details.MinipoolAddress, nodeAddress: details.NodeAddress, value: reflect.ValueOf(details)}
+	}
+	newEntities := make([]diffEntity, len(newDetails))
+	for i, details := range newDetails {
+		newEntities[i] = diffEntity{address: details.MinipoolAddress, nodeAddress: details.NodeAddress, value: reflect.ValueOf(details)}
+	}
+	return diffEntities(EntityMinipool, oldEntities, newEntities)
+}
+
+// Get the changes to a set of Oracle DAO members, matched by member address
+func DiffOracleDaoMemberDetails(oldDetails []OracleDaoMemberDetails, newDetails []OracleDaoMemberDetails) []Change {
+	oldEntities := make([]diffEntity, len(oldDetails))
+	for i, details := range oldDetails {
+		oldEntities[i] = diffEntity{address: details.Address, nodeAddress: details.Address, value: reflect.ValueOf(details)}
+	}
+	newEntities := make([]diffEntity, len(newDetails))
+	for i, details := range newDetails {
+		newEntities[i] = diffEntity{address: details.Address, nodeAddress: details.Address, value: reflect.ValueOf(details)}
+	}
+	return diffEntities(EntityOracleDaoMember, oldEntities, newEntities)
+}
+
+// Get the changes for a single node: the node itself, its minipools, and its Oracle DAO membership
+func (d *StateDiff) ForNode(nodeAddress common.Address) *StateDiff {
+	return d.Filter(func(change Change) bool {
+		return change.Entity != EntityNetwork && change.NodeAddress == nodeAddress
+	})
+}
+
+// Get the changes to any of the given fields; entities that were added or removed are included too
+func (d *StateDiff) ForFields(fields ...string) *StateDiff {
+	fieldSet := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		fieldSet[field] = true
+	}
+	return d.Filter(func(change Change) bool {
+		return change.Type != ChangeModified || fieldSet[change.Field]
+	})
+}
+
+// Get the changes to one kind of entity
+func (d *StateDiff) ForEntity(entity EntityType) *StateDiff {
+	return d.Filter(func(change Change) bool {
+		return change.Entity == entity
+	})
+}
+
+// Get the changes that match a filter
+func (d *StateDiff) Filter(filter func(change Change) bool) *StateDiff {
+	filtered := &StateDiff{
+		FromBlock: d.FromBlock,
+		ToBlock:   d.ToBlock,
+		Changes:   []Change{},
+	}
+	for _, change := range d.Changes {
+		if filter(change) {
+			filtered.Changes = append(filtered.Changes, change)
+		}
+	}
+	return filtered
+}
+
+// Check if there are no changes
+func (d *StateDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// An entity to compare, with the addresses it's keyed and filtered by
+type diffEntity struct {
+	address     common.Address
+	nodeAddress common.Address
+	value       reflect.Value
+}
+
+// Get the changes between two sets of entities, sorted by entity address
+func diffEntities(entity EntityType, oldEntities []diffEntity, newEntities []diffEntity) []Change {
+	oldByAddress := make(map[common.Address]diffEntity, len(oldEntities))
+	newByAddress := make(map[common.Address]diffEntity, len(newEntities))
+	addresses := []common.Address{}
+	for _, oldEntity := range oldEntities {
+		oldByAddress[oldEntity.address] = oldEntity
+		addresses = append(addresses, oldEntity.address)
+	}
+	for _, newEntity := range newEntities {
+		newByAddress[newEntity.address] = newEntity
+		if _, exists := oldByAddress[newEntity.address]; !exists {
+			addresses = append(addresses, newEntity.address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	changes := []Change{}
+	for _, address := range addresses {
+		oldEntity, oldExists := oldByAddress[address]
+		newEntity, newExists := newByAddress[address]
+		switch {
+		case !oldExists:
+			changes = append(changes, Change{Entity: entity, Address: address, NodeAddress: newEntity.nodeAddress, Type: ChangeAdded})
+		case !newExists:
+			changes = append(changes, Change{Entity: entity, Address: address, NodeAddress: oldEntity.nodeAddress, Type: ChangeRemoved})
+		default:
+			template := Change{Entity: entity, Address: address, NodeAddress: newEntity.nodeAddress}
+			changes = diffFields(template, "", oldEntity.value, newEntity.value, changes)
+		}
+	}
+	return changes
+}
+
+var (
+	bigIntType = reflect.TypeOf(&big.Int{})
+	timeType   = reflect.TypeOf(time.Time{})
+
+	// Setting values are compared as a whole by their raw value
+	settingTypes = map[reflect.Type]bool{
+		reflect.TypeOf(DurationSetting{}): true,
+		reflect.TypeOf(FractionSetting{}): true,
+		reflect.TypeOf(EtherSetting{}):    true,
+		reflect.TypeOf(TimeSetting{}):     true,
+		reflect.TypeOf(CountSetting{}):    true,
+	}
+)
+
+// Compare the exported fields of two structs, recursing into nested structs
+func diffFields(template Change, prefix string, oldValue reflect.Value, newValue reflect.Value, changes []Change) []Change {
+	structType := oldValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := prefix + field.Name
+		oldField := oldValue.Field(i)
+		newField := newValue.Field(i)
+
+		// Recurse into plain structs such as the queue capacity
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType && field.Type.NumField() > 0 && !isOpaqueStruct(field.Type) && !settingTypes[field.Type] {
+			changes = diffFields(template, name+".", oldField, newField, changes)
+			continue
+		}
+		if valuesEqual(oldField, newField) {
+			continue
+		}
+		change := template
+		change.Type = ChangeModified
+		change.Field = name
+		change.OldValue = oldField.Interface()
+		change.NewValue = newField.Interface()
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// Check if a struct's fields are all unexported, so it should be compared as a whole
+func isOpaqueStruct(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		if structType.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if two field values are equal, comparing big integers, times and settings by value
+func valuesEqual(oldValue reflect.Value, newValue reflect.Value) bool {
+	if settingTypes[oldValue.Type()] {
+		return bigIntsEqual(oldValue.FieldByName("Raw").Interface().(*big.Int), newValue.FieldByName("Raw").Interface().(*big.Int))
+	}
+	switch oldValue.Type() {
+	case bigIntType:
+		return bigIntsEqual(oldValue.Interface().(*big.Int), newValue.Interface().(*big.Int))
+	case timeType:
+		return oldValue.Interface().(time.Time).Equal(newValue.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(oldValue.Interface(), newValue.Interface())
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+)
+
+// The current version of the serialized network state format
+// Increment this whenever the layout of a snapshot changes in a way older readers can't handle
+const NetworkStateFormatVersion uint32 = 1
+
+// The magic bytes at the start of a binary network state
+var networkStateMagic = [4]byte{'R', 'P', 'N', 'S'}
+
+// The serialized form of a network state; the indexes are rebuilt on load
+type networkStateSnapshot struct {
+	FormatVersion              uint32                                `json:"formatVersion"`
+	ElBlockNumber              uint64                                `json:"elBlockNumber"`
+	NetworkVersion             string                                `json:"networkVersion"`
+	NetworkDetails             *NetworkDetails                       `json:"networkDetails"`
+	NodeDetails                []NativeNodeDetails                   `json:"nodeDetails"`
+	MinipoolDetails            []NativeMinipoolDetails               `json:"minipoolDetails"`
+	OracleDaoMemberDetails     []OracleDaoMemberDetails              `json:"oracleDaoMemberDetails"`
+	ProtocolDaoProposalDetails []protocol.ProtocolDaoProposalDetails `json:"protocolDaoProposalDetails"`
+}
+
+// Serialize the network state to versioned JSON
+func (s *NetworkState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.toSnapshot())
+}
+
+// Deserialize the network state from versioned JSON
+func (s *NetworkState) UnmarshalJSON(data []byte) error {
+	var snapshot networkStateSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("error decoding network state: %w", err)
+	}
+	return s.fromSnapshot(snapshot)
+}
+
+// Serialize the network state to the compact binary format:
+// the magic bytes "RPNS", the format version as a big-endian uint32, then the gzipped gob encoding of the snapshot
+func (s *NetworkState) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.Write(networkStateMagic[:])
+	if err := binary.Write(&buffer, binary.BigEndian, NetworkStateFormatVersion); err != nil {
+		return nil, err
+	}
+	writer := gzip.NewWriter(&buffer)
+	if err := gob.NewEncoder(writer).Encode(s.toSnapshot()); err != nil {
+		return nil, fmt.Errorf("error encoding network state: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing network state: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Deserialize the network state from the compact binary format
+func (s *NetworkState) UnmarshalBinary(data []byte) error {
+	if len(data) < len(networkStateMagic)+4 || !bytes.Equal(data[:len(networkStateMagic)], networkStateMagic[:]) {
+		return fmt.Errorf("data is not a binary network state")
+	}
+	formatVersion := binary.BigEndian.Uint32(data[len(networkStateMagic):])
+	if formatVersion > NetworkStateFormatVersion {
+		return fmt.Errorf("network state format version %d is newer than the supported version %d", formatVersion, NetworkStateFormatVersion)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data[len(networkStateMagic)+4:]))
+	if err != nil {
+		return fmt.Errorf("error decompressing network state: %w", err)
+	}
+	defer reader.Close()
+	var snapshot networkStateSnapshot
+	if err := gob.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return fmt.Errorf("error decoding network state: %w", err)
+	}
+	return s.fromSnapshot(snapshot)
+}
+
+// Get the serialized form of the network state
+func (s *NetworkState) toSnapshot() networkStateSnapshot {
+	return networkStateSnapshot{
+		FormatVersion:              NetworkStateFormatVersion,
+		ElBlockNumber:              s.ElBlockNumber,
+		NetworkVersion:             s.NetworkVersion,
+		NetworkDetails:             s.NetworkDetails,
+		NodeDetails:                s.NodeDetails,
+		MinipoolDetails:            s.MinipoolDetails,
+		OracleDaoMemberDetails:     s.OracleDaoMemberDetails,
+		ProtocolDaoProposalDetails: s.ProtocolDaoProposalDetails,
+	}
+}
+
+// Load the network state from its serialized form
+func (s *NetworkState) fromSnapshot(snapshot networkStateSnapshot) error {
+	if snapshot.FormatVersion == 0 || snapshot.FormatVersion > NetworkStateFormatVersion {
+		return fmt.Errorf("unsupported network state format version %d (supported: %d)", snapshot.FormatVersion, NetworkStateFormatVersion)
+	}
+	*s = NetworkState{
+		ElBlockNumber:              snapshot.ElBlockNumber,
+		NetworkVersion:             snapshot.NetworkVersion,
+		NetworkDetails:             snapshot.NetworkDetails,
+		NodeDetails:                snapshot.NodeDetails,
+		MinipoolDetails:            snapshot.MinipoolDetails,
+		OracleDaoMemberDetails:     snapshot.OracleDaoMemberDetails,
+		ProtocolDaoProposalDetails: snapshot.ProtocolDaoProposalDetails,
+	}
+	s.UpdateIndexes()
+	return nil
+}
@@ -0,0 +1,144 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A snapshot of the entire Rocket Pool network at a single EL block
+type NetworkState struct {
+	// The block the snapshot was taken at
+	ElBlockNumber uint64
+	// The protocol version deployed at that block
+	NetworkVersion string
+
+	// Network details
+	NetworkDetails *NetworkDetails
+
+	// Node details
+	NodeDetails []NativeNodeDetails
+
+	// Minipool details
+	MinipoolDetails []NativeMinipoolDetails
+
+	// Oracle DAO details
+	OracleDaoMemberDetails []OracleDaoMemberDetails
+
+	// Protocol DAO proposals; empty before on-chain pDAO voting was deployed
+	ProtocolDaoProposalDetails []protocol.ProtocolDaoProposalDetails
+
+	// Indexes
+	NodeDetailsByAddress            map[common.Address]*NativeNodeDetails
+	MinipoolDetailsByAddress        map[common.Address]*NativeMinipoolDetails
+	MinipoolDetailsByPubkey         map[types.ValidatorPubkey]*NativeMinipoolDetails
+	MinipoolDetailsByNode           map[common.Address][]*NativeMinipoolDetails
+	OracleDaoMemberDetailsByAddress map[common.Address]*OracleDaoMemberDetails
+}
+
+// Create a snapshot of the entire network at the block the network contracts were created for
+func NewNetworkState(rp *rocketpool.RocketPool, contracts *NetworkContracts) (*NetworkState, error) {
+
+	state := &NetworkState{
+		ElBlockNumber:              contracts.ElBlockNumber.Uint64(),
+		ProtocolDaoProposalDetails: []protocol.ProtocolDaoProposalDetails{},
+	}
+	if contracts.Version != nil {
+		state.NetworkVersion = contracts.Version.Original()
+	}
+
+	// The loaders share the network contracts' multicaller, so they're run one at a time
+	var err error
+	state.NetworkDetails, err = NewNetworkDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+	state.NodeDetails, err = GetAllNativeNodeDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting all node details: %w", err)
+	}
+	state.MinipoolDetails, err = GetAllNativeMinipoolDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting all minipool details: %w", err)
+	}
+	state.OracleDaoMemberDetails, err = GetAllOracleDaoMemberDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Oracle DAO details: %w", err)
+	}
+	if contracts.Capabilities.Has(rocketpool.FeatureOnChainPDaoVoting) {
+		state.ProtocolDaoProposalDetails, err = GetAllProtocolDaoProposalDetails(rp, contracts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Protocol DAO proposal details: %w", err)
+		}
+	}
+
+	// Link the minipools to their nodes and fill in the node fields that depend on them
+	state.UpdateIndexes()
+	for i := range state.NodeDetails {
+		node := state.NodeDetails[i]
+		err = CalculateAverageFeeAndDistributorShares(rp, contracts, node, state.MinipoolDetailsByNode[node.NodeAddress])
+		if err != nil {
+			return nil, fmt.Errorf("error calculating average fee and distributor shares for node %s: %w", node.NodeAddress.Hex(), err)
+		}
+	}
+
+	return state, nil
+
+}
+
+// Get a node's details by its address
+func (s *NetworkState) GetNode(nodeAddress common.Address) (*NativeNodeDetails, bool) {
+	details, exists := s.NodeDetailsByAddress[nodeAddress]
+	return details, exists
+}
+
+// Get a minipool's details by its address
+func (s *NetworkState) GetMinipool(minipoolAddress common.Address) (*NativeMinipoolDetails, bool) {
+	details, exists := s.MinipoolDetailsByAddress[minipoolAddress]
+	return details, exists
+}
+
+// Get a minipool's details by its validator pubkey
+func (s *NetworkState) GetMinipoolByPubkey(pubkey types.ValidatorPubkey) (*NativeMinipoolDetails, bool) {
+	details, exists := s.MinipoolDetailsByPubkey[pubkey]
+	return details, exists
+}
+
+// Get the details of a node's minipools
+func (s *NetworkState) GetNodeMinipools(nodeAddress common.Address) []*NativeMinipoolDetails {
+	return s.MinipoolDetailsByNode[nodeAddress]
+}
+
+// Get an Oracle DAO member's details by their address
+func (s *NetworkState) GetOracleDaoMember(memberAddress common.Address) (*OracleDaoMemberDetails, bool) {
+	details, exists := s.OracleDaoMemberDetailsByAddress[memberAddress]
+	return details, exists
+}
+
+// Rebuild the lookup indexes; call this after adding or removing details
+func (s *NetworkState) UpdateIndexes() {
+	s.NodeDetailsByAddress = make(map[common.Address]*NativeNodeDetails, len(s.NodeDetails))
+	for i := range s.NodeDetails {
+		details := &s.NodeDetails[i]
+		s.NodeDetailsByAddress[details.NodeAddress] = details
+	}
+
+	s.MinipoolDetailsByAddress = make(map[common.Address]*NativeMinipoolDetails, len(s.MinipoolDetails))
+	s.MinipoolDetailsByPubkey = make(map[types.ValidatorPubkey]*NativeMinipoolDetails, len(s.MinipoolDetails))
+	s.MinipoolDetailsByNode = make(map[common.Address][]*NativeMinipoolDetails, len(s.NodeDetails))
+	for i := range s.MinipoolDetails {
+		details := &s.MinipoolDetails[i]
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = details
+		s.MinipoolDetailsByPubkey[details.Pubkey] = details
+		s.MinipoolDetailsByNode[details.NodeAddress] = append(s.MinipoolDetailsByNode[details.NodeAddress], details)
+	}
+
+	s.OracleDaoMemberDetailsByAddress = make(map[common.Address]*OracleDaoMemberDetails, len(s.OracleDaoMemberDetails))
+	for i := range s.OracleDaoMemberDetails {
+		details := &s.OracleDaoMemberDetails[i]
+		s.OracleDaoMemberDetailsByAddress[details.Address] = details
+	}
+}
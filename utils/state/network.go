@@ -67,7 +67,7 @@ type NetworkDetails struct {
 }
 
 // Create a snapshot of all of the network's details
-func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts, isHoustonDeployed bool) (*NetworkDetails, error) {
+func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts) (*NetworkDetails, error) {
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
@@ -135,10 +135,11 @@ func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts, i
 	contracts.Multicaller.AddCall(contracts.RocketDepositPool, &details.DepositPoolUserBalance, "getUserBalance")
 
 	// Houston
-	if isHoustonDeployed {
+	if contracts.Capabilities.Has(rocketpool.FeatureSubmissionFrequencies) {
 		contracts.Multicaller.AddCall(contracts.RocketDAOProtocolSettingsNetwork, &pricesSubmissionFrequency, "getSubmitPricesFrequency")
 		contracts.Multicaller.AddCall(contracts.RocketDAOProtocolSettingsNetwork, &balancesSubmissionFrequency, "getSubmitBalancesFrequency")
-	} else {
+	}
+	if contracts.Capabilities.Has(rocketpool.FeatureLatestReportBlock) {
 		// getLatestReportableBlock was deprecated on Houston
 		contracts.Multicaller.AddCall(contracts.RocketNetworkPrices, &latestReportablePricesBlock, "getLatestReportableBlock")
 		contracts.Multicaller.AddCall(contracts.RocketNetworkBalances, &latestReportableBalancesBlock, "getLatestReportableBlock")
@@ -161,10 +162,11 @@ func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts, i
 	}
 	details.QueueLength = totalQueueLength
 	details.PricesBlock = pricesBlock.Uint64()
-	if !isHoustonDeployed {
+	if contracts.Capabilities.Has(rocketpool.FeatureLatestReportBlock) {
 		details.LatestReportablePricesBlock = latestReportablePricesBlock.Uint64()
 		details.LatestReportableBalancesBlock = latestReportableBalancesBlock.Uint64()
-	} else {
+	}
+	if contracts.Capabilities.Has(rocketpool.FeatureSubmissionFrequencies) {
 		details.PricesSubmissionFrequency = pricesSubmissionFrequency.Uint64()
 		details.BalancesSubmissionFrequency = balancesSubmissionFrequency.Uint64()
 	}
@@ -58,6 +58,9 @@ func GetProtocolDaoProposalDetails(rp *rocketpool.RocketPool, contracts *Network
 	details := protocol.ProtocolDaoProposalDetails{}
 	rawDetails := protocolDaoProposalDetailsRaw{}
 	details.ID = proposalID
+	if err := contracts.Capabilities.Require(rocketpool.FeatureOnChainPDaoVoting); err != nil {
+		return details, err
+	}
 
 	addProposalCalls(rp, contracts, contracts.Multicaller, &rawDetails, opts)
 
@@ -77,6 +80,10 @@ func GetAllProtocolDaoProposalDetails(rp *rocketpool.RocketPool, contracts *Netw
 		BlockNumber: contracts.ElBlockNumber,
 	}
 
+	if err := contracts.Capabilities.Require(rocketpool.FeatureOnChainPDaoVoting); err != nil {
+		return nil, err
+	}
+
 	// Get the number of proposals available
 	propCount, err := protocol.GetTotalProposalCount(rp, opts)
 	if err != nil {
@@ -0,0 +1,504 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// A setting stored as a number of seconds
+type DurationSetting struct {
+	Raw   *big.Int      `json:"raw"`
+	Value time.Duration `json:"value"`
+}
+
+// A setting stored as a fraction with 18 decimal places (e.g. 5% is stored as 5e16)
+type FractionSetting struct {
+	Raw   *big.Int `json:"raw"`
+	Value float64  `json:"value"`
+}
+
+// A setting stored as an amount of ETH or RPL in wei
+type EtherSetting struct {
+	Raw   *big.Int `json:"raw"`
+	Value float64  `json:"value"`
+}
+
+// A setting stored as a Unix timestamp
+type TimeSetting struct {
+	Raw   *big.Int  `json:"raw"`
+	Value time.Time `json:"value"`
+}
+
+// A setting stored as a plain number
+type CountSetting struct {
+	Raw   *big.Int `json:"raw"`
+	Value uint64   `json:"value"`
+}
+
+// Settings from rocketDAOProtocolSettingsAuction
+type AuctionSettingsDetails struct {
+	CreateLotEnabled      bool            `json:"createLotEnabled"`
+	BidOnLotEnabled       bool            `json:"bidOnLotEnabled"`
+	LotMinimumEthValue    EtherSetting    `json:"lotMinimumEthValue"`
+	LotMaximumEthValue    EtherSetting    `json:"lotMaximumEthValue"`
+	LotDuration           DurationSetting `json:"lotDuration"`
+	LotStartingPriceRatio FractionSetting `json:"lotStartingPriceRatio"`
+	LotReservePriceRatio  FractionSetting `json:"lotReservePriceRatio"`
+}
+
+// Settings from rocketDAOProtocolSettingsDeposit
+type DepositSettingsDetails struct {
+	DepositEnabled                      bool            `json:"depositEnabled"`
+	AssignDepositsEnabled               bool            `json:"assignDepositsEnabled"`
+	MinimumDeposit                      EtherSetting    `json:"minimumDeposit"`
+	MaximumDepositPoolSize              EtherSetting    `json:"maximumDepositPoolSize"`
+	MaximumDepositAssignments           CountSetting    `json:"maximumDepositAssignments"`
+	MaximumSocializedDepositAssignments CountSetting    `json:"maximumSocializedDepositAssignments"`
+	DepositFee                          FractionSetting `json:"depositFee"`
+}
+
+// Settings from rocketDAOProtocolSettingsInflation
+type InflationSettingsDetails struct {
+	IntervalRate FractionSetting `json:"intervalRate"`
+	StartTime    TimeSetting     `json:"startTime"`
+}
+
+// Settings from rocketDAOProtocolSettingsMinipool
+type MinipoolSettingsDetails struct {
+	SubmitWithdrawableEnabled bool            `json:"submitWithdrawableEnabled"`
+	LaunchTimeout             DurationSetting `json:"launchTimeout"`
+	MaximumCount              CountSetting    `json:"maximumCount"`
+
+	// Atlas
+	BondReductionEnabled       bool            `json:"bondReductionEnabled"`
+	UserDistributeWindowStart  DurationSetting `json:"userDistributeWindowStart"`
+	UserDistributeWindowLength DurationSetting `json:"userDistributeWindowLength"`
+}
+
+// Settings from rocketDAOProtocolSettingsNetwork
+type NetworkSettingsDetails struct {
+	NodeConsensusThreshold   FractionSetting `json:"nodeConsensusThreshold"`
+	SubmitBalancesEnabled    bool            `json:"submitBalancesEnabled"`
+	SubmitPricesEnabled      bool            `json:"submitPricesEnabled"`
+	MinimumNodeFee           FractionSetting `json:"minimumNodeFee"`
+	TargetNodeFee            FractionSetting `json:"targetNodeFee"`
+	MaximumNodeFee           FractionSetting `json:"maximumNodeFee"`
+	NodeFeeDemandRange       EtherSetting    `json:"nodeFeeDemandRange"`
+	TargetRethCollateralRate FractionSetting `json:"targetRethCollateralRate"`
+	NodePenaltyThreshold     FractionSetting `json:"nodePenaltyThreshold"`
+	PerPenaltyRate           FractionSetting `json:"perPenaltyRate"`
+
+	// Redstone
+	SubmitRewardsEnabled bool `json:"submitRewardsEnabled"`
+
+	// Houston
+	SubmitBalancesFrequency DurationSetting `json:"submitBalancesFrequency"`
+	SubmitPricesFrequency   DurationSetting `json:"submitPricesFrequency"`
+}
+
+// Settings from rocketDAOProtocolSettingsNode
+type NodeSettingsDetails struct {
+	RegistrationEnabled     bool            `json:"registrationEnabled"`
+	DepositEnabled          bool            `json:"depositEnabled"`
+	MinimumPerMinipoolStake FractionSetting `json:"minimumPerMinipoolStake"`
+	MaximumPerMinipoolStake FractionSetting `json:"maximumPerMinipoolStake"`
+
+	// Redstone
+	SmoothingPoolRegistrationEnabled bool `json:"smoothingPoolRegistrationEnabled"`
+
+	// Atlas
+	VacantMinipoolsEnabled bool `json:"vacantMinipoolsEnabled"`
+}
+
+// Settings from rocketDAOProtocolSettingsRewards
+type RewardsSettingsDetails struct {
+	ClaimersPercTotal FractionSetting `json:"claimersPercTotal"`
+	ClaimIntervalTime DurationSetting `json:"claimIntervalTime"`
+
+	// Houston
+	NodeOperatorPercent     FractionSetting `json:"nodeOperatorPercent"`
+	OracleDaoPercent        FractionSetting `json:"oracleDaoPercent"`
+	ProtocolDaoPercent      FractionSetting `json:"protocolDaoPercent"`
+	ClaimersPercTimeUpdated TimeSetting     `json:"claimersPercTimeUpdated"`
+}
+
+// Settings from rocketDAOProtocolSettingsProposals (Houston)
+type ProposalsSettingsDetails struct {
+	VotePhase1Time      DurationSetting `json:"votePhase1Time"`
+	VotePhase2Time      DurationSetting `json:"votePhase2Time"`
+	VoteDelayTime       DurationSetting `json:"voteDelayTime"`
+	ExecuteTime         DurationSetting `json:"executeTime"`
+	ProposalBond        EtherSetting    `json:"proposalBond"`
+	ChallengeBond       EtherSetting    `json:"challengeBond"`
+	ChallengePeriod     DurationSetting `json:"challengePeriod"`
+	ProposalQuorum      FractionSetting `json:"proposalQuorum"`
+	ProposalVetoQuorum  FractionSetting `json:"proposalVetoQuorum"`
+	ProposalMaxBlockAge CountSetting    `json:"proposalMaxBlockAge"`
+}
+
+// Settings from rocketDAOProtocolSettingsSecurity (Houston)
+type SecuritySettingsDetails struct {
+	MembersQuorum FractionSetting `json:"membersQuorum"`
+	LeaveTime     DurationSetting `json:"leaveTime"`
+	VoteTime      DurationSetting `json:"voteTime"`
+	ExecuteTime   DurationSetting `json:"executeTime"`
+	ActionTime    DurationSetting `json:"actionTime"`
+}
+
+// Settings from rocketDAONodeTrustedSettingsMembers
+type OracleDaoMembersSettingsDetails struct {
+	Quorum                 FractionSetting `json:"quorum"`
+	RplBond                EtherSetting    `json:"rplBond"`
+	MinipoolUnbondedMax    CountSetting    `json:"minipoolUnbondedMax"`
+	MinipoolUnbondedMinFee FractionSetting `json:"minipoolUnbondedMinFee"`
+	ChallengeCooldown      DurationSetting `json:"challengeCooldown"`
+	ChallengeWindow        DurationSetting `json:"challengeWindow"`
+	ChallengeCost          EtherSetting    `json:"challengeCost"`
+}
+
+// Settings from rocketDAONodeTrustedSettingsMinipool
+type OracleDaoMinipoolSettingsDetails struct {
+	ScrubPeriod         DurationSetting `json:"scrubPeriod"`
+	ScrubPenaltyEnabled bool            `json:"scrubPenaltyEnabled"`
+
+	// Atlas
+	PromotionScrubPeriod      DurationSetting `json:"promotionScrubPeriod"`
+	BondReductionWindowStart  DurationSetting `json:"bondReductionWindowStart"`
+	BondReductionWindowLength DurationSetting `json:"bondReductionWindowLength"`
+}
+
+// Settings from rocketDAONodeTrustedSettingsProposals
+type OracleDaoProposalsSettingsDetails struct {
+	CooldownTime  DurationSetting `json:"cooldownTime"`
+	VoteTime      DurationSetting `json:"voteTime"`
+	VoteDelayTime DurationSetting `json:"voteDelayTime"`
+	ExecuteTime   DurationSetting `json:"executeTime"`
+	ActionTime    DurationSetting `json:"actionTime"`
+}
+
+// A snapshot of every protocol and Oracle DAO setting at a single block
+// Settings that weren't deployed in the snapshot's protocol version are left empty
+type SettingsDetails struct {
+	ElBlockNumber  uint64 `json:"elBlockNumber"`
+	NetworkVersion string `json:"networkVersion"`
+
+	// Settings that predate feature tracking but couldn't be read from the deployed contracts, as <contract>.<getter>; these are left empty
+	FailedSettings []string `json:"failedSettings,omitempty"`
+
+	// Protocol DAO
+	Auction   AuctionSettingsDetails   `json:"auction"`
+	Deposit   DepositSettingsDetails   `json:"deposit"`
+	Inflation InflationSettingsDetails `json:"inflation"`
+	Minipool  MinipoolSettingsDetails  `json:"minipool"`
+	Network   NetworkSettingsDetails   `json:"network"`
+	Node      NodeSettingsDetails      `json:"node"`
+	Rewards   RewardsSettingsDetails   `json:"rewards"`
+
+	// Protocol DAO (Houston)
+	Proposals ProposalsSettingsDetails `json:"proposals"`
+	Security  SecuritySettingsDetails  `json:"security"`
+
+	// Oracle DAO
+	OracleDaoMembers   OracleDaoMembersSettingsDetails   `json:"oracleDaoMembers"`
+	OracleDaoMinipool  OracleDaoMinipoolSettingsDetails  `json:"oracleDaoMinipool"`
+	OracleDaoProposals OracleDaoProposalsSettingsDetails `json:"oracleDaoProposals"`
+}
+
+// A single setting getter
+type settingGetter struct {
+	contractName string
+	method       string
+	feature      rocketpool.Feature // The feature that introduced the setting, if any
+	output       interface{}
+	convert      func()
+}
+
+// A contract method a setting is read from
+type SettingMethod struct {
+	ContractName string
+	Method       string
+	Feature      rocketpool.Feature // The feature that introduced the setting, if any
+}
+
+// Get the contract methods every setting in a snapshot is read from
+func GetSettingMethods() []SettingMethod {
+	getters := getSettingGetters(&SettingsDetails{})
+	methods := make([]SettingMethod, len(getters))
+	for i, getter := range getters {
+		methods[i] = SettingMethod{
+			ContractName: getter.contractName,
+			Method:       getter.method,
+			Feature:      getter.feature,
+		}
+	}
+	return methods
+}
+
+// Create a snapshot of every setting at the block the network contracts were created for
+// This takes a single multicall round trip, using the settings contracts loaded with the network contracts
+// Settings the protocol version's features say are deployed must be readable; other settings that can't be read are recorded in FailedSettings
+func NewSettingsDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts) (*SettingsDetails, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	details := &SettingsDetails{
+		ElBlockNumber: contracts.ElBlockNumber.Uint64(),
+	}
+	if contracts.Version != nil {
+		details.NetworkVersion = contracts.Version.Original()
+	}
+
+	// Read the settings that are deployed at this block; calls are allowed to fail so settings missing from older contract versions are left empty
+	settingsContracts := contracts.getSettingsContracts()
+	getters := []settingGetter{}
+	calledGetters := []settingGetter{}
+	for _, getter := range getSettingGetters(details) {
+		if getter.feature != "" && !contracts.Capabilities.Has(getter.feature) {
+			continue
+		}
+		getters = append(getters, getter)
+		contract := settingsContracts[getter.contractName]
+		if contract == nil {
+			if err := details.addFailedSetting(getter); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, exists := contract.ABI.Methods[getter.method]; !exists {
+			if err := details.addFailedSetting(getter); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := contracts.Multicaller.AddCall(contract, getter.output, getter.method); err != nil {
+			return nil, fmt.Errorf("error adding %s.%s to the multicall: %w", getter.contractName, getter.method, err)
+		}
+		calledGetters = append(calledGetters, getter)
+	}
+	results, err := contracts.Multicaller.FlexibleCall(false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error executing multicall for settings: %w", err)
+	}
+	for i, result := range results {
+		if result.Success {
+			continue
+		}
+		if err := details.addFailedSetting(calledGetters[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Convert the raw values
+	for _, getter := range getters {
+		if getter.convert != nil {
+			getter.convert()
+		}
+	}
+	return details, nil
+}
+
+// Record a setting that couldn't be read, or fail if the protocol version's features say it's deployed
+func (details *SettingsDetails) addFailedSetting(getter settingGetter) error {
+	if getter.feature != "" {
+		return fmt.Errorf("error reading %s.%s, which is deployed with feature %s", getter.contractName, getter.method, getter.feature)
+	}
+	details.FailedSettings = append(details.FailedSettings, fmt.Sprintf("%s.%s", getter.contractName, getter.method))
+	return nil
+}
+
+// Get the settings contracts, keyed by name; contracts that weren't loaded are nil
+func (c *NetworkContracts) getSettingsContracts() map[string]*rocketpool.Contract {
+	return map[string]*rocketpool.Contract{
+		psettings.AuctionSettingsContractName:    c.RocketDAOProtocolSettingsAuction,
+		psettings.DepositSettingsContractName:    c.RocketDAOProtocolSettingsDeposit,
+		psettings.InflationSettingsContractName:  c.RocketDAOProtocolSettingsInflation,
+		psettings.MinipoolSettingsContractName:   c.RocketDAOProtocolSettingsMinipool,
+		psettings.NetworkSettingsContractName:    c.RocketDAOProtocolSettingsNetwork,
+		psettings.NodeSettingsContractName:       c.RocketDAOProtocolSettingsNode,
+		psettings.RewardsSettingsContractName:    c.RocketDAOProtocolSettingsRewards,
+		psettings.ProposalsSettingsContractName:  c.RocketDAOProtocolSettingsProposals,
+		psettings.SecuritySettingsContractName:   c.RocketDAOProtocolSettingsSecurity,
+		tnsettings.MembersSettingsContractName:   c.RocketDAONodeTrustedSettingsMembers,
+		tnsettings.MinipoolSettingsContractName:  c.RocketDAONodeTrustedSettingsMinipool,
+		tnsettings.ProposalsSettingsContractName: c.RocketDAONodeTrustedSettingsProposals,
+	}
+}
+
+// Get the getters for every setting, writing into the provided details
+func getSettingGetters(details *SettingsDetails) []settingGetter {
+	auction := psettings.AuctionSettingsContractName
+	deposit := psettings.DepositSettingsContractName
+	inflation := psettings.InflationSettingsContractName
+	minipool := psettings.MinipoolSettingsContractName
+	network := psettings.NetworkSettingsContractName
+	node := psettings.NodeSettingsContractName
+	rewards := psettings.RewardsSettingsContractName
+	proposals := psettings.ProposalsSettingsContractName
+	security := psettings.SecuritySettingsContractName
+	odaoMembers := tnsettings.MembersSettingsContractName
+	odaoMinipool := tnsettings.MinipoolSettingsContractName
+	odaoProposals := tnsettings.ProposalsSettingsContractName
+	houston := rocketpool.FeatureOnChainPDaoVoting
+
+	return []settingGetter{
+		// Auction
+		boolGetter(auction, "getCreateLotEnabled", "", &details.Auction.CreateLotEnabled),
+		boolGetter(auction, "getBidOnLotEnabled", "", &details.Auction.BidOnLotEnabled),
+		etherGetter(auction, "getLotMinimumEthValue", "", &details.Auction.LotMinimumEthValue),
+		etherGetter(auction, "getLotMaximumEthValue", "", &details.Auction.LotMaximumEthValue),
+		durationGetter(auction, "getLotDuration", "", &details.Auction.LotDuration),
+		fractionGetter(auction, "getStartingPriceRatio", "", &details.Auction.LotStartingPriceRatio),
+		fractionGetter(auction, "getReservePriceRatio", "", &details.Auction.LotReservePriceRatio),
+
+		// Deposit
+		boolGetter(deposit, "getDepositEnabled", "", &details.Deposit.DepositEnabled),
+		boolGetter(deposit, "getAssignDepositsEnabled", "", &details.Deposit.AssignDepositsEnabled),
+		etherGetter(deposit, "getMinimumDeposit", "", &details.Deposit.MinimumDeposit),
+		etherGetter(deposit, "getMaximumDepositPoolSize", "", &details.Deposit.MaximumDepositPoolSize),
+		countGetter(deposit, "getMaximumDepositAssignments", "", &details.Deposit.MaximumDepositAssignments),
+		countGetter(deposit, "getMaximumDepositSocialisedAssignments", rocketpool.FeatureVacantMinipools, &details.Deposit.MaximumSocializedDepositAssignments),
+		fractionGetter(deposit, "getDepositFee", "", &details.Deposit.DepositFee),
+
+		// Inflation
+		fractionGetter(inflation, "getInflationIntervalRate", "", &details.Inflation.IntervalRate),
+		timeGetter(inflation, "getInflationIntervalStartTime", "", &details.Inflation.StartTime),
+
+		// Minipool
+		boolGetter(minipool, "getSubmitWithdrawableEnabled", "", &details.Minipool.SubmitWithdrawableEnabled),
+		durationGetter(minipool, "getLaunchTimeout", "", &details.Minipool.LaunchTimeout),
+		countGetter(minipool, "getMaximumCount", "", &details.Minipool.MaximumCount),
+		boolGetter(minipool, "getBondReductionEnabled", rocketpool.FeatureBondReduction, &details.Minipool.BondReductionEnabled),
+		durationGetter(minipool, "getUserDistributeWindowStart", rocketpool.FeatureBondReduction, &details.Minipool.UserDistributeWindowStart),
+		durationGetter(minipool, "getUserDistributeWindowLength", rocketpool.FeatureBondReduction, &details.Minipool.UserDistributeWindowLength),
+
+		// Network
+		fractionGetter(network, "getNodeConsensusThreshold", "", &details.Network.NodeConsensusThreshold),
+		boolGetter(network, "getSubmitBalancesEnabled", "", &details.Network.SubmitBalancesEnabled),
+		boolGetter(network, "getSubmitPricesEnabled", "", &details.Network.SubmitPricesEnabled),
+		fractionGetter(network, "getMinimumNodeFee", "", &details.Network.MinimumNodeFee),
+		fractionGetter(network, "getTargetNodeFee", "", &details.Network.TargetNodeFee),
+		fractionGetter(network, "getMaximumNodeFee", "", &details.Network.MaximumNodeFee),
+		etherGetter(network, "getNodeFeeDemandRange", "", &details.Network.NodeFeeDemandRange),
+		fractionGetter(network, "getTargetRethCollateralRate", "", &details.Network.TargetRethCollateralRate),
+		fractionGetter(network, "getNodePenaltyThreshold", "", &details.Network.NodePenaltyThreshold),
+		fractionGetter(network, "getPerPenaltyRate", "", &details.Network.PerPenaltyRate),
+		boolGetter(network, "getSubmitRewardsEnabled", rocketpool.FeatureMerkleRewards, &details.Network.SubmitRewardsEnabled),
+		durationGetter(network, "getSubmitBalancesFrequency", rocketpool.FeatureSubmissionFrequencies, &details.Network.SubmitBalancesFrequency),
+		durationGetter(network, "getSubmitPricesFrequency", rocketpool.FeatureSubmissionFrequencies, &details.Network.SubmitPricesFrequency),
+
+		// Node
+		boolGetter(node, "getRegistrationEnabled", "", &details.Node.RegistrationEnabled),
+		boolGetter(node, "getDepositEnabled", "", &details.Node.DepositEnabled),
+		fractionGetter(node, "getMinimumPerMinipoolStake", "", &details.Node.MinimumPerMinipoolStake),
+		fractionGetter(node, "getMaximumPerMinipoolStake", "", &details.Node.MaximumPerMinipoolStake),
+		boolGetter(node, "getSmoothingPoolRegistrationEnabled", rocketpool.FeatureSmoothingPool, &details.Node.SmoothingPoolRegistrationEnabled),
+		boolGetter(node, "getVacantMinipoolsEnabled", rocketpool.FeatureVacantMinipools, &details.Node.VacantMinipoolsEnabled),
+
+		// Rewards
+		fractionGetter(rewards, "getRewardsClaimersPercTotal", "", &details.Rewards.ClaimersPercTotal),
+		durationGetter(rewards, "getRewardsClaimIntervalTime", "", &details.Rewards.ClaimIntervalTime),
+		fractionGetter(rewards, "getRewardsClaimersNodePerc", houston, &details.Rewards.NodeOperatorPercent),
+		fractionGetter(rewards, "getRewardsClaimersTrustedNodePerc", houston, &details.Rewards.OracleDaoPercent),
+		fractionGetter(rewards, "getRewardsClaimersProtocolPerc", houston, &details.Rewards.ProtocolDaoPercent),
+		timeGetter(rewards, "getRewardsClaimersTimeUpdated", houston, &details.Rewards.ClaimersPercTimeUpdated),
+
+		// Proposals
+		durationGetter(proposals, "getVotePhase1Time", houston, &details.Proposals.VotePhase1Time),
+		durationGetter(proposals, "getVotePhase2Time", houston, &details.Proposals.VotePhase2Time),
+		durationGetter(proposals, "getVoteDelayTime", houston, &details.Proposals.VoteDelayTime),
+		durationGetter(proposals, "getExecuteTime", houston, &details.Proposals.ExecuteTime),
+		etherGetter(proposals, "getProposalBond", houston, &details.Proposals.ProposalBond),
+		etherGetter(proposals, "getChallengeBond", houston, &details.Proposals.ChallengeBond),
+		durationGetter(proposals, "getChallengePeriod", houston, &details.Proposals.ChallengePeriod),
+		fractionGetter(proposals, "getProposalQuorum", houston, &details.Proposals.ProposalQuorum),
+		fractionGetter(proposals, "getProposalVetoQuorum", houston, &details.Proposals.ProposalVetoQuorum),
+		countGetter(proposals, "getProposalMaxBlockAge", houston, &details.Proposals.ProposalMaxBlockAge),
+
+		// Security
+		fractionGetter(security, "getQuorum", houston, &details.Security.MembersQuorum),
+		durationGetter(security, "getLeaveTime", houston, &details.Security.LeaveTime),
+		durationGetter(security, "getVoteTime", houston, &details.Security.VoteTime),
+		durationGetter(security, "getExecuteTime", houston, &details.Security.ExecuteTime),
+		durationGetter(security, "getActionTime", houston, &details.Security.ActionTime),
+
+		// Oracle DAO members
+		fractionGetter(odaoMembers, "getQuorum", "", &details.OracleDaoMembers.Quorum),
+		etherGetter(odaoMembers, "getRPLBond", "", &details.OracleDaoMembers.RplBond),
+		countGetter(odaoMembers, "getMinipoolUnbondedMax", "", &details.OracleDaoMembers.MinipoolUnbondedMax),
+		fractionGetter(odaoMembers, "getMinipoolUnbondedMinFee", "", &details.OracleDaoMembers.MinipoolUnbondedMinFee),
+		durationGetter(odaoMembers, "getChallengeCooldown", "", &details.OracleDaoMembers.ChallengeCooldown),
+		durationGetter(odaoMembers, "getChallengeWindow", "", &details.OracleDaoMembers.ChallengeWindow),
+		etherGetter(odaoMembers, "getChallengeCost", "", &details.OracleDaoMembers.ChallengeCost),
+
+		// Oracle DAO minipools
+		durationGetter(odaoMinipool, "getScrubPeriod", "", &details.OracleDaoMinipool.ScrubPeriod),
+		boolGetter(odaoMinipool, "getScrubPenaltyEnabled", "", &details.OracleDaoMinipool.ScrubPenaltyEnabled),
+		durationGetter(odaoMinipool, "getPromotionScrubPeriod", rocketpool.FeatureVacantMinipools, &details.OracleDaoMinipool.PromotionScrubPeriod),
+		durationGetter(odaoMinipool, "getBondReductionWindowStart", rocketpool.FeatureBondReduction, &details.OracleDaoMinipool.BondReductionWindowStart),
+		durationGetter(odaoMinipool, "getBondReductionWindowLength", rocketpool.FeatureBondReduction, &details.OracleDaoMinipool.BondReductionWindowLength),
+
+		// Oracle DAO proposals
+		durationGetter(odaoProposals, "getCooldownTime", "", &details.OracleDaoProposals.CooldownTime),
+		durationGetter(odaoProposals, "getVoteTime", "", &details.OracleDaoProposals.VoteTime),
+		durationGetter(odaoProposals, "getVoteDelayTime", "", &details.OracleDaoProposals.VoteDelayTime),
+		durationGetter(odaoProposals, "getExecuteTime", "", &details.OracleDaoProposals.ExecuteTime),
+		durationGetter(odaoProposals, "getActionTime", "", &details.OracleDaoProposals.ActionTime),
+	}
+}
+
+// Get a getter for a boolean setting
+func boolGetter(contractName string, method string, feature rocketpool.Feature, value *bool) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: value}
+}
+
+// Get a getter for a setting stored in seconds
+func durationGetter(contractName string, method string, feature rocketpool.Feature, setting *DurationSetting) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: &setting.Raw, convert: func() {
+		if setting.Raw != nil {
+			setting.Value = convertToDuration(setting.Raw)
+		}
+	}}
+}
+
+// Get a getter for a setting stored as an 18-decimal fraction
+func fractionGetter(contractName string, method string, feature rocketpool.Feature, setting *FractionSetting) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: &setting.Raw, convert: func() {
+		if setting.Raw != nil {
+			setting.Value = eth.WeiToEth(setting.Raw)
+		}
+	}}
+}
+
+// Get a getter for a setting stored in wei
+func etherGetter(contractName string, method string, feature rocketpool.Feature, setting *EtherSetting) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: &setting.Raw, convert: func() {
+		if setting.Raw != nil {
+			setting.Value = eth.WeiToEth(setting.Raw)
+		}
+	}}
+}
+
+// Get a getter for a setting stored as a Unix timestamp
+func timeGetter(contractName string, method string, feature rocketpool.Feature, setting *TimeSetting) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: &setting.Raw, convert: func() {
+		if setting.Raw != nil {
+			setting.Value = convertToTime(setting.Raw)
+		}
+	}}
+}
+
+// Get a getter for a setting stored as a plain number
+func countGetter(contractName string, method string, feature rocketpool.Feature, setting *CountSetting) settingGetter {
+	return settingGetter{contractName: contractName, method: method, feature: feature, output: &setting.Raw, convert: func() {
+		if setting.Raw != nil {
+			setting.Value = setting.Raw.Uint64()
+		}
+	}}
+}
@@ -0,0 +1,692 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Network details that every node's RPL stake limits depend on
+var nodeStakeDependencies = []string{"RplPrice", "MinCollateralFraction", "MaxCollateralFraction"}
+
+// Oracle DAO contracts whose events change member details
+var oracleDaoContractNames = []string{"rocketDAONodeTrusted", "rocketDAONodeTrustedActions", "rocketDAONodeTrustedProposals"}
+
+// The number of minipool addresses to filter logs for in a single request
+const minipoolLogAddressBatchSize int = 1000
+
+// The result of bringing a network state up to date
+type UpdateResult struct {
+	FromBlock        uint64 `json:"fromBlock"`
+	ToBlock          uint64 `json:"toBlock"`
+	FullReload       bool   `json:"fullReload"`
+	FullReloadReason string `json:"fullReloadReason,omitempty"`
+	LogCount         int    `json:"logCount"`
+	UpdatedNodes     int    `json:"updatedNodes"`
+	UpdatedMinipools int    `json:"updatedMinipools"`
+	RemovedMinipools int    `json:"removedMinipools"`
+	UpdatedOracleDao bool   `json:"updatedOracleDao"`
+	UpdatedProposals int    `json:"updatedProposals"`
+}
+
+// The entities affected by the events since a state's block
+type EventChanges struct {
+	DirtyNodes         map[common.Address]bool
+	DirtyMinipools     map[common.Address]bool
+	NewNodes           []common.Address
+	NewMinipools       []common.Address
+	DestroyedMinipools map[common.Address]bool
+	UpdateOracleDao    bool
+}
+
+// Keeps a network state up to date by applying the changes signalled by Rocket Pool events, block by block
+// Only the nodes and minipools touched by an event (or whose ETH balance moved) are refetched; contract upgrades and reorgs trigger a full reload
+type StateUpdater struct {
+	rp                    *rocketpool.RocketPool
+	multicallerAddress    common.Address
+	balanceBatcherAddress common.Address
+	state                 *NetworkState
+	contracts             *NetworkContracts
+	blockHash             common.Hash
+	minipoolDelegate      common.Address
+}
+
+// Create a state updater, starting from a full snapshot at the block in the call options (or the latest block)
+func NewStateUpdater(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, opts *bind.CallOpts) (*StateUpdater, error) {
+	updater := &StateUpdater{
+		rp:                    rp,
+		multicallerAddress:    multicallerAddress,
+		balanceBatcherAddress: balanceBatcherAddress,
+	}
+	contracts, err := NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := updater.reload(contracts); err != nil {
+		return nil, err
+	}
+	return updater, nil
+}
+
+// Create a state updater, starting from an existing snapshot (e.g. one loaded from an archive)
+func NewStateUpdaterFromState(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, state *NetworkState) (*StateUpdater, error) {
+	updater := &StateUpdater{
+		rp:                    rp,
+		multicallerAddress:    multicallerAddress,
+		balanceBatcherAddress: balanceBatcherAddress,
+		state:                 state,
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: new(big.Int).SetUint64(state.ElBlockNumber),
+	}
+	contracts, err := NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := updater.setBlock(contracts); err != nil {
+		return nil, err
+	}
+	return updater, nil
+}
+
+// Get the current network state
+// Updates create a new state rather than modifying this one, so it's safe to keep for comparisons
+func (u *StateUpdater) GetState() *NetworkState {
+	return u.state
+}
+
+// Get the network contracts at the current state's block
+func (u *StateUpdater) GetContracts() *NetworkContracts {
+	return u.contracts
+}
+
+// Bring the network state up to the given block
+func (u *StateUpdater) Update(blockNumber uint64) (UpdateResult, error) {
+	result := UpdateResult{
+		FromBlock: u.state.ElBlockNumber,
+		ToBlock:   blockNumber,
+	}
+	if blockNumber == u.state.ElBlockNumber {
+		return result, nil
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+	}
+	contracts, err := NewNetworkContracts(u.rp, u.multicallerAddress, u.balanceBatcherAddress, opts)
+	if err != nil {
+		return result, err
+	}
+
+	// Fall back to a full reload if incremental updates aren't safe
+	reason, err := u.getFullReloadReason(contracts, blockNumber)
+	if err != nil {
+		return result, err
+	}
+	if reason == "" {
+		reason, err = u.updateFromEvents(contracts, &result)
+		if err != nil {
+			return result, err
+		}
+	}
+	if reason != "" {
+		result = UpdateResult{
+			FromBlock:        result.FromBlock,
+			ToBlock:          blockNumber,
+			FullReload:       true,
+			FullReloadReason: reason,
+		}
+		if err := u.reload(contracts); err != nil {
+			return result, err
+		}
+		result.UpdatedNodes = len(u.state.NodeDetails)
+		result.UpdatedMinipools = len(u.state.MinipoolDetails)
+		result.UpdatedOracleDao = true
+		result.UpdatedProposals = len(u.state.ProtocolDaoProposalDetails)
+	}
+	return result, nil
+}
+
+// Check if the state has to be rebuilt from scratch to reach the new block
+func (u *StateUpdater) getFullReloadReason(contracts *NetworkContracts, blockNumber uint64) (string, error) {
+	if blockNumber < u.state.ElBlockNumber {
+		return fmt.Sprintf("block %d is before the current state's block %d", blockNumber, u.state.ElBlockNumber), nil
+	}
+
+	// Check for reorgs
+	header, err := u.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(u.state.ElBlockNumber))
+	if err != nil {
+		return "", fmt.Errorf("error getting header for block %d: %w", u.state.ElBlockNumber, err)
+	}
+	if header.Hash() != u.blockHash {
+		return fmt.Sprintf("block %d was reorged", u.state.ElBlockNumber), nil
+	}
+
+	// Check for upgrades
+	if reason := GetUpgradeReason(u.contracts, contracts); reason != "" {
+		return reason, nil
+	}
+	minipoolDelegate, err := u.rp.GetAddress("rocketMinipoolDelegate", &bind.CallOpts{BlockNumber: contracts.ElBlockNumber})
+	if err != nil {
+		return "", err
+	}
+	if *minipoolDelegate != u.minipoolDelegate {
+		return "contract rocketMinipoolDelegate was upgraded", nil
+	}
+	return "", nil
+}
+
+// Apply the changes signalled by the events since the current state's block
+// Returns the reason for a full reload if the resulting state doesn't match the network's node and minipool counts
+func (u *StateUpdater) updateFromEvents(contracts *NetworkContracts, result *UpdateResult) (string, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	oldState := u.state
+	fromBlock := new(big.Int).SetUint64(oldState.ElBlockNumber + 1)
+	toBlock := contracts.ElBlockNumber
+
+	// Get the events from the Rocket Pool contracts
+	watchedContracts := getWatchedContracts(contracts)
+	oracleDaoAddresses := map[common.Address]bool{}
+	for _, contractName := range oracleDaoContractNames {
+		address, err := u.rp.GetAddress(contractName, opts)
+		if err != nil {
+			return "", err
+		}
+		oracleDaoAddresses[*address] = true
+	}
+	addresses := make([]common.Address, 0, len(watchedContracts)+len(oracleDaoAddresses))
+	for _, contract := range watchedContracts {
+		addresses = append(addresses, *contract.Address)
+	}
+	for address := range oracleDaoAddresses {
+		addresses = append(addresses, address)
+	}
+	logs, err := eth.GetLogs(u.rp, addresses, nil, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting Rocket Pool events: %w", err)
+	}
+
+	// Get the events the known minipools emitted themselves, such as status updates
+	// New minipools are refetched anyway, so their events don't matter
+	delegateAbi, err := u.rp.GetABI("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return "", err
+	}
+	minipoolTopics := make([]common.Hash, 0, len(delegateAbi.Events))
+	for _, event := range delegateAbi.Events {
+		minipoolTopics = append(minipoolTopics, event.ID)
+	}
+	minipoolAddresses := make([]common.Address, len(oldState.MinipoolDetails))
+	for i, details := range oldState.MinipoolDetails {
+		minipoolAddresses[i] = details.MinipoolAddress
+	}
+	minipoolLogs := []ethtypes.Log{}
+	for i := 0; i < len(minipoolAddresses); i += minipoolLogAddressBatchSize {
+		end := i + minipoolLogAddressBatchSize
+		if end > len(minipoolAddresses) {
+			end = len(minipoolAddresses)
+		}
+		batchLogs, err := eth.GetLogs(u.rp, minipoolAddresses[i:end], [][]common.Hash{minipoolTopics}, nil, fromBlock, toBlock, nil)
+		if err != nil {
+			return "", fmt.Errorf("error getting minipool events: %w", err)
+		}
+		minipoolLogs = append(minipoolLogs, batchLogs...)
+	}
+	result.LogCount = len(logs)
+
+	// Find the affected entities
+	changes := GetEventChanges(oldState, contracts, oracleDaoAddresses, logs, minipoolLogs)
+
+	// Reload the network details; settings updates and price or balance submissions all show up here
+	networkDetails, err := NewNetworkDetails(u.rp, contracts)
+	if err != nil {
+		return "", fmt.Errorf("error getting network details: %w", err)
+	}
+	if len(filterFieldChanges(DiffNetworkDetails(oldState.NetworkDetails, networkDetails), nodeStakeDependencies)) > 0 {
+		for _, details := range oldState.NodeDetails {
+			changes.DirtyNodes[details.NodeAddress] = true
+		}
+	}
+
+	// ETH transfers don't emit events, so check the balances directly
+	minipoolBalances, err := contracts.BalanceBatcher.GetEthBalances(minipoolAddresses, opts)
+	if err != nil {
+		return "", fmt.Errorf("error getting minipool balances: %w", err)
+	}
+	for i, details := range oldState.MinipoolDetails {
+		if !bigIntsEqual(details.Balance, minipoolBalances[i]) {
+			changes.MarkMinipool(oldState, details.MinipoolAddress)
+		}
+	}
+	nodeAddresses := make([]common.Address, len(oldState.NodeDetails))
+	distributorAddresses := make([]common.Address, len(oldState.NodeDetails))
+	for i, details := range oldState.NodeDetails {
+		nodeAddresses[i] = details.NodeAddress
+		distributorAddresses[i] = details.FeeDistributorAddress
+	}
+	nodeBalances, err := contracts.BalanceBatcher.GetEthBalances(nodeAddresses, opts)
+	if err != nil {
+		return "", fmt.Errorf("error getting node balances: %w", err)
+	}
+	distributorBalances, err := contracts.BalanceBatcher.GetEthBalances(distributorAddresses, opts)
+	if err != nil {
+		return "", fmt.Errorf("error getting distributor balances: %w", err)
+	}
+
+	// Refetch the affected nodes
+	nodeRefetches := append(sortAddresses(changes.DirtyNodes), changes.NewNodes...)
+	refetchedNodes, err := getBulkNodeDetails(u.rp, contracts, nodeRefetches, opts)
+	if err != nil {
+		return "", err
+	}
+	refetchedNodesByAddress := make(map[common.Address]NativeNodeDetails, len(refetchedNodes))
+	for _, details := range refetchedNodes {
+		refetchedNodesByAddress[details.NodeAddress] = details
+	}
+
+	// Refetch the affected minipools
+	minipoolRefetches := append(sortAddresses(changes.DirtyMinipools), changes.NewMinipools...)
+	versions, err := getMinipoolVersionsFast(u.rp, contracts, minipoolRefetches, opts)
+	if err != nil {
+		return "", fmt.Errorf("error getting minipool versions: %w", err)
+	}
+	refetchedMinipools, err := getBulkMinipoolDetails(u.rp, contracts, minipoolRefetches, versions, opts)
+	if err != nil {
+		return "", err
+	}
+	refetchedMinipoolsByAddress := make(map[common.Address]NativeMinipoolDetails, len(refetchedMinipools))
+	for _, details := range refetchedMinipools {
+		refetchedMinipoolsByAddress[details.MinipoolAddress] = details
+	}
+
+	// Build the new state
+	newState := &NetworkState{
+		ElBlockNumber:              toBlock.Uint64(),
+		NetworkVersion:             contracts.Version.Original(),
+		NetworkDetails:             networkDetails,
+		NodeDetails:                make([]NativeNodeDetails, 0, len(oldState.NodeDetails)+len(changes.NewNodes)),
+		MinipoolDetails:            make([]NativeMinipoolDetails, 0, len(oldState.MinipoolDetails)+len(changes.NewMinipools)),
+		OracleDaoMemberDetails:     oldState.OracleDaoMemberDetails,
+		ProtocolDaoProposalDetails: oldState.ProtocolDaoProposalDetails,
+	}
+	recalculateNodes := map[common.Address]bool{}
+	for i, details := range oldState.NodeDetails {
+		if refetched, exists := refetchedNodesByAddress[details.NodeAddress]; exists {
+			details = refetched
+			recalculateNodes[details.NodeAddress] = true
+		} else if !bigIntsEqual(details.BalanceETH, nodeBalances[i]) || !bigIntsEqual(details.DistributorBalance, distributorBalances[i]) {
+			details.BalanceETH = nodeBalances[i]
+			details.DistributorBalance = distributorBalances[i]
+			recalculateNodes[details.NodeAddress] = true
+		}
+		newState.NodeDetails = append(newState.NodeDetails, details)
+	}
+	for _, address := range changes.NewNodes {
+		newState.NodeDetails = append(newState.NodeDetails, refetchedNodesByAddress[address])
+		recalculateNodes[address] = true
+	}
+	for _, details := range oldState.MinipoolDetails {
+		if changes.DestroyedMinipools[details.MinipoolAddress] {
+			result.RemovedMinipools++
+			continue
+		}
+		if refetched, exists := refetchedMinipoolsByAddress[details.MinipoolAddress]; exists {
+			if !refetched.Exists {
+				// The minipool was destroyed
+				result.RemovedMinipools++
+				continue
+			}
+			details = refetched
+		}
+		newState.MinipoolDetails = append(newState.MinipoolDetails, details)
+	}
+	for _, address := range changes.NewMinipools {
+		newState.MinipoolDetails = append(newState.MinipoolDetails, refetchedMinipoolsByAddress[address])
+	}
+	result.UpdatedNodes = len(refetchedNodes)
+	result.UpdatedMinipools = len(refetchedMinipools)
+
+	// Refresh the Oracle DAO if any of its contracts emitted events
+	if changes.UpdateOracleDao {
+		newState.OracleDaoMemberDetails, err = GetAllOracleDaoMemberDetails(u.rp, contracts)
+		if err != nil {
+			return "", fmt.Errorf("error getting Oracle DAO details: %w", err)
+		}
+		result.UpdatedOracleDao = true
+	}
+
+	// Refresh new proposals and the ones that can still change state
+	if contracts.Capabilities.Has(rocketpool.FeatureOnChainPDaoVoting) {
+		newState.ProtocolDaoProposalDetails, result.UpdatedProposals, err = u.updateProposals(contracts, oldState.ProtocolDaoProposalDetails)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Link everything up and recalculate the node values that depend on balances and minipools
+	newState.UpdateIndexes()
+	for address := range recalculateNodes {
+		details := newState.NodeDetailsByAddress[address]
+		details.AverageNodeFee = big.NewInt(0)
+		details.DistributorBalanceNodeETH = big.NewInt(0)
+		details.DistributorBalanceUserETH = big.NewInt(0)
+		err = CalculateAverageFeeAndDistributorShares(u.rp, contracts, *details, newState.MinipoolDetailsByNode[address])
+		if err != nil {
+			return "", fmt.Errorf("error calculating average fee and distributor shares for node %s: %w", address.Hex(), err)
+		}
+	}
+
+	// Make sure no registrations or minipools were missed
+	nodeCount, err := node.GetNodeCount(u.rp, opts)
+	if err != nil {
+		return "", err
+	}
+	minipoolCount, err := minipool.GetMinipoolCount(u.rp, opts)
+	if err != nil {
+		return "", err
+	}
+	if reason := GetCountMismatchReason(newState, nodeCount, minipoolCount); reason != "" {
+		return reason, nil
+	}
+
+	u.state = newState
+	return "", u.setBlock(contracts)
+}
+
+// Refresh the proposals that were created or could have changed state
+func (u *StateUpdater) updateProposals(contracts *NetworkContracts, proposals []protocol.ProtocolDaoProposalDetails) ([]protocol.ProtocolDaoProposalDetails, int, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	proposalCount, err := protocol.GetTotalProposalCount(u.rp, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting proposal count: %w", err)
+	}
+	ids := []uint64{}
+	for _, proposal := range proposals {
+		if !isProposalFinished(proposal.State) {
+			ids = append(ids, proposal.ID)
+		}
+	}
+	for id := uint64(len(proposals)) + 1; id <= proposalCount; id++ {
+		ids = append(ids, id)
+	}
+	refetched, err := getProposalDetails(u.rp, contracts, ids, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting proposal details: %w", err)
+	}
+
+	updated := make([]protocol.ProtocolDaoProposalDetails, len(proposals), proposalCount)
+	copy(updated, proposals)
+	for _, proposal := range refetched {
+		if proposal.ID <= uint64(len(proposals)) {
+			updated[proposal.ID-1] = proposal
+		} else {
+			updated = append(updated, proposal)
+		}
+	}
+	return updated, len(refetched), nil
+}
+
+// Replace the state with a full snapshot at the contracts' block
+func (u *StateUpdater) reload(contracts *NetworkContracts) error {
+	state, err := NewNetworkState(u.rp, contracts)
+	if err != nil {
+		return err
+	}
+	u.state = state
+	return u.setBlock(contracts)
+}
+
+// Record the block the state is at, so upgrades and reorgs can be detected on the next update
+func (u *StateUpdater) setBlock(contracts *NetworkContracts) error {
+	header, err := u.rp.Client.HeaderByNumber(context.Background(), contracts.ElBlockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting header for block %s: %w", contracts.ElBlockNumber.String(), err)
+	}
+	minipoolDelegate, err := u.rp.GetAddress("rocketMinipoolDelegate", &bind.CallOpts{BlockNumber: contracts.ElBlockNumber})
+	if err != nil {
+		return err
+	}
+	u.contracts = contracts
+	u.blockHash = header.Hash()
+	u.minipoolDelegate = *minipoolDelegate
+	return nil
+}
+
+// Get the network contracts whose events can change node or minipool details
+func getWatchedContracts(contracts *NetworkContracts) []*rocketpool.Contract {
+	watched := []*rocketpool.Contract{
+		contracts.RocketStorage,
+		contracts.RocketDepositPool,
+		contracts.RocketMinipoolManager,
+		contracts.RocketMinipoolQueue,
+		contracts.RocketNodeDeposit,
+		contracts.RocketNodeDistributorFactory,
+		contracts.RocketNodeManager,
+		contracts.RocketNodeStaking,
+		contracts.RocketTokenRETH,
+		contracts.RocketTokenRPL,
+		contracts.RocketTokenRPLFixedSupply,
+	}
+	if contracts.RocketMinipoolBondReducer != nil {
+		watched = append(watched, contracts.RocketMinipoolBondReducer)
+	}
+	return watched
+}
+
+// Get the reason an upgrade between two sets of network contracts requires a full reload, or an empty string if there wasn't one
+func GetUpgradeReason(oldContracts *NetworkContracts, newContracts *NetworkContracts) string {
+	if newContracts.Version.String() != oldContracts.Version.String() {
+		return fmt.Sprintf("the network was upgraded from v%s to v%s", oldContracts.Version.Original(), newContracts.Version.Original())
+	}
+	if contractName := getUpgradedContract(oldContracts, newContracts); contractName != "" {
+		return fmt.Sprintf("contract %s was upgraded", contractName)
+	}
+	return ""
+}
+
+// Get the reason an updated state requires a full reload, or an empty string if it matches the network's node and minipool counts
+// A mismatch means a registration or minipool was missed by the events
+func GetCountMismatchReason(state *NetworkState, nodeCount uint64, minipoolCount uint64) string {
+	if nodeCount != uint64(len(state.NodeDetails)) {
+		return fmt.Sprintf("the node count %d doesn't match the %d nodes found from the events", nodeCount, len(state.NodeDetails))
+	}
+	if minipoolCount != uint64(len(state.MinipoolDetails)) {
+		return fmt.Sprintf("the minipool count %d doesn't match the %d minipools found from the events", minipoolCount, len(state.MinipoolDetails))
+	}
+	return ""
+}
+
+// Get the entities affected by the Rocket Pool events and the events the state's minipools emitted themselves
+// A minipool change marks its node as well; destroyed minipools are dropped rather than refetched
+func GetEventChanges(state *NetworkState, contracts *NetworkContracts, oracleDaoAddresses map[common.Address]bool, logs []ethtypes.Log, minipoolLogs []ethtypes.Log) *EventChanges {
+	changes := &EventChanges{
+		DirtyNodes:         map[common.Address]bool{},
+		DirtyMinipools:     map[common.Address]bool{},
+		NewNodes:           []common.Address{},
+		NewMinipools:       []common.Address{},
+		DestroyedMinipools: map[common.Address]bool{},
+	}
+	distributorNodes := make(map[common.Address]common.Address, len(state.NodeDetails))
+	for _, details := range state.NodeDetails {
+		distributorNodes[details.FeeDistributorAddress] = details.NodeAddress
+	}
+	nodeRegisteredID := contracts.RocketNodeManager.ABI.Events["NodeRegistered"].ID
+	minipoolCreatedID := contracts.RocketMinipoolManager.ABI.Events["MinipoolCreated"].ID
+	minipoolDestroyedID := contracts.RocketMinipoolManager.ABI.Events["MinipoolDestroyed"].ID
+	createdMinipools := []common.Address{}
+	dirtyMinipools := map[common.Address]bool{}
+	for _, log := range logs {
+		if oracleDaoAddresses[log.Address] {
+			changes.UpdateOracleDao = true
+		}
+		if len(log.Topics) > 1 && log.Address == *contracts.RocketNodeManager.Address && log.Topics[0] == nodeRegisteredID {
+			address := common.BytesToAddress(log.Topics[1].Bytes())
+			if _, exists := state.NodeDetailsByAddress[address]; !exists {
+				changes.NewNodes = append(changes.NewNodes, address)
+			}
+		}
+		if len(log.Topics) > 1 && log.Address == *contracts.RocketMinipoolManager.Address && log.Topics[0] == minipoolCreatedID {
+			address := common.BytesToAddress(log.Topics[1].Bytes())
+			if _, exists := state.MinipoolDetailsByAddress[address]; !exists {
+				createdMinipools = append(createdMinipools, address)
+			}
+		}
+		if len(log.Topics) > 1 && log.Address == *contracts.RocketMinipoolManager.Address && log.Topics[0] == minipoolDestroyedID {
+			changes.DestroyedMinipools[common.BytesToAddress(log.Topics[1].Bytes())] = true
+		}
+		for _, address := range GetLogAddresses(log) {
+			if _, exists := state.NodeDetailsByAddress[address]; exists {
+				changes.DirtyNodes[address] = true
+			}
+			if nodeAddress, exists := distributorNodes[address]; exists {
+				changes.DirtyNodes[nodeAddress] = true
+			}
+			if _, exists := state.MinipoolDetailsByAddress[address]; exists {
+				dirtyMinipools[address] = true
+			}
+		}
+	}
+	for _, log := range minipoolLogs {
+		if _, exists := state.MinipoolDetailsByAddress[log.Address]; exists {
+			dirtyMinipools[log.Address] = true
+		}
+	}
+
+	// Destroyed minipools are dropped rather than refetched, but their nodes still change
+	for _, address := range createdMinipools {
+		if !changes.DestroyedMinipools[address] {
+			changes.NewMinipools = append(changes.NewMinipools, address)
+		}
+	}
+	for address := range dirtyMinipools {
+		changes.MarkMinipool(state, address)
+	}
+	for address := range changes.DestroyedMinipools {
+		if details, exists := state.MinipoolDetailsByAddress[address]; exists {
+			changes.DirtyNodes[details.NodeAddress] = true
+		}
+	}
+	return changes
+}
+
+// Mark one of the state's minipools for refetching, along with its node
+// Destroyed minipools only mark their node, since they're dropped rather than refetched
+func (c *EventChanges) MarkMinipool(state *NetworkState, address common.Address) {
+	details, exists := state.MinipoolDetailsByAddress[address]
+	if !exists {
+		return
+	}
+	c.DirtyNodes[details.NodeAddress] = true
+	if !c.DestroyedMinipools[address] {
+		c.DirtyMinipools[address] = true
+	}
+}
+
+// Get the name of the first contract whose address differs between two sets of network contracts
+func getUpgradedContract(oldContracts *NetworkContracts, newContracts *NetworkContracts) string {
+	oldValue := reflect.ValueOf(*oldContracts)
+	newValue := reflect.ValueOf(*newContracts)
+	contractType := reflect.TypeOf(&rocketpool.Contract{})
+	for i := 0; i < oldValue.NumField(); i++ {
+		if oldValue.Field(i).Type() != contractType {
+			continue
+		}
+		oldContract := oldValue.Field(i).Interface().(*rocketpool.Contract)
+		newContract := newValue.Field(i).Interface().(*rocketpool.Contract)
+		if oldContract == nil || newContract == nil {
+			if oldContract != newContract {
+				return oldValue.Type().Field(i).Name
+			}
+			continue
+		}
+		if *oldContract.Address != *newContract.Address {
+			return newContract.Name
+		}
+	}
+	return ""
+}
+
+// Get the addresses an event refers to, from its indexed topics and its data words
+// Any word with 12 leading zero bytes counts, so small numbers come back too; they just won't match a node or minipool
+func GetLogAddresses(log ethtypes.Log) []common.Address {
+	addresses := []common.Address{}
+	words := make([][]byte, 0, len(log.Topics)+len(log.Data)/32)
+	for i, topic := range log.Topics {
+		if i > 0 {
+			// The first topic is the event ID
+			words = append(words, topic.Bytes())
+		}
+	}
+	for i := 0; i+32 <= len(log.Data); i += 32 {
+		words = append(words, log.Data[i:i+32])
+	}
+	for _, word := range words {
+		// Addresses are left-padded with zeros
+		if bytes.Equal(word[:12], make([]byte, 12)) {
+			addresses = append(addresses, common.BytesToAddress(word[12:]))
+		}
+	}
+	return addresses
+}
+
+// Get the changes to any of the given fields
+func filterFieldChanges(changes []Change, fields []string) []Change {
+	filtered := []Change{}
+	for _, change := range changes {
+		for _, field := range fields {
+			if change.Field == field {
+				filtered = append(filtered, change)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// Check if a proposal is in a state it can't leave
+func isProposalFinished(state types.ProtocolDaoProposalState) bool {
+	switch state {
+	case types.ProtocolDaoProposalState_Pending,
+		types.ProtocolDaoProposalState_ActivePhase1,
+		types.ProtocolDaoProposalState_ActivePhase2,
+		types.ProtocolDaoProposalState_Succeeded:
+		return false
+	}
+	return true
+}
+
+// Check if two big integers are equal, treating nil as distinct from zero
+func bigIntsEqual(a *big.Int, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Cmp(b) == 0
+}
+
+// Get the addresses in a set, sorted
+func sortAddresses(set map[common.Address]bool) []common.Address {
+	addresses := make([]common.Address, 0, len(set))
+	for address := range set {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses
+}